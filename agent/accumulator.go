@@ -23,10 +23,28 @@ type MetricMaker interface {
 	) telegraf.Metric
 }
 
+// errorRecorder is implemented by the plugins that keep their last error.
+type errorRecorder interface {
+	SetError(err error)
+}
+
+// logNamer is implemented by the plugins that have an alias, so that their
+// errors can be told apart from the other instances of the plugin.
+type logNamer interface {
+	LogName() string
+}
+
 func NewAccumulator(
 	maker MetricMaker,
 	metrics chan telegraf.Metric,
 ) telegraf.Accumulator {
+	return newAccumulator(maker, metrics)
+}
+
+func newAccumulator(
+	maker MetricMaker,
+	metrics chan telegraf.Metric,
+) *accumulator {
 	acc := accumulator{
 		maker:     maker,
 		metrics:   metrics,
@@ -41,6 +59,10 @@ type accumulator struct {
 	maker MetricMaker
 
 	precision time.Duration
+
+	// scheduled, when set, is used instead of the current time for metrics
+	// added without a timestamp.
+	scheduled time.Time
 }
 
 func (ac *accumulator) AddFields(
@@ -105,8 +127,15 @@ func (ac *accumulator) AddError(err error) {
 		return
 	}
 	NErrors.Incr(1)
+	if r, ok := ac.maker.(errorRecorder); ok {
+		r.SetError(err)
+	}
+	name := ac.maker.Name()
+	if n, ok := ac.maker.(logNamer); ok {
+		name = n.LogName()
+	}
 	//TODO suppress/throttle consecutive duplicate errors?
-	log.Printf("E! Error in plugin [%s]: %s", ac.maker.Name(), err)
+	log.Printf("E! [%s] Error in plugin: %s", name, err)
 }
 
 // SetPrecision takes two time.Duration objects. If the first is non-zero,
@@ -134,6 +163,8 @@ func (ac accumulator) getTime(t []time.Time) time.Time {
 	var timestamp time.Time
 	if len(t) > 0 {
 		timestamp = t[0]
+	} else if !ac.scheduled.IsZero() {
+		timestamp = ac.scheduled
 	} else {
 		timestamp = time.Now()
 	}
@@ -3,12 +3,14 @@ package agent
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal/models"
 	"github.com/influxdata/telegraf/metric"
 
 	"github.com/stretchr/testify/assert"
@@ -98,6 +100,24 @@ func TestAccAddError(t *testing.T) {
 	assert.Contains(t, string(errs[2]), "baz")
 }
 
+func TestAccAddErrorRecordsLastError(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	processor := models.NewRunningProcessor(nil,
+		&models.ProcessorConfig{Name: "lasterror"})
+	metrics := make(chan telegraf.Metric, 10)
+	defer close(metrics)
+	a := NewAccumulator(processorMaker{processor}, metrics)
+
+	a.AddError(fmt.Errorf("foo"))
+	a.AddError(fmt.Errorf("bar"))
+
+	assert.Equal(t, int64(2), processor.Errors())
+	msg, _ := processor.LastError()
+	assert.Equal(t, "bar", msg)
+}
+
 func TestAddNoIntervalWithPrecision(t *testing.T) {
 	now := time.Date(2006, time.February, 10, 12, 0, 0, 82912748, time.UTC)
 	metrics := make(chan telegraf.Metric, 10)
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -12,18 +13,38 @@ import (
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/internal/config"
 	"github.com/influxdata/telegraf/internal/models"
-	"github.com/influxdata/telegraf/selfstat"
+	"github.com/influxdata/telegraf/metric"
 )
 
 // Agent runs telegraf and collects data based on the given config
 type Agent struct {
 	Config *config.Config
+
+	router *models.Router
+
+	// gatherTriggers and flushTriggers request an immediate gather of an
+	// input or flush of an output.
+	gatherTriggers map[*models.RunningInput]chan struct{}
+	flushTriggers  map[*models.RunningOutput]chan struct{}
+
+	// taps receive the metrics passing through the pipeline.
+	taps taps
+
+	// now, newTimer and newTicker are the clock of the agent, replaced by
+	// the tests. newTimer and newTicker return the channel of the timer or
+	// ticker and the function stopping it.
+	now       func() time.Time
+	newTimer  func(d time.Duration) (<-chan time.Time, func() bool)
+	newTicker func(d time.Duration) (<-chan time.Time, func())
 }
 
 // NewAgent returns an Agent struct based off the given Config
 func NewAgent(config *config.Config) (*Agent, error) {
 	a := &Agent{
-		Config: config,
+		Config:    config,
+		now:       time.Now,
+		newTimer:  newTimer,
+		newTicker: newTicker,
 	}
 
 	if !a.Config.Agent.OmitHostname {
@@ -39,6 +60,17 @@ func NewAgent(config *config.Config) (*Agent, error) {
 		config.Tags["host"] = a.Config.Agent.Hostname
 	}
 
+	a.router = models.NewRouter(config.Routes, config.Outputs)
+
+	a.gatherTriggers = make(map[*models.RunningInput]chan struct{})
+	for _, input := range config.Inputs {
+		a.gatherTriggers[input] = make(chan struct{}, 1)
+	}
+	a.flushTriggers = make(map[*models.RunningOutput]chan struct{})
+	for _, output := range config.Outputs {
+		a.flushTriggers[output] = make(chan struct{}, 1)
+	}
+
 	return a, nil
 }
 
@@ -48,24 +80,24 @@ func (a *Agent) Connect() error {
 		switch ot := o.Output.(type) {
 		case telegraf.ServiceOutput:
 			if err := ot.Start(); err != nil {
-				log.Printf("E! Service for output %s failed to start, exiting\n%s\n",
-					o.Name, err.Error())
+				o.Log().Errorf("Service failed to start, exiting\n%s",
+					err.Error())
 				return err
 			}
 		}
 
-		log.Printf("D! Attempting connection to output: %s\n", o.Name)
+		o.Log().Debug("Attempting connection to output")
 		err := o.Output.Connect()
 		if err != nil {
-			log.Printf("E! Failed to connect to output %s, retrying in 15s, "+
-				"error was '%s' \n", o.Name, err)
+			o.Log().Errorf("Failed to connect to output, retrying in 15s, "+
+				"error was '%s'", err)
 			time.Sleep(15 * time.Second)
 			err = o.Output.Connect()
 			if err != nil {
 				return err
 			}
 		}
-		log.Printf("D! Successfully connected to output: %s\n", o.Name)
+		o.Log().Debug("Successfully connected to output")
 	}
 	return nil
 }
@@ -87,8 +119,8 @@ func panicRecover(input *models.RunningInput) {
 	if err := recover(); err != nil {
 		trace := make([]byte, 2048)
 		runtime.Stack(trace, true)
-		log.Printf("E! FATAL: Input [%s] panicked: %s, Stack:\n%s\n",
-			input.Name(), err, trace)
+		input.Log().Errorf("FATAL: Input panicked: %s, Stack:\n%s",
+			err, trace)
 		log.Println("E! PLEASE REPORT THIS PANIC ON GITHUB with " +
 			"stack trace, configuration, and OS information: " +
 			"https://github.com/influxdata/telegraf/issues/new")
@@ -105,110 +137,199 @@ func (a *Agent) gatherer(
 ) {
 	defer panicRecover(input)
 
-	GatherTime := selfstat.RegisterTiming("gather",
-		"gather_time_ns",
-		map[string]string{"input": input.Config.Name},
-	)
-
-	acc := NewAccumulator(input, metricC)
+	acc := newAccumulator(input, metricC)
 	acc.SetPrecision(a.Config.Agent.Precision.Duration,
 		a.Config.Agent.Interval.Duration)
 
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	for {
-		internal.RandomSleep(a.Config.Agent.CollectionJitter.Duration, shutdown)
+	// ctx is cancelled on shutdown, to stop any gather still running
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
 
+	gather := func(timeout time.Duration) {
 		start := time.Now()
-		gatherWithTimeout(shutdown, input, acc, interval)
+		if err := input.Gather(ctx, acc, timeout); err != nil {
+			acc.AddError(err)
+		}
 		elapsed := time.Since(start)
 
-		GatherTime.Incr(elapsed.Nanoseconds())
+		input.GatherTime.Incr(elapsed.Nanoseconds())
+	}
 
-		select {
-		case <-shutdown:
-			return
-		case <-ticker.C:
-			continue
+	// triggered gathers are not jittered nor given the scheduled time
+	triggerC := a.gatherTriggers[input]
+	triggered := func(timeout time.Duration) {
+		acc.scheduled = time.Time{}
+		gather(timeout)
+	}
+
+	if schedule := input.Config.Schedule; schedule != nil {
+		for {
+			now := a.now()
+			next := schedule.Next(now)
+			timerC, stop := a.newTimer(next.Sub(now))
+			select {
+			case <-shutdown:
+				stop()
+				return
+			case <-triggerC:
+				stop()
+				triggered(input.Config.Timeout)
+				continue
+			case <-timerC:
+			}
+
+			internal.RandomSleep(schedule.Jitter, shutdown)
+
+			// by default a gather may run until the next scheduled one
+			timeout := input.Config.Timeout
+			if timeout <= 0 {
+				timeout = schedule.Next(next).Sub(next)
+			}
+
+			acc.scheduled = next
+			gather(timeout)
 		}
 	}
-}
 
-// gatherWithTimeout gathers from the given input, with the given timeout.
-//   when the given timeout is reached, gatherWithTimeout logs an error message
-//   but continues waiting for it to return. This is to avoid leaving behind
-//   hung processes, and to prevent re-calling the same hung process over and
-//   over.
-func gatherWithTimeout(
-	shutdown chan struct{},
-	input *models.RunningInput,
-	acc telegraf.Accumulator,
-	timeout time.Duration,
-) {
-	ticker := time.NewTicker(timeout)
-	defer ticker.Stop()
-	done := make(chan error)
-	go func() {
-		done <- input.Input.Gather(acc)
-	}()
+	timeout := gatherTimeout(input, interval)
+	tickC, stop := a.newTicker(interval)
+	defer stop()
 
+gatherLoop:
 	for {
-		select {
-		case err := <-done:
-			if err != nil {
-				acc.AddError(err)
+		internal.RandomSleep(a.Config.Agent.CollectionJitter.Duration, shutdown)
+
+		gather(timeout)
+
+		for {
+			select {
+			case <-shutdown:
+				return
+			case <-triggerC:
+				triggered(timeout)
+			case <-tickC:
+				continue gatherLoop
 			}
-			return
-		case <-ticker.C:
-			err := fmt.Errorf("took longer to collect than collection interval (%s)",
-				timeout)
-			acc.AddError(err)
-			continue
-		case <-shutdown:
-			return
 		}
 	}
 }
 
+func newTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	timer := time.NewTimer(d)
+	return timer.C, timer.Stop
+}
+
+func newTicker(d time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(d)
+	return ticker.C, ticker.Stop
+}
+
+// gatherTimeout returns how long a single gather of the input may take.
+func gatherTimeout(input *models.RunningInput, interval time.Duration) time.Duration {
+	if input.Config.Timeout > 0 {
+		return input.Config.Timeout
+	}
+	return interval
+}
+
 // Test verifies that we can 'Gather' from all inputs with their configured
-// Config struct
-func (a *Agent) Test() error {
-	shutdown := make(chan struct{})
-	defer close(shutdown)
-	metricC := make(chan telegraf.Metric)
+// Config struct. The gathered metrics are run through the processors and
+// aggregators, and the metrics each output would receive are printed. Service
+// inputs are only started if wait is set, and run for that long.
+func (a *Agent) Test(wait time.Duration) error {
+	var mu sync.Mutex
+	var gathered []telegraf.Metric
+	collect := func(m telegraf.Metric) {
+		mu.Lock()
+		gathered = append(gathered, m)
+		mu.Unlock()
+	}
 
-	// dummy receiver for the point channel
+	stopProcessors, err := a.startProcessors(a.Config.Processors, collect)
+	if err != nil {
+		return err
+	}
+
+	// receiver for the point channel, running the processors on each metric
+	// like the flusher does
+	shutdown := make(chan struct{})
+	done := make(chan struct{})
+	metricC := make(chan telegraf.Metric, 100)
 	go func() {
+		defer close(done)
 		for {
 			select {
-			case <-metricC:
-				// do nothing
+			case m := <-metricC:
+				for _, m := range a.applyProcessors(a.Config.Processors, 0, m) {
+					collect(m)
+				}
 			case <-shutdown:
+				if len(metricC) > 0 {
+					// keep going until metricC is flushed
+					continue
+				}
 				return
 			}
 		}
 	}()
 
+	// stop processes the metrics left and stops the processors, it is
+	// deferred so that they are stopped when gathering fails
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(shutdown)
+			<-done
+			stopProcessors()
+		})
+	}
+	defer stop()
+
+	var services []telegraf.ServiceInput
 	for _, input := range a.Config.Inputs {
-		if _, ok := input.Input.(telegraf.ServiceInput); ok {
-			fmt.Printf("\nWARNING: skipping plugin [[%s]]: service inputs not supported in --test mode\n",
-				input.Name())
+		input.SetTrace(true)
+		input.SetDefaultTags(a.Config.Tags)
+
+		if p, ok := input.Input.(telegraf.ServiceInput); ok {
+			if wait <= 0 {
+				fmt.Printf("\nWARNING: skipping plugin [[%s]]: service inputs "+
+					"are only started in --test mode if --test-wait is set\n",
+					input.LogName())
+				continue
+			}
+
+			acc := NewAccumulator(input, metricC)
+			acc.SetPrecision(time.Nanosecond, 0)
+			fmt.Printf("* Plugin: %s, Starting service for %s\n", input.LogName(), wait)
+			if err := p.Start(acc); err != nil {
+				stopServices(services)
+				return err
+			}
+			services = append(services, p)
 			continue
 		}
 
 		acc := NewAccumulator(input, metricC)
 		acc.SetPrecision(a.Config.Agent.Precision.Duration,
 			a.Config.Agent.Interval.Duration)
-		input.SetTrace(true)
-		input.SetDefaultTags(a.Config.Tags)
 
-		fmt.Printf("* Plugin: %s, Collection 1\n", input.Name())
+		interval := a.Config.Agent.Interval.Duration
+		fmt.Printf("* Plugin: %s, Collection 1\n", input.LogName())
 		if input.Config.Interval != 0 {
+			interval = input.Config.Interval
 			fmt.Printf("* Internal: %s\n", input.Config.Interval)
 		}
+		timeout := gatherTimeout(input, interval)
 
-		if err := input.Input.Gather(acc); err != nil {
+		if err := input.Gather(context.Background(), acc, timeout); err != nil {
+			stopServices(services)
 			return err
 		}
 
@@ -217,16 +338,137 @@ func (a *Agent) Test() error {
 		switch input.Name() {
 		case "inputs.cpu", "inputs.mongodb", "inputs.procstat":
 			time.Sleep(500 * time.Millisecond)
-			fmt.Printf("* Plugin: %s, Collection 2\n", input.Name())
-			if err := input.Input.Gather(acc); err != nil {
+			fmt.Printf("* Plugin: %s, Collection 2\n", input.LogName())
+			if err := input.Gather(context.Background(), acc, timeout); err != nil {
+				stopServices(services)
 				return err
 			}
 		}
+	}
+
+	if len(services) > 0 {
+		time.Sleep(wait)
+		stopServices(services)
+	}
+
+	stop()
+
+	return a.printTest(gathered)
+}
+
+// stopServices stops the service inputs started in --test mode.
+func stopServices(services []telegraf.ServiceInput) {
+	for _, p := range services {
+		p.Stop()
+	}
+}
+
+// printTest aggregates the gathered metrics once and prints the metrics that
+// would be written by each output, serialized in its data format.
+func (a *Agent) printTest(gathered []telegraf.Metric) error {
+	metrics := make([]telegraf.Metric, 0, len(gathered))
+	for _, m := range gathered {
+		var dropOriginal bool
+		for _, agg := range a.Config.Aggregators {
+			if ok := agg.TestAdd(m.Copy()); ok {
+				dropOriginal = true
+			}
+		}
+		if !dropOriginal {
+			metrics = append(metrics, m)
+		}
+	}
+
+	for _, agg := range a.Config.Aggregators {
+		aggC := make(chan telegraf.Metric, 100)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for m := range aggC {
+				metrics = append(metrics, a.applyAggregateProcessors(m)...)
+			}
+		}()
+
+		fmt.Printf("* Aggregator: %s\n", agg.LogName())
+		agg.TestPush(NewAccumulator(agg, aggC))
+		close(aggC)
+		<-done
+	}
+
+	metrics, err := a.testAggProcessors(metrics)
+	if err != nil {
+		return err
+	}
+
+	if len(a.Config.Outputs) == 0 {
+		fmt.Printf("* No outputs, %d metrics\n", len(metrics))
+		for _, m := range metrics {
+			fmt.Print("> " + m.String())
+		}
+		return nil
+	}
 
+	written := make(map[*models.RunningOutput][]telegraf.Metric)
+	for _, m := range metrics {
+		outputs := a.Config.Outputs
+		if a.router != nil {
+			outputs = a.router.Outputs(m)
+		}
+		for _, o := range outputs {
+			if fm := o.FilterMetric(m.Copy()); fm != nil {
+				written[o] = append(written[o], fm)
+			}
+		}
+	}
+
+	for _, o := range a.Config.Outputs {
+		name := o.Name
+		if o.Config.Alias != "" {
+			name += "::" + o.Config.Alias
+		}
+		fmt.Printf("* Output: %s, %d metrics\n", name, len(written[o]))
+		for _, m := range written[o] {
+			if o.Serializer == nil {
+				fmt.Print("> " + m.String())
+				continue
+			}
+			b, err := o.Serializer.Serialize(m)
+			if err != nil {
+				fmt.Printf("E! could not serialize %s: %s\n", m.Name(), err)
+				continue
+			}
+			fmt.Print(string(b))
+		}
 	}
 	return nil
 }
 
+// testAggProcessors runs the metrics leaving the aggregators through the
+// processors that follow them.
+func (a *Agent) testAggProcessors(metrics []telegraf.Metric) ([]telegraf.Metric, error) {
+	if len(a.Config.AggProcessors) == 0 {
+		return metrics, nil
+	}
+
+	var mu sync.Mutex
+	processed := make([]telegraf.Metric, 0, len(metrics))
+	collect := func(m telegraf.Metric) {
+		mu.Lock()
+		processed = append(processed, m)
+		mu.Unlock()
+	}
+
+	stopProcessors, err := a.startProcessors(a.Config.AggProcessors, collect)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range a.applyProcessors(a.Config.AggProcessors, 0, metrics...) {
+		collect(m)
+	}
+	stopProcessors()
+	return processed, nil
+}
+
 // flush writes a list of metrics to all configured outputs
 func (a *Agent) flush() {
 	var wg sync.WaitGroup
@@ -235,17 +477,228 @@ func (a *Agent) flush() {
 	for _, o := range a.Config.Outputs {
 		go func(output *models.RunningOutput) {
 			defer wg.Done()
-			err := output.Write()
-			if err != nil {
-				log.Printf("E! Error writing to output [%s]: %s\n",
-					output.Name, err.Error())
-			}
+			flushOutput(output)
 		}(o)
 	}
 
 	wg.Wait()
 }
 
+// flushOutput writes the buffered metrics of a single output.
+func flushOutput(output *models.RunningOutput) {
+	err := output.Write()
+	if err != nil {
+		// Write has already counted the error, log it without the logger of
+		// the output so that it is not counted twice.
+		log.Printf("E! [%s] Error writing to output: %s",
+			output.LogName(), err.Error())
+	}
+}
+
+// flushLoop flushes the output on its own flush interval until shutdown, so
+// that a slow output does not delay the flushes of the others.
+func (a *Agent) flushLoop(shutdown chan struct{}, output *models.RunningOutput) {
+	interval := a.Config.Agent.FlushInterval.Duration
+	if output.Config.FlushInterval > 0 {
+		interval = output.Config.FlushInterval
+	}
+	jitter := a.Config.Agent.FlushJitter.Duration
+	if output.Config.FlushJitter > 0 {
+		jitter = output.Config.FlushJitter
+	}
+
+	tickC, stop := a.newTicker(interval)
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	semaphore := make(chan struct{}, 1)
+	flush := func(jitter time.Duration) {
+		select {
+		case semaphore <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				internal.RandomSleep(jitter, shutdown)
+				flushOutput(output)
+				<-semaphore
+			}()
+		default:
+			// skipping this flush because one is already happening
+			output.Log().Warn("Skipping a scheduled flush because there is " +
+				"already a flush ongoing.")
+		}
+	}
+
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-a.flushTriggers[output]:
+			flush(0)
+		case <-tickC:
+			flush(jitter)
+		}
+	}
+}
+
+// route runs the metric through the processors that follow the aggregators,
+// and sends the results to the outputs.
+func (a *Agent) route(m telegraf.Metric) {
+	for _, m := range a.applyProcessors(a.Config.AggProcessors, 0, m) {
+		a.send(m)
+	}
+}
+
+// send adds the metric to each output selected by the configured routes.
+func (a *Agent) send(m telegraf.Metric) {
+	a.taps.send(stageOutputs, m)
+	outputs := a.Config.Outputs
+	if a.router != nil {
+		outputs = a.router.Outputs(m)
+	}
+	for i, o := range outputs {
+		if i == len(outputs)-1 {
+			o.AddMetric(m)
+		} else {
+			o.AddMetric(m.Copy())
+		}
+	}
+}
+
+// aggregate adds the metric to the aggregators, and routes it to the outputs
+// unless an aggregator drops the original.
+func (a *Agent) aggregate(m telegraf.Metric) {
+	a.taps.send(stageProcessors, m)
+	// if dropOriginal is set to true, then we will only send this
+	// metric to the aggregators, not the outputs.
+	var dropOriginal bool
+	if !m.IsAggregate() {
+		for _, agg := range a.Config.Aggregators {
+			if ok := agg.Add(m.Copy()); ok {
+				dropOriginal = true
+			}
+		}
+	}
+	if !dropOriginal {
+		a.route(m)
+	}
+}
+
+// applyProcessors runs the metrics through the processors, starting with the
+// processor at index start.
+func (a *Agent) applyProcessors(
+	processors models.RunningProcessors,
+	start int,
+	metrics ...telegraf.Metric,
+) []telegraf.Metric {
+	for _, processor := range processors[start:] {
+		metrics = processor.Apply(metrics...)
+	}
+	return metrics
+}
+
+// applyAggregateProcessors runs the aggregates through the processors,
+// skipping the streaming processors. The metrics emitted by a streaming
+// processor can't be told apart from the gathered metrics, so the aggregates
+// would be added to the aggregators again.
+func (a *Agent) applyAggregateProcessors(metrics ...telegraf.Metric) []telegraf.Metric {
+	for _, processor := range a.Config.Processors {
+		if _, ok := processor.Processor.(telegraf.StreamingProcessor); ok {
+			continue
+		}
+		metrics = processor.Apply(metrics...)
+	}
+	return metrics
+}
+
+// startProcessors starts the streaming processors. The metrics they emit go
+// through the processors that follow them, and are then passed to sink. The
+// returned function stops the processors and waits until all of their
+// metrics have been handled. The processors that follow are then applied
+// concurrently with the gathered metrics, RunningProcessor.Apply serializes
+// the calls of each processor.
+func (a *Agent) startProcessors(
+	processors models.RunningProcessors,
+	sink func(telegraf.Metric),
+) (func(), error) {
+	var wg sync.WaitGroup
+	var started []telegraf.StreamingProcessor
+	var channels []chan telegraf.Metric
+
+	stop := func() {
+		for _, sp := range started {
+			sp.Stop()
+		}
+		for _, c := range channels {
+			close(c)
+		}
+		wg.Wait()
+	}
+
+	for i, processor := range processors {
+		sp, ok := processor.Processor.(telegraf.StreamingProcessor)
+		if !ok {
+			continue
+		}
+
+		procC := make(chan telegraf.Metric, 100)
+		acc := NewAccumulator(processorMaker{processor}, procC)
+		if err := sp.Start(acc); err != nil {
+			stop()
+			return nil, fmt.Errorf("processor %s failed to start: %s",
+				processor.LogName(), err)
+		}
+		started = append(started, sp)
+		channels = append(channels, procC)
+
+		wg.Add(1)
+		go func(next int) {
+			defer wg.Done()
+			for m := range procC {
+				for _, m := range a.applyProcessors(processors, next, m) {
+					sink(m)
+				}
+			}
+		}(i + 1)
+	}
+
+	return stop, nil
+}
+
+// processorMaker creates the metrics added by streaming processors.
+type processorMaker struct {
+	processor *models.RunningProcessor
+}
+
+func (p processorMaker) Name() string {
+	return "processors." + p.processor.Name
+}
+
+func (p processorMaker) LogName() string {
+	return p.processor.LogName()
+}
+
+func (p processorMaker) SetError(err error) {
+	p.processor.SetError(err)
+}
+
+func (p processorMaker) MakeMetric(
+	measurement string,
+	fields map[string]interface{},
+	tags map[string]string,
+	mType telegraf.ValueType,
+	t time.Time,
+) telegraf.Metric {
+	m, err := metric.New(measurement, tags, fields, t, mType)
+	if err != nil {
+		p.processor.Log().Errorf("Error in plugin: %s", err)
+		return nil
+	}
+	return m
+}
+
 // flusher monitors the metrics input channel and flushes on the minimum interval
 func (a *Agent) flusher(shutdown chan struct{}, metricC chan telegraf.Metric, aggC chan telegraf.Metric) error {
 	// Inelegant, but this sleep is to allow the Gather threads to run, so that
@@ -268,29 +721,21 @@ func (a *Agent) flusher(shutdown chan struct{}, metricC chan telegraf.Metric, ag
 				}
 				return
 			case m := <-outMetricC:
-				// if dropOriginal is set to true, then we will only send this
-				// metric to the aggregators, not the outputs.
-				var dropOriginal bool
-				if !m.IsAggregate() {
-					for _, agg := range a.Config.Aggregators {
-						if ok := agg.Add(m.Copy()); ok {
-							dropOriginal = true
-						}
-					}
-				}
-				if !dropOriginal {
-					for i, o := range a.Config.Outputs {
-						if i == len(a.Config.Outputs)-1 {
-							o.AddMetric(m)
-						} else {
-							o.AddMetric(m.Copy())
-						}
-					}
-				}
+				a.aggregate(m)
 			}
 		}
 	}()
 
+	stopAggProcessors, err := a.startProcessors(a.Config.AggProcessors, a.send)
+	if err != nil {
+		return err
+	}
+	stopProcessors, err := a.startProcessors(a.Config.Processors, a.aggregate)
+	if err != nil {
+		stopAggProcessors()
+		return err
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -303,53 +748,40 @@ func (a *Agent) flusher(shutdown chan struct{}, metricC chan telegraf.Metric, ag
 				}
 				return
 			case metric := <-aggC:
-				metrics := []telegraf.Metric{metric}
-				for _, processor := range a.Config.Processors {
-					metrics = processor.Apply(metrics...)
-				}
+				metrics := a.applyAggregateProcessors(metric)
 				for _, m := range metrics {
-					for i, o := range a.Config.Outputs {
-						if i == len(a.Config.Outputs)-1 {
-							o.AddMetric(m)
-						} else {
-							o.AddMetric(m.Copy())
-						}
-					}
+					a.route(m)
 				}
 			}
 		}
 	}()
 
-	ticker := time.NewTicker(a.Config.Agent.FlushInterval.Duration)
-	semaphore := make(chan struct{}, 1)
+	var flushWg sync.WaitGroup
+	for _, o := range a.Config.Outputs {
+		flushWg.Add(1)
+		go func(output *models.RunningOutput) {
+			defer flushWg.Done()
+			a.flushLoop(shutdown, output)
+		}(o)
+	}
+
 	for {
 		select {
 		case <-shutdown:
 			log.Println("I! Hang on, flushing any cached metrics before shutdown")
 			// wait for outMetricC to get flushed before flushing outputs
 			wg.Wait()
+			stopProcessors()
+			stopAggProcessors()
+			// wait for scheduled flushes to finish before the final one
+			flushWg.Wait()
 			a.flush()
 			return nil
-		case <-ticker.C:
-			go func() {
-				select {
-				case semaphore <- struct{}{}:
-					internal.RandomSleep(a.Config.Agent.FlushJitter.Duration, shutdown)
-					a.flush()
-					<-semaphore
-				default:
-					// skipping this flush because one is already happening
-					log.Println("W! Skipping a scheduled flush because there is" +
-						" already a flush ongoing.")
-				}
-			}()
 		case metric := <-metricC:
+			a.taps.send(stageInputs, metric)
 			// NOTE potential bottleneck here as we put each metric through the
 			// processors serially.
-			mS := []telegraf.Metric{metric}
-			for _, processor := range a.Config.Processors {
-				mS = processor.Apply(mS...)
-			}
+			mS := a.applyProcessors(a.Config.Processors, 0, metric)
 			for _, m := range mS {
 				outMetricC <- m
 			}
@@ -380,14 +812,21 @@ func (a *Agent) Run(shutdown chan struct{}) error {
 			// metrics.
 			acc.SetPrecision(time.Nanosecond, 0)
 			if err := p.Start(acc); err != nil {
-				log.Printf("E! Service for input %s failed to start, exiting\n%s\n",
-					input.Name(), err.Error())
+				input.Log().Errorf("Service failed to start, exiting\n%s",
+					err.Error())
 				return err
 			}
 			defer p.Stop()
 		}
 	}
 
+	if a.Config.Agent.APIAddress != "" {
+		if err := a.serveAPI(shutdown); err != nil {
+			log.Printf("E! Error starting the agent API: %s", err)
+			return err
+		}
+	}
+
 	// Round collection to nearest interval by sleeping
 	if a.Config.Agent.RoundInterval {
 		i := int64(a.Config.Agent.Interval.Duration)
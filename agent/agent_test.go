@@ -1,9 +1,19 @@
 package agent
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/internal/config"
+	"github.com/influxdata/telegraf/internal/models"
+	"github.com/influxdata/telegraf/plugins/serializers"
+	"github.com/influxdata/telegraf/testutil"
 
 	// needing to load the plugins
 	_ "github.com/influxdata/telegraf/plugins/inputs/all"
@@ -11,6 +21,7 @@ import (
 	_ "github.com/influxdata/telegraf/plugins/outputs/all"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAgent_OmitHostname(t *testing.T) {
@@ -109,3 +120,502 @@ func TestAgent_LoadOutput(t *testing.T) {
 	a, _ = NewAgent(c)
 	assert.Equal(t, 3, len(a.Config.Outputs))
 }
+
+type testInput struct{}
+
+func (i *testInput) SampleConfig() string { return "" }
+func (i *testInput) Description() string  { return "" }
+func (i *testInput) Gather(acc telegraf.Accumulator) error {
+	acc.AddFields("cpu", map[string]interface{}{"value": int64(1)}, nil, time.Unix(0, 0))
+	return nil
+}
+
+// slowInput takes a while to gather and does not set a timestamp.
+type slowInput struct{}
+
+func (i *slowInput) SampleConfig() string { return "" }
+func (i *slowInput) Description() string  { return "" }
+func (i *slowInput) Gather(acc telegraf.Accumulator) error {
+	time.Sleep(100 * time.Millisecond)
+	acc.AddFields("slow", map[string]interface{}{"value": int64(1)}, nil)
+	return nil
+}
+
+// failingInput fails to gather.
+type failingInput struct{}
+
+func (i *failingInput) SampleConfig() string { return "" }
+func (i *failingInput) Description() string  { return "" }
+func (i *failingInput) Gather(acc telegraf.Accumulator) error {
+	return errors.New("gather failed")
+}
+
+type testServiceInput struct {
+	testInput
+	started bool
+}
+
+func (i *testServiceInput) Gather(acc telegraf.Accumulator) error { return nil }
+func (i *testServiceInput) Start(acc telegraf.Accumulator) error {
+	i.started = true
+	acc.AddFields("svc", map[string]interface{}{"value": int64(2)}, nil, time.Unix(0, 0))
+	return nil
+}
+func (i *testServiceInput) Stop() {}
+
+type testProcessor struct{}
+
+func (p *testProcessor) SampleConfig() string { return "" }
+func (p *testProcessor) Description() string  { return "" }
+func (p *testProcessor) Apply(in ...telegraf.Metric) []telegraf.Metric {
+	for _, m := range in {
+		m.AddTag("processed", "true")
+	}
+	return in
+}
+
+// testStreamingProcessor emits the metrics it is given through the
+// accumulator, recording their names.
+type testStreamingProcessor struct {
+	acc telegraf.Accumulator
+
+	sync.Mutex
+	applied []string
+	stopped bool
+}
+
+func (p *testStreamingProcessor) SampleConfig() string { return "" }
+func (p *testStreamingProcessor) Description() string  { return "" }
+func (p *testStreamingProcessor) Start(acc telegraf.Accumulator) error {
+	p.acc = acc
+	return nil
+}
+func (p *testStreamingProcessor) Stop() {
+	p.Lock()
+	defer p.Unlock()
+	p.stopped = true
+}
+func (p *testStreamingProcessor) Apply(in ...telegraf.Metric) []telegraf.Metric {
+	p.Lock()
+	defer p.Unlock()
+	for _, m := range in {
+		p.applied = append(p.applied, m.Name())
+		p.acc.AddFields(m.Name(), m.Fields(), m.Tags(), m.Time())
+	}
+	return nil
+}
+
+func (p *testStreamingProcessor) Applied() []string {
+	p.Lock()
+	defer p.Unlock()
+	return append([]string(nil), p.applied...)
+}
+
+func (p *testStreamingProcessor) Stopped() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.stopped
+}
+
+type testAggregator struct {
+	count int64
+}
+
+func (a *testAggregator) SampleConfig() string   { return "" }
+func (a *testAggregator) Description() string    { return "" }
+func (a *testAggregator) Add(in telegraf.Metric) { a.count++ }
+func (a *testAggregator) Push(acc telegraf.Accumulator) {
+	acc.AddFields("count", map[string]interface{}{"value": a.count}, nil, time.Unix(0, 0))
+}
+func (a *testAggregator) Reset() { a.count = 0 }
+
+type testOutput struct{}
+
+func (o *testOutput) Connect() error                { return nil }
+func (o *testOutput) Close() error                  { return nil }
+func (o *testOutput) Description() string           { return "" }
+func (o *testOutput) SampleConfig() string          { return "" }
+func (o *testOutput) Write([]telegraf.Metric) error { return nil }
+
+// recordingOutput records the names of the metrics written.
+type recordingOutput struct {
+	testOutput
+
+	sync.Mutex
+	written []string
+}
+
+func (o *recordingOutput) Write(metrics []telegraf.Metric) error {
+	o.Lock()
+	defer o.Unlock()
+	for _, m := range metrics {
+		o.written = append(o.written, m.Name())
+	}
+	return nil
+}
+
+// blockingOutput counts its writes, blocking each until release is closed.
+// Each write is signalled on written when it is set.
+type blockingOutput struct {
+	testOutput
+	release chan struct{}
+	written chan struct{}
+
+	sync.Mutex
+	writes int
+}
+
+func (o *blockingOutput) Write([]telegraf.Metric) error {
+	<-o.release
+	o.Lock()
+	o.writes++
+	o.Unlock()
+	if o.written != nil {
+		o.written <- struct{}{}
+	}
+	return nil
+}
+
+func (o *blockingOutput) Writes() int {
+	o.Lock()
+	defer o.Unlock()
+	return o.writes
+}
+
+// captureStdout returns what f prints to stdout.
+func captureStdout(t *testing.T, f func()) string {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	out := make(chan string)
+	go func() {
+		b, _ := ioutil.ReadAll(r)
+		out <- string(b)
+	}()
+
+	f()
+	w.Close()
+	return <-out
+}
+
+func TestAgent_TestPipeline(t *testing.T) {
+	c := config.NewConfig()
+	c.Agent.OmitHostname = true
+
+	service := &testServiceInput{}
+	c.Inputs = []*models.RunningInput{
+		models.NewRunningInput(&testInput{}, &models.InputConfig{Name: "test"}),
+		models.NewRunningInput(service, &models.InputConfig{Name: "service"}),
+	}
+	c.Processors = models.RunningProcessors{{
+		Name:      "test",
+		Processor: &testProcessor{},
+		Config:    &models.ProcessorConfig{Name: "test"},
+	}}
+
+	aggFilter := models.Filter{NamePass: []string{"cpu"}}
+	require.NoError(t, aggFilter.Compile())
+	c.Aggregators = []*models.RunningAggregator{
+		models.NewRunningAggregator(&testAggregator{}, &models.AggregatorConfig{
+			Name:         "test",
+			DropOriginal: true,
+			Filter:       aggFilter,
+		}),
+	}
+
+	serializer, err := serializers.NewInfluxSerializer()
+	require.NoError(t, err)
+	influx := models.NewRunningOutput("influx", &testOutput{},
+		&models.OutputConfig{Name: "influx"}, 0, 0)
+	influx.Serializer = serializer
+
+	outFilter := models.Filter{NameDrop: []string{"svc"}}
+	require.NoError(t, outFilter.Compile())
+	filtered := models.NewRunningOutput("filtered", &testOutput{},
+		&models.OutputConfig{Name: "filtered", Filter: outFilter}, 0, 0)
+	c.Outputs = []*models.RunningOutput{influx, filtered}
+
+	a, err := NewAgent(c)
+	require.NoError(t, err)
+
+	out := captureStdout(t, func() {
+		require.NoError(t, a.Test(10*time.Millisecond))
+	})
+
+	assert.True(t, service.started)
+	assert.Contains(t, out, "* Output: influx, 2 metrics\n"+
+		"svc,processed=true value=2i 0\n"+
+		"count,processed=true value=1i 0\n")
+	assert.Contains(t, out, "* Output: filtered, 1 metrics\n"+
+		"> count,processed=true value=1i 0\n")
+}
+
+func TestAgent_TestAggProcessors(t *testing.T) {
+	c := config.NewConfig()
+	c.Agent.OmitHostname = true
+
+	c.Inputs = []*models.RunningInput{
+		models.NewRunningInput(&testInput{}, &models.InputConfig{Name: "test"}),
+	}
+	c.AggProcessors = models.RunningProcessors{
+		models.NewRunningProcessor(&testProcessor{}, &models.ProcessorConfig{
+			Name:             "test",
+			AfterAggregators: true,
+		}),
+	}
+	c.Aggregators = models.RunningAggregators{
+		models.NewRunningAggregator(&testAggregator{}, &models.AggregatorConfig{
+			Name: "test",
+		}),
+	}
+
+	a, err := NewAgent(c)
+	require.NoError(t, err)
+
+	out := captureStdout(t, func() {
+		require.NoError(t, a.Test(0))
+	})
+
+	// both the original and the aggregate are processed
+	assert.Contains(t, out, "* No outputs, 2 metrics\n"+
+		"> cpu,processed=true value=1i 0\n"+
+		"> count,processed=true value=1i 0\n")
+}
+
+func TestAgent_TestSkipsServiceInputs(t *testing.T) {
+	c := config.NewConfig()
+	c.Agent.OmitHostname = true
+
+	service := &testServiceInput{}
+	c.Inputs = []*models.RunningInput{
+		models.NewRunningInput(service, &models.InputConfig{Name: "service"}),
+	}
+
+	a, err := NewAgent(c)
+	require.NoError(t, err)
+
+	out := captureStdout(t, func() {
+		require.NoError(t, a.Test(0))
+	})
+
+	assert.False(t, service.started)
+	assert.Contains(t, out, "skipping plugin [[inputs.service]]")
+	assert.Contains(t, out, "* No outputs, 0 metrics\n")
+}
+
+func TestAgent_TestStopsProcessorsOnError(t *testing.T) {
+	c := config.NewConfig()
+	c.Agent.OmitHostname = true
+
+	c.Inputs = []*models.RunningInput{
+		models.NewRunningInput(&failingInput{}, &models.InputConfig{Name: "failing"}),
+	}
+	streaming := &testStreamingProcessor{}
+	c.Processors = models.RunningProcessors{
+		models.NewRunningProcessor(streaming, &models.ProcessorConfig{Name: "streaming"}),
+	}
+
+	a, err := NewAgent(c)
+	require.NoError(t, err)
+
+	captureStdout(t, func() {
+		assert.Error(t, a.Test(0))
+	})
+	assert.True(t, streaming.Stopped())
+}
+
+// manualTickers replaces the tickers of an agent with channels ticked by the
+// test, keyed by their period.
+type manualTickers struct {
+	sync.Mutex
+	tickers map[time.Duration]chan time.Time
+	created chan struct{}
+}
+
+func newManualTickers(a *Agent) *manualTickers {
+	m := &manualTickers{
+		tickers: make(map[time.Duration]chan time.Time),
+		created: make(chan struct{}, 10),
+	}
+	a.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+		m.Lock()
+		defer m.Unlock()
+		tickC := make(chan time.Time)
+		m.tickers[d] = tickC
+		m.created <- struct{}{}
+		return tickC, func() {}
+	}
+	return m
+}
+
+// tick ticks the ticker with period d, once its loop is ready for it.
+func (m *manualTickers) tick(d time.Duration) {
+	m.Lock()
+	tickC := m.tickers[d]
+	m.Unlock()
+	tickC <- time.Now()
+}
+
+func TestAgent_FlushLoopPerOutput(t *testing.T) {
+	c := config.NewConfig()
+	c.Agent.FlushInterval.Duration = time.Hour
+
+	release := make(chan struct{})
+	close(release)
+	fastOutput := &blockingOutput{release: release, written: make(chan struct{})}
+	slowOutput := &blockingOutput{release: make(chan struct{})}
+	idleOutput := &blockingOutput{release: release}
+
+	fast := models.NewRunningOutput("fast", fastOutput,
+		&models.OutputConfig{Name: "fast", FlushInterval: time.Second}, 0, 0)
+	slow := models.NewRunningOutput("slow", slowOutput,
+		&models.OutputConfig{Name: "slow", FlushInterval: time.Minute}, 0, 0)
+	idle := models.NewRunningOutput("idle", idleOutput,
+		&models.OutputConfig{Name: "idle"}, 0, 0)
+	c.Outputs = []*models.RunningOutput{fast, slow, idle}
+
+	a, err := NewAgent(c)
+	require.NoError(t, err)
+	tickers := newManualTickers(a)
+
+	shutdown := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, o := range c.Outputs {
+		wg.Add(1)
+		go func(o *models.RunningOutput) {
+			defer wg.Done()
+			a.flushLoop(shutdown, o)
+		}(o)
+	}
+	for range c.Outputs {
+		<-tickers.created
+	}
+
+	// the idle output uses the flush interval of the agent
+	tickers.Lock()
+	assert.Len(t, tickers.tickers, 3)
+	assert.Contains(t, tickers.tickers, time.Hour)
+	tickers.Unlock()
+
+	m := testutil.TestMetric(1)
+	slow.AddMetric(m)
+	idle.AddMetric(m)
+	tickers.tick(time.Minute)
+
+	// the slow output is stuck in its first write, the fast one is not
+	// held up by it
+	for i := 0; i < 2; i++ {
+		fast.AddMetric(m)
+		tickers.tick(time.Second)
+		<-fastOutput.written
+	}
+	assert.Equal(t, 2, fastOutput.Writes())
+	assert.Equal(t, 0, slowOutput.Writes())
+	assert.Equal(t, 0, idleOutput.Writes())
+
+	close(shutdown)
+	close(slowOutput.release)
+	wg.Wait()
+	assert.Equal(t, 1, slowOutput.Writes())
+}
+
+func TestAgent_AggregatesSkipStreamingProcessors(t *testing.T) {
+	c := config.NewConfig()
+	c.Agent.FlushInterval.Duration = time.Hour
+
+	streaming := &testStreamingProcessor{}
+	c.Processors = models.RunningProcessors{
+		models.NewRunningProcessor(streaming, &models.ProcessorConfig{Name: "streaming"}),
+		models.NewRunningProcessor(&testProcessor{}, &models.ProcessorConfig{Name: "test"}),
+	}
+	c.Aggregators = models.RunningAggregators{
+		models.NewRunningAggregator(&testAggregator{}, &models.AggregatorConfig{
+			Name: "test",
+		}),
+	}
+	output := &recordingOutput{}
+	c.Outputs = []*models.RunningOutput{
+		models.NewRunningOutput("recording", output,
+			&models.OutputConfig{Name: "recording"}, 0, 0),
+	}
+
+	a, err := NewAgent(c)
+	require.NoError(t, err)
+
+	shutdown := make(chan struct{})
+	metricC := make(chan telegraf.Metric, 10)
+	aggC := make(chan telegraf.Metric, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, a.flusher(shutdown, metricC, aggC))
+	}()
+
+	metricC <- testutil.TestMetric(int64(1), "cpu")
+	aggregate := testutil.TestMetric(int64(1), "count")
+	aggregate.SetAggregate(true)
+	aggC <- aggregate
+
+	for start := time.Now(); time.Since(start) < 5*time.Second; {
+		if len(streaming.Applied()) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(shutdown)
+	<-done
+
+	// the aggregate is not emitted again by the streaming processor, which
+	// would make it a gathered metric
+	assert.Equal(t, []string{"cpu"}, streaming.Applied())
+	sort.Strings(output.written)
+	assert.Equal(t, []string{"count", "cpu"}, output.written)
+}
+
+func TestAgent_ScheduledGatherer(t *testing.T) {
+	c := config.NewConfig()
+
+	schedule, err := models.NewSchedule("@every 1s", "UTC", 0)
+	require.NoError(t, err)
+	input := models.NewRunningInput(&slowInput{}, &models.InputConfig{
+		Name:     "slow",
+		Schedule: schedule,
+	})
+	c.Inputs = []*models.RunningInput{input}
+
+	a, err := NewAgent(c)
+	require.NoError(t, err)
+
+	now := time.Date(2017, 6, 1, 10, 0, 0, 250000000, time.UTC)
+	a.now = func() time.Time { return now }
+	// the first timer fires at once, the next ones never do
+	fired := make(chan time.Time, 1)
+	fired <- now
+	timers := make(chan time.Duration, 10)
+	a.newTimer = func(d time.Duration) (<-chan time.Time, func() bool) {
+		timers <- d
+		return fired, func() bool { return true }
+	}
+
+	shutdown := make(chan struct{})
+	metricC := make(chan telegraf.Metric, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.gatherer(shutdown, input, time.Hour, metricC)
+	}()
+
+	m := <-metricC
+	close(shutdown)
+	<-done
+
+	assert.Equal(t, 750*time.Millisecond, <-timers)
+	// the metric has the scheduled time, not the time the gather ended
+	want := time.Date(2017, 6, 1, 10, 0, 1, 0, time.UTC)
+	assert.True(t, want.Equal(m.Time()), "%s != %s", want, m.Time())
+}
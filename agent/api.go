@@ -0,0 +1,379 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
+	"github.com/influxdata/telegraf/plugins/serializers/influx"
+	"github.com/influxdata/telegraf/selfstat"
+)
+
+// Pipeline stages that can be tapped through the API.
+const (
+	// stageInputs are the metrics as gathered, before the processors.
+	stageInputs = "inputs"
+	// stageProcessors are the metrics after the processors, before the
+	// aggregators.
+	stageProcessors = "processors"
+	// stageOutputs are the metrics routed to the outputs, including the
+	// aggregates.
+	stageOutputs = "outputs"
+)
+
+// tapBufferSize is the number of metrics buffered for each tap, metrics are
+// dropped when the client does not keep up.
+const tapBufferSize = 1000
+
+// tap receives a copy of the metrics passing through a pipeline stage.
+type tap struct {
+	stage   string
+	filter  filter.Filter
+	metrics chan telegraf.Metric
+}
+
+// taps are the taps currently opened through the API.
+type taps struct {
+	sync.RWMutex
+	taps map[*tap]struct{}
+}
+
+func (t *taps) add(tp *tap) {
+	t.Lock()
+	defer t.Unlock()
+	if t.taps == nil {
+		t.taps = make(map[*tap]struct{})
+	}
+	t.taps[tp] = struct{}{}
+}
+
+func (t *taps) remove(tp *tap) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.taps, tp)
+}
+
+// send passes a copy of the metric to the taps of the stage, without ever
+// blocking the pipeline.
+func (t *taps) send(stage string, m telegraf.Metric) {
+	t.RLock()
+	defer t.RUnlock()
+	for tp := range t.taps {
+		if tp.stage != stage {
+			continue
+		}
+		if tp.filter != nil && !tp.filter.Match(m.Name()) {
+			continue
+		}
+		select {
+		case tp.metrics <- m.Copy():
+		default:
+		}
+	}
+}
+
+// trigger does a non-blocking send on c, it is a no-op when a trigger is
+// already pending.
+func trigger(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+// serveAPI serves the HTTP API until shutdown.
+func (a *Agent) serveAPI(shutdown chan struct{}) error {
+	listener, err := net.Listen("tcp", a.Config.Agent.APIAddress)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Handler: a.apiHandler(shutdown)}
+	go func() {
+		<-shutdown
+		server.Close()
+	}()
+
+	log.Printf("I! Serving the agent API on %s", listener.Addr())
+	go func() {
+		err := server.Serve(listener)
+		if err != http.ErrServerClosed {
+			log.Printf("E! Error serving the agent API: %s", err)
+		}
+	}()
+	return nil
+}
+
+func (a *Agent) apiHandler(shutdown chan struct{}) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", a.serveHealth)
+	mux.HandleFunc("/plugins", a.servePlugins)
+	mux.HandleFunc("/gather", a.serveGather)
+	mux.HandleFunc("/flush", a.serveFlush)
+	mux.HandleFunc("/tap", func(w http.ResponseWriter, req *http.Request) {
+		a.serveTap(w, req, shutdown)
+	})
+	return mux
+}
+
+type healthCheck struct {
+	Output  string `json:"output"`
+	Message string `json:"message"`
+}
+
+type healthResponse struct {
+	Status string        `json:"status"`
+	Checks []healthCheck `json:"checks,omitempty"`
+}
+
+// serveHealth reports unhealthy when an output has been failing for too long
+// or has a buffer that is too full.
+func (a *Agent) serveHealth(w http.ResponseWriter, req *http.Request) {
+	maxFailure := a.Config.Agent.HealthMaxFailureDuration.Duration
+	maxFill := a.Config.Agent.HealthMaxBufferFill
+
+	resp := healthResponse{Status: "pass"}
+	for _, o := range a.Config.Outputs {
+		name := o.LogName()
+		if since := o.FailingSince(); maxFailure > 0 && !since.IsZero() {
+			if failing := time.Since(since); failing > maxFailure {
+				resp.Checks = append(resp.Checks, healthCheck{
+					Output: name,
+					Message: fmt.Sprintf("writes failing for %s",
+						failing.Round(time.Second)),
+				})
+			}
+		}
+		if fill := o.BufferFill(); maxFill > 0 && fill > maxFill {
+			resp.Checks = append(resp.Checks, healthCheck{
+				Output:  name,
+				Message: fmt.Sprintf("buffer %.0f%% full", fill*100),
+			})
+		}
+	}
+
+	status := http.StatusOK
+	if len(resp.Checks) > 0 {
+		resp.Status = "fail"
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, resp)
+}
+
+type pluginInfo struct {
+	Name          string           `json:"name"`
+	Stats         map[string]int64 `json:"stats"`
+	LastError     string           `json:"last_error,omitempty"`
+	LastErrorTime *time.Time       `json:"last_error_time,omitempty"`
+}
+
+type pluginsResponse struct {
+	Inputs      []pluginInfo `json:"inputs"`
+	Processors  []pluginInfo `json:"processors"`
+	Aggregators []pluginInfo `json:"aggregators"`
+	Outputs     []pluginInfo `json:"outputs"`
+}
+
+type errorSource interface {
+	Errors() int64
+	LastError() (string, time.Time)
+}
+
+func newPluginInfo(name string, errs errorSource, stats ...selfstat.Stat) pluginInfo {
+	info := pluginInfo{
+		Name:  name,
+		Stats: map[string]int64{"errors": errs.Errors()},
+	}
+	for _, s := range stats {
+		info.Stats[s.FieldName()] = s.Get()
+	}
+	if msg, t := errs.LastError(); msg != "" {
+		info.LastError = msg
+		info.LastErrorTime = &t
+	}
+	return info
+}
+
+// servePlugins lists the loaded plugins with their counters and last error.
+func (a *Agent) servePlugins(w http.ResponseWriter, req *http.Request) {
+	resp := pluginsResponse{
+		Inputs:      []pluginInfo{},
+		Processors:  []pluginInfo{},
+		Aggregators: []pluginInfo{},
+		Outputs:     []pluginInfo{},
+	}
+	for _, i := range a.Config.Inputs {
+		resp.Inputs = append(resp.Inputs, newPluginInfo(i.LogName(), i,
+			i.MetricsGathered, i.GatherTimeouts))
+	}
+	for _, p := range a.Config.Processors {
+		resp.Processors = append(resp.Processors,
+			newPluginInfo(p.LogName(), p))
+	}
+	for _, p := range a.Config.AggProcessors {
+		resp.Processors = append(resp.Processors,
+			newPluginInfo(p.LogName(), p))
+	}
+	for _, agg := range a.Config.Aggregators {
+		resp.Aggregators = append(resp.Aggregators,
+			newPluginInfo(agg.LogName(), agg))
+	}
+	for _, o := range a.Config.Outputs {
+		resp.Outputs = append(resp.Outputs, newPluginInfo(o.LogName(), o,
+			o.MetricsWritten, o.MetricsFiltered, o.BufferSize, o.BufferLimit))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type triggerResponse struct {
+	Triggered []string `json:"triggered"`
+}
+
+// serveGather triggers an immediate gather of all inputs, or of the inputs
+// given by the "input" parameter. The parameter is the name of the plugin, with
+// or without the "inputs." prefix, or its alias.
+func (a *Agent) serveGather(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := req.URL.Query().Get("input")
+	resp := triggerResponse{Triggered: []string{}}
+	for _, i := range a.Config.Inputs {
+		if name != "" && !matchPlugin(name, i.Config.Name, i.Config.Alias,
+			i.Name(), i.LogName()) {
+			continue
+		}
+		trigger(a.gatherTriggers[i])
+		resp.Triggered = append(resp.Triggered, i.LogName())
+	}
+	if len(resp.Triggered) == 0 {
+		http.Error(w, fmt.Sprintf("no input named %q", name),
+			http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+// serveFlush triggers an immediate flush of all outputs, or of the outputs
+// given by the "output" parameter. The parameter is the name of the plugin,
+// with or without the "outputs." prefix, or its alias.
+func (a *Agent) serveFlush(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := req.URL.Query().Get("output")
+	resp := triggerResponse{Triggered: []string{}}
+	for _, o := range a.Config.Outputs {
+		if name != "" && !matchPlugin(name, o.Name, o.Config.Alias,
+			"outputs."+o.Name, o.LogName()) {
+			continue
+		}
+		trigger(a.flushTriggers[o])
+		resp.Triggered = append(resp.Triggered, o.LogName())
+	}
+	if len(resp.Triggered) == 0 {
+		http.Error(w, fmt.Sprintf("no output named %q", name),
+			http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+// serveTap streams the metrics passing through the "stage" parameter in
+// influx line protocol, until the client disconnects. The "namepass"
+// parameter is a comma separated list of globs the measurement names must
+// match.
+func (a *Agent) serveTap(
+	w http.ResponseWriter,
+	req *http.Request,
+	shutdown chan struct{},
+) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	query := req.URL.Query()
+	tp := &tap{
+		stage:   query.Get("stage"),
+		metrics: make(chan telegraf.Metric, tapBufferSize),
+	}
+	switch tp.stage {
+	case "":
+		tp.stage = stageOutputs
+	case stageInputs, stageProcessors, stageOutputs:
+	default:
+		http.Error(w, fmt.Sprintf("invalid stage %q, must be one of %s, %s "+
+			"or %s", tp.stage, stageInputs, stageProcessors, stageOutputs),
+			http.StatusBadRequest)
+		return
+	}
+	if namepass := query.Get("namepass"); namepass != "" {
+		var err error
+		tp.filter, err = filter.Compile(strings.Split(namepass, ","))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	a.taps.add(tp)
+	defer a.taps.remove(tp)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	serializer := &influx.InfluxSerializer{}
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-req.Context().Done():
+			return
+		case m := <-tp.metrics:
+			b, err := serializer.Serialize(m)
+			if err != nil {
+				continue
+			}
+			if _, err := w.Write(b); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// matchPlugin returns true if name is one of the names of a plugin, an empty
+// alias never matches.
+func matchPlugin(name, pluginName, alias string, names ...string) bool {
+	if name == pluginName || (alias != "" && name == alias) {
+		return true
+	}
+	for _, n := range names {
+		if name == n {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("E! Error writing agent API response: %s", err)
+	}
+}
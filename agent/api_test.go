@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal/config"
+	"github.com/influxdata/telegraf/internal/models"
+	"github.com/influxdata/telegraf/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingOutput struct {
+	testOutput
+}
+
+func (o *failingOutput) Write([]telegraf.Metric) error {
+	return errors.New("connection refused")
+}
+
+func newAPITestAgent(t *testing.T) (*Agent, *httptest.Server, chan struct{}) {
+	c := config.NewConfig()
+	c.Agent.HealthMaxFailureDuration.Duration = time.Nanosecond
+	c.Agent.HealthMaxBufferFill = 0.5
+
+	c.Inputs = []*models.RunningInput{
+		models.NewRunningInput(&testInput{}, &models.InputConfig{Name: "test"}),
+	}
+	c.Outputs = []*models.RunningOutput{
+		models.NewRunningOutput("ok", &testOutput{},
+			&models.OutputConfig{Name: "ok"}, 1, 4),
+		models.NewRunningOutput("failing", &failingOutput{},
+			&models.OutputConfig{Name: "failing"}, 10, 4),
+	}
+
+	a, err := NewAgent(c)
+	require.NoError(t, err)
+
+	shutdown := make(chan struct{})
+	ts := httptest.NewServer(a.apiHandler(shutdown))
+	return a, ts, shutdown
+}
+
+func TestAPI_Health(t *testing.T) {
+	a, ts, shutdown := newAPITestAgent(t)
+	defer ts.Close()
+	defer close(shutdown)
+
+	resp, err := http.Get(ts.URL + "/health")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	failing := a.Config.Outputs[1]
+	for i := 0; i < 3; i++ {
+		failing.AddMetric(testutil.TestMetric(1))
+	}
+	assert.Error(t, failing.Write())
+
+	resp, err = http.Get(ts.URL + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	var health healthResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+	assert.Equal(t, "fail", health.Status)
+	require.Len(t, health.Checks, 2)
+	assert.Equal(t, "outputs.failing", health.Checks[0].Output)
+	assert.Contains(t, health.Checks[0].Message, "writes failing")
+	assert.Equal(t, "buffer 75% full", health.Checks[1].Message)
+}
+
+func TestAPI_Plugins(t *testing.T) {
+	a, ts, shutdown := newAPITestAgent(t)
+	defer ts.Close()
+	defer close(shutdown)
+
+	acc := NewAccumulator(a.Config.Inputs[0], make(chan telegraf.Metric, 1))
+	acc.AddError(errors.New("permission denied"))
+
+	resp, err := http.Get(ts.URL + "/plugins")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var plugins pluginsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&plugins))
+	require.Len(t, plugins.Inputs, 1)
+	assert.Equal(t, "inputs.test", plugins.Inputs[0].Name)
+	assert.Equal(t, "permission denied", plugins.Inputs[0].LastError)
+	assert.NotNil(t, plugins.Inputs[0].LastErrorTime)
+	assert.Contains(t, plugins.Inputs[0].Stats, "metrics_gathered")
+
+	require.Len(t, plugins.Outputs, 2)
+	assert.Equal(t, "outputs.ok", plugins.Outputs[0].Name)
+	assert.Contains(t, plugins.Outputs[0].Stats, "buffer_limit")
+	assert.Empty(t, plugins.Outputs[0].LastError)
+}
+
+func TestAPI_Trigger(t *testing.T) {
+	a, ts, shutdown := newAPITestAgent(t)
+	defer ts.Close()
+	defer close(shutdown)
+
+	resp, err := http.Get(ts.URL + "/gather")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(ts.URL+"/gather?input=test", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Len(t, a.gatherTriggers[a.Config.Inputs[0]], 1)
+
+	resp, err = http.Post(ts.URL+"/flush?output=outputs.failing", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Len(t, a.flushTriggers[a.Config.Outputs[0]], 0)
+	assert.Len(t, a.flushTriggers[a.Config.Outputs[1]], 1)
+
+	resp, err = http.Post(ts.URL+"/flush?output=missing", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPI_Tap(t *testing.T) {
+	a, ts, shutdown := newAPITestAgent(t)
+	defer ts.Close()
+	defer close(shutdown)
+
+	resp, err := http.Get(ts.URL + "/tap?stage=bogus")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(ts.URL + "/tap?stage=inputs&namepass=test*")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the tap is registered once the headers have been sent
+	a.taps.send(stageOutputs, testutil.TestMetric(1))
+	a.taps.send(stageInputs, testutil.TestMetric(2, "other"))
+	a.taps.send(stageInputs, testutil.TestMetric(3))
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Contains(t, line, "test1,tag1=value1 value=3i")
+}
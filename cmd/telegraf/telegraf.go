@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -31,9 +32,24 @@ var pprofAddr = flag.String("pprof-addr", "",
 var fQuiet = flag.Bool("quiet", false,
 	"run in quiet mode")
 var fTest = flag.Bool("test", false, "gather metrics, print them out, and exit")
+var fTestWait = flag.Duration("test-wait", 0,
+	"run service inputs for this long in test mode, implies --test")
 var fConfig = flag.String("config", "", "configuration file to load")
 var fConfigDirectory = flag.String("config-directory", "",
 	"directory containing additional *.conf files")
+var fConfigHeaders headerFlags
+var fConfigTLSCA = flag.String("config-tls-ca", "",
+	"CA file to verify the server of a remote config")
+var fConfigTLSCert = flag.String("config-tls-cert", "",
+	"client certificate file used to fetch a remote config")
+var fConfigTLSKey = flag.String("config-tls-key", "",
+	"client key file used to fetch a remote config")
+var fConfigInsecureSkipVerify = flag.Bool("config-insecure-skip-verify", false,
+	"skip the verification of the server of a remote config")
+var fConfigPollInterval = flag.Duration("config-poll-interval", 0,
+	"poll a remote config for changes at this interval, 0 disables polling")
+var fConfigCacheDir = flag.String("config-cache-dir", "",
+	"directory caching the last good copy of remote configs")
 var fVersion = flag.Bool("version", false, "display the version")
 var fSampleConfig = flag.Bool("sample-config", false,
 	"print out full sample configuration")
@@ -63,7 +79,39 @@ var (
 	branch      string
 )
 
+// headerFlags are the repeated --config-header flags, in "Name: value"
+// format.
+type headerFlags []string
+
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerFlags) Set(value string) error {
+	if !strings.Contains(value, ":") {
+		return fmt.Errorf("invalid header %q, must be \"Name: value\"", value)
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+// Map returns the headers by name, with the environment variables in the
+// values expanded so that secrets need not be passed on the command line.
+func (h headerFlags) Map() map[string]string {
+	headers := make(map[string]string, len(h))
+	for _, header := range h {
+		parts := strings.SplitN(header, ":", 2)
+		headers[strings.TrimSpace(parts[0])] =
+			os.ExpandEnv(strings.TrimSpace(parts[1]))
+	}
+	return headers
+}
+
 func init() {
+	flag.Var(&fConfigHeaders, "config-header",
+		"header added to the requests fetching a remote config, "+
+			"ie, \"Authorization: Token $TOKEN\", can be repeated")
+
 	// If commit or branch are not set, make that clear.
 	if commit == "" {
 		commit = "unknown"
@@ -84,9 +132,23 @@ The commands & flags are:
   config              print out full sample configuration to stdout
   version             print the version to stdout
 
-  --config <file>     configuration file to load
-  --test              gather metrics once, print them to stdout, and exit
+  --config <file>     configuration file to load, or http(s) URL to fetch it from
+  --test              gather metrics once, print what each output would
+                      receive to stdout, and exit
+  --test-wait         run service inputs for this long in --test mode, ie, 10s
   --config-directory  directory containing additional *.conf files
+  --config-header     header added to the requests fetching a remote config,
+                      ie, "Authorization: Token $TOKEN", can be repeated
+  --config-tls-ca     CA file to verify the server of a remote config
+  --config-tls-cert   client certificate file used to fetch a remote config
+  --config-tls-key    client key file used to fetch a remote config
+  --config-insecure-skip-verify
+                      skip the verification of the server of a remote config
+  --config-poll-interval
+                      poll a remote config for changes and reload it when it
+                      changes, ie, 1m
+  --config-cache-dir  directory caching the last good copy of remote configs,
+                      used when the server is unreachable, disabled by default
   --input-filter      filter the input plugins to enable, separator is :
   --output-filter     filter the output plugins to enable, separator is :
   --usage             print usage for a plugin, ie, 'telegraf --usage mysql'
@@ -105,18 +167,72 @@ Examples:
   # run a single telegraf collection, outputing metrics to stdout
   telegraf --config telegraf.conf --test
 
+  # run a telegraf collection, including 30s of metrics from service inputs
+  telegraf --config telegraf.conf --test-wait 30s
+
   # run telegraf with all plugins defined in config file
   telegraf --config telegraf.conf
 
   # run telegraf, enabling the cpu & memory input, and influxdb output plugins
   telegraf --config telegraf.conf --input-filter cpu:mem --output-filter influxdb
 
+  # run telegraf with a config fetched from a server, reloading it when it
+  # changes
+  telegraf --config https://example.com/telegraf.conf \
+    --config-header "Authorization: Token $TOKEN" --config-poll-interval 1m
+
   # run telegraf with pprof
   telegraf --config telegraf.conf --pprof-addr localhost:6060
 `
 
 var stop chan struct{}
 
+// loadConfig loads and checks the config files.
+func loadConfig(inputFilters []string, outputFilters []string) (*config.Config, error) {
+	c := config.NewConfig()
+	c.OutputFilters = outputFilters
+	c.InputFilters = inputFilters
+	c.Remote = config.RemoteConfig{
+		Headers:            fConfigHeaders.Map(),
+		SSLCA:              *fConfigTLSCA,
+		SSLCert:            *fConfigTLSCert,
+		SSLKey:             *fConfigTLSKey,
+		InsecureSkipVerify: *fConfigInsecureSkipVerify,
+		CacheDir:           *fConfigCacheDir,
+	}
+	err := c.LoadConfig(*fConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if *fConfigDirectory != "" {
+		err = c.LoadDirectory(*fConfigDirectory)
+		if err != nil {
+			return nil, err
+		}
+	}
+	test := *fTest || *fTestWait > 0
+	if !test && len(c.Outputs) == 0 {
+		return nil, errors.New("Error: no outputs found, did you provide a valid config file?")
+	}
+	if len(c.Inputs) == 0 {
+		return nil, errors.New("Error: no inputs found, did you provide a valid config file?")
+	}
+
+	if int64(c.Agent.Interval.Duration) <= 0 {
+		return nil, fmt.Errorf("Agent interval must be positive, found %s",
+			c.Agent.Interval.Duration)
+	}
+
+	if int64(c.Agent.FlushInterval.Duration) <= 0 {
+		return nil, fmt.Errorf("Agent flush_interval must be positive; found %s",
+			c.Agent.Interval.Duration)
+	}
+
+	c.CacheRemoteConfig()
+	return c, nil
+}
+
 func reloadLoop(
 	stop chan struct{},
 	inputFilters []string,
@@ -124,57 +240,39 @@ func reloadLoop(
 	aggregatorFilters []string,
 	processorFilters []string,
 ) {
+	// next is the config to run on the next iteration, or the error loading
+	// it
+	next, nextErr := loadConfig(inputFilters, outputFilters)
+
 	reload := make(chan bool, 1)
 	reload <- true
 	for <-reload {
 		reload <- false
 
-		// If no other options are specified, load the config file and run.
-		c := config.NewConfig()
-		c.OutputFilters = outputFilters
-		c.InputFilters = inputFilters
-		err := c.LoadConfig(*fConfig)
-		if err != nil {
-			log.Fatal("E! " + err.Error())
-		}
-
-		if *fConfigDirectory != "" {
-			err = c.LoadDirectory(*fConfigDirectory)
-			if err != nil {
-				log.Fatal("E! " + err.Error())
-			}
-		}
-		if !*fTest && len(c.Outputs) == 0 {
-			log.Fatalf("E! Error: no outputs found, did you provide a valid config file?")
-		}
-		if len(c.Inputs) == 0 {
-			log.Fatalf("E! Error: no inputs found, did you provide a valid config file?")
-		}
-
-		if int64(c.Agent.Interval.Duration) <= 0 {
-			log.Fatalf("E! Agent interval must be positive, found %s",
-				c.Agent.Interval.Duration)
-		}
-
-		if int64(c.Agent.FlushInterval.Duration) <= 0 {
-			log.Fatalf("E! Agent flush_interval must be positive; found %s",
-				c.Agent.Interval.Duration)
+		if nextErr != nil {
+			log.Fatal("E! " + nextErr.Error())
 		}
 
+		c := next
 		ag, err := agent.NewAgent(c)
 		if err != nil {
 			log.Fatal("E! " + err.Error())
 		}
 
 		// Setup logging
-		logger.SetupLogging(
-			ag.Config.Agent.Debug || *fDebug,
-			ag.Config.Agent.Quiet || *fQuiet,
-			ag.Config.Agent.Logfile,
-		)
-
-		if *fTest {
-			err = ag.Test()
+		logger.SetupLogging(logger.LogConfig{
+			Debug:               ag.Config.Agent.Debug || *fDebug,
+			Quiet:               ag.Config.Agent.Quiet || *fQuiet,
+			Logfile:             ag.Config.Agent.Logfile,
+			Format:              ag.Config.Agent.LogFormat,
+			RotationMaxAge:      ag.Config.Agent.LogfileRotationMaxAge.Duration,
+			RotationMaxSize:     ag.Config.Agent.LogfileRotationMaxSize.Size,
+			RotationMaxArchives: ag.Config.Agent.LogfileRotationMaxArchives,
+			PluginLevels:        ag.Config.LogLevels(),
+		})
+
+		if *fTest || *fTestWait > 0 {
+			err = ag.Test(*fTestWait)
 			if err != nil {
 				log.Fatal("E! " + err.Error())
 			}
@@ -187,22 +285,53 @@ func reloadLoop(
 		}
 
 		shutdown := make(chan struct{})
+		changed := make(chan struct{})
+		if *fConfigPollInterval > 0 && c.HasRemoteConfig() {
+			go c.WatchRemoteConfig(*fConfigPollInterval, shutdown, changed)
+		}
 		signals := make(chan os.Signal)
 		signal.Notify(signals, os.Interrupt, syscall.SIGHUP)
 		go func() {
-			select {
-			case sig := <-signals:
-				if sig == os.Interrupt {
-					close(shutdown)
+			// restart loads the new config. With a remote config the agent
+			// keeps running with the current config if it fails, otherwise
+			// telegraf exits once the agent has stopped.
+			restart := func() bool {
+				log.Printf("I! Reloading Telegraf config\n")
+				reloaded, err := loadConfig(inputFilters, outputFilters)
+				if err != nil && c.HasRemoteConfig() {
+					log.Printf("E! Error reloading the config, keeping the "+
+						"current config: %s", err)
+					return false
 				}
-				if sig == syscall.SIGHUP {
-					log.Printf("I! Reloading Telegraf config\n")
-					<-reload
-					reload <- true
+				next, nextErr = reloaded, err
+				<-reload
+				reload <- true
+				close(shutdown)
+				return true
+			}
+
+			for {
+				select {
+				case sig := <-signals:
+					if sig == os.Interrupt {
+						close(shutdown)
+						return
+					}
+					if sig == syscall.SIGHUP && restart() {
+						return
+					}
+				case <-changed:
+					if restart() {
+						return
+					}
+					// watch again, to retry once the file changes or can be
+					// fetched
+					changed = make(chan struct{})
+					go c.WatchRemoteConfig(*fConfigPollInterval, shutdown, changed)
+				case <-stop:
 					close(shutdown)
+					return
 				}
-			case <-stop:
-				close(shutdown)
 			}
 		}()
 
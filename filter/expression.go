@@ -0,0 +1,548 @@
+package filter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Expression is a compiled boolean expression which can be evaluated
+// against the measurement name, tags, fields and timestamp of a metric.
+//
+// The grammar supports comparisons, regular expression matches and the
+// boolean operators "and", "or" and "not", ie:
+//
+//   e, _ := CompileExpression(`fields.usage_idle > 99 and tags.cpu != "cpu-total"`)
+//   e.Eval("cpu", fields, tags, t)
+//
+// The following identifiers are available:
+//
+//   name, measurement:     the measurement name
+//   time:                  the timestamp in nanoseconds since the epoch
+//   tags.<key>:            the value of the tag, tags["<key>"] may also be used
+//   fields.<key>:          the value of the field, fields["<key>"] may also be used
+//
+// Comparing a missing tag or field, or values of different types, is always
+// false, with the exception of "!=" which is true.
+type Expression struct {
+	text string
+	root node
+}
+
+// CompileExpression parses the given expression.
+func CompileExpression(expr string) (*Expression, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+
+	return &Expression{text: expr, root: root}, nil
+}
+
+// Eval returns the result of the expression for the given metric values.
+func (e *Expression) Eval(
+	measurement string,
+	fields map[string]interface{},
+	tags map[string]string,
+	t time.Time,
+) bool {
+	env := &environment{
+		measurement: measurement,
+		fields:      fields,
+		tags:        tags,
+		t:           t,
+	}
+	return truthy(e.root.eval(env))
+}
+
+func (e *Expression) String() string {
+	return e.text
+}
+
+type environment struct {
+	measurement string
+	fields      map[string]interface{}
+	tags        map[string]string
+	t           time.Time
+}
+
+// node is a node of the expression tree. eval returns one of nil, bool,
+// string, int64 or float64.
+type node interface {
+	eval(env *environment) interface{}
+}
+
+type literal struct {
+	value interface{}
+}
+
+func (n *literal) eval(env *environment) interface{} {
+	return n.value
+}
+
+type identifier struct {
+	kind string
+	key  string
+}
+
+func (n *identifier) eval(env *environment) interface{} {
+	switch n.kind {
+	case "name":
+		return env.measurement
+	case "time":
+		return env.t.UnixNano()
+	case "tags":
+		if v, ok := env.tags[n.key]; ok {
+			return v
+		}
+	case "fields":
+		if v, ok := env.fields[n.key]; ok {
+			return normalize(v)
+		}
+	}
+	return nil
+}
+
+type not struct {
+	operand node
+}
+
+func (n *not) eval(env *environment) interface{} {
+	return !truthy(n.operand.eval(env))
+}
+
+type logical struct {
+	op          string
+	left, right node
+}
+
+func (n *logical) eval(env *environment) interface{} {
+	left := truthy(n.left.eval(env))
+	if n.op == "and" {
+		return left && truthy(n.right.eval(env))
+	}
+	return left || truthy(n.right.eval(env))
+}
+
+type regexMatch struct {
+	negate  bool
+	operand node
+	re      *regexp.Regexp
+}
+
+func (n *regexMatch) eval(env *environment) interface{} {
+	s, ok := n.operand.eval(env).(string)
+	if !ok {
+		return n.negate
+	}
+	return n.re.MatchString(s) != n.negate
+}
+
+type comparison struct {
+	op          string
+	left, right node
+}
+
+func (n *comparison) eval(env *environment) interface{} {
+	cmp, ok := compare(n.left.eval(env), n.right.eval(env))
+	if !ok {
+		return n.op == "!="
+	}
+
+	switch n.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// compare returns -1, 0 or 1 if a is less, equal or greater than b. The
+// second return value is false if the values cannot be compared.
+func compare(a, b interface{}) (int, bool) {
+	switch av := a.(type) {
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv), true
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			if av == bv {
+				return 0, true
+			}
+			if !av {
+				return -1, true
+			}
+			return 1, true
+		}
+	case int64:
+		switch bv := b.(type) {
+		case int64:
+			return compareInt(av, bv), true
+		case float64:
+			return compareFloat(float64(av), bv), true
+		}
+	case float64:
+		switch bv := b.(type) {
+		case int64:
+			return compareFloat(av, float64(bv)), true
+		case float64:
+			return compareFloat(av, bv), true
+		}
+	}
+	return 0, false
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// truthy converts a value to a boolean. Booleans are returned as-is, missing
+// values are false and any other value is true.
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	}
+	return true
+}
+
+// normalize converts field values to the types understood by the
+// expression evaluator.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return v
+}
+
+const (
+	tokEOF = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOperator
+)
+
+type token struct {
+	kind int
+	text string
+	pos  int
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||",
+	"<", ">", "!", "(", ")", "[", "]", "-"}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+	i := 0
+outer:
+	for i < len(s) {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			var buf bytes.Buffer
+			for end < len(s) && rune(s[end]) != c {
+				// only the quote and the backslash itself are escaped, so
+				// that regular expressions can be written as-is.
+				if s[end] == '\\' && end+1 < len(s) &&
+					(rune(s[end+1]) == c || s[end+1] == '\\') {
+					end++
+				}
+				buf.WriteByte(s[end])
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokString, text: buf.String(), pos: i})
+			i = end + 1
+		case c >= '0' && c <= '9' || c == '.':
+			end := i
+			for end < len(s) && strings.ContainsRune("0123456789.eE_", rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: s[i:end], pos: i})
+			i = end
+		case c == '_' || unicode.IsLetter(c):
+			end := i
+			for end < len(s) {
+				r := rune(s[end])
+				if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: s[i:end], pos: i})
+			i = end
+		default:
+			for _, op := range operators {
+				if strings.HasPrefix(s[i:], op) {
+					tokens = append(tokens, token{kind: tokOperator, text: op, pos: i})
+					i += len(op)
+					continue outer
+				}
+			}
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, text: "end of expression", pos: len(s)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of the given operators or
+// keywords.
+func (p *parser) accept(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOperator && tok.kind != tokIdent {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.next()
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		tok := p.peek()
+		return fmt.Errorf("expected %q but found %q at position %d",
+			op, tok.text, tok.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("or", "||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logical{op: "or", left: left, right: right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("and", "&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logical{op: "and", left: left, right: right}
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	if _, ok := p.accept("not", "!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &not{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := p.accept("==", "!=", "<=", ">=", "<", ">", "=~", "!~")
+	if !ok {
+		return left, nil
+	}
+
+	if op == "=~" || op == "!~" {
+		tok := p.next()
+		if tok.kind != tokString {
+			return nil, fmt.Errorf("expected a regular expression string after %q at position %d",
+				op, tok.pos)
+		}
+		re, err := regexp.Compile(tok.text)
+		if err != nil {
+			return nil, err
+		}
+		return &regexMatch{negate: op == "!~", operand: left, re: re}, nil
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return &comparison{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return &literal{value: tok.text}, nil
+	case tokNumber:
+		return parseNumber(tok, false)
+	case tokIdent:
+		return p.parseIdentifier(tok)
+	case tokOperator:
+		switch tok.text {
+		case "(":
+			n, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "-":
+			num := p.next()
+			if num.kind != tokNumber {
+				return nil, fmt.Errorf("expected a number after '-' at position %d", num.pos)
+			}
+			return parseNumber(num, true)
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func (p *parser) parseIdentifier(tok token) (node, error) {
+	switch tok.text {
+	case "true":
+		return &literal{value: true}, nil
+	case "false":
+		return &literal{value: false}, nil
+	case "name", "measurement":
+		return &identifier{kind: "name"}, nil
+	case "time":
+		return &identifier{kind: "time"}, nil
+	case "tags", "fields":
+		if err := p.expect("["); err != nil {
+			return nil, err
+		}
+		key := p.next()
+		if key.kind != tokString {
+			return nil, fmt.Errorf("expected a string key at position %d", key.pos)
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return &identifier{kind: tok.text, key: key.text}, nil
+	}
+
+	for _, kind := range []string{"tags", "fields"} {
+		if strings.HasPrefix(tok.text, kind+".") && len(tok.text) > len(kind)+1 {
+			return &identifier{kind: kind, key: tok.text[len(kind)+1:]}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown identifier %q at position %d", tok.text, tok.pos)
+}
+
+func parseNumber(tok token, negate bool) (node, error) {
+	text := strings.Replace(tok.text, "_", "", -1)
+	if negate {
+		text = "-" + text
+	}
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return &literal{value: i}, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+	}
+	return &literal{value: f}, nil
+}
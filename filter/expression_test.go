@@ -0,0 +1,106 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	fields := map[string]interface{}{
+		"usage_idle": 99.5,
+		"count":      int64(42),
+		"small":      int32(3),
+		"up":         true,
+		"status":     "ok",
+	}
+	tags := map[string]string{
+		"cpu":       "cpu0",
+		"host-name": "server01",
+	}
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{`fields.usage_idle > 99`, true},
+		{`fields.usage_idle > 99 and tags.cpu != "cpu-total"`, true},
+		{`fields.usage_idle > 99 && tags.cpu == "cpu-total"`, false},
+		{`fields.usage_idle < 10 or tags.cpu == "cpu0"`, true},
+		{`fields.usage_idle < 10 || tags.cpu == "cpu1"`, false},
+		{`not (fields.usage_idle > 99)`, false},
+		{`!fields.up`, false},
+		{`fields.up`, true},
+		{`fields.up == true`, true},
+		{`fields.count == 42`, true},
+		{`fields.count >= 42.0`, true},
+		{`fields.small < 4`, true},
+		{`fields.count > -1`, true},
+		{`fields.status == 'ok'`, true},
+		{`name == "cpu"`, true},
+		{`measurement != "cpu"`, false},
+		{`name =~ "^c.u$"`, true},
+		{`name !~ "^mem"`, true},
+		{`tags["host-name"] =~ "server\d+"`, true},
+		{`fields["count"] == 42`, true},
+		{`time == 1500000000000000000`, true},
+		{`time > 1500000000000000000`, false},
+		// missing values and type mismatches
+		{`fields.missing > 1`, false},
+		{`fields.missing == 1`, false},
+		{`fields.missing != 1`, true},
+		{`fields.missing`, false},
+		{`tags.missing != "x"`, true},
+		{`fields.status > 1`, false},
+		{`tags.cpu =~ "x" or fields.count =~ "4"`, false},
+	}
+
+	for _, tt := range tests {
+		e, err := CompileExpression(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.expected, e.Eval("cpu", fields, tags, now), tt.expr)
+	}
+}
+
+func TestExpressionPrecedence(t *testing.T) {
+	e, err := CompileExpression(`true or false and false`)
+	require.NoError(t, err)
+	assert.True(t, e.Eval("", nil, nil, time.Time{}))
+
+	e, err = CompileExpression(`(true or false) and false`)
+	require.NoError(t, err)
+	assert.False(t, e.Eval("", nil, nil, time.Time{}))
+}
+
+func TestExpressionErrors(t *testing.T) {
+	exprs := []string{
+		``,
+		`fields.value >`,
+		`(fields.value > 1`,
+		`fields.value > 1)`,
+		`unknown == 1`,
+		`tags.cpu =~ tags.other`,
+		`tags.cpu =~ "("`,
+		`tags[cpu] == "x"`,
+		`"unterminated`,
+		`fields.value # 1`,
+	}
+
+	for _, expr := range exprs {
+		_, err := CompileExpression(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func BenchmarkExpression(b *testing.B) {
+	e, _ := CompileExpression(`fields.usage_idle > 99 and tags.cpu != "cpu-total"`)
+	fields := map[string]interface{}{"usage_idle": 99.5}
+	tags := map[string]string{"cpu": "cpu0"}
+	now := time.Now()
+	for n := 0; n < b.N; n++ {
+		e.Eval("cpu", fields, tags, now)
+	}
+}
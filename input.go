@@ -1,5 +1,7 @@
 package telegraf
 
+import "context"
+
 type Input interface {
 	// SampleConfig returns the default configuration of the Input
 	SampleConfig() string
@@ -29,3 +31,14 @@ type ServiceInput interface {
 	// Stop stops the services and closes any necessary channels and connections
 	Stop()
 }
+
+// ContextInput is an Input that can be cancelled while gathering. The agent
+// calls GatherContext instead of Gather, with a context that is done once the
+// gather timeout of the input has passed or Telegraf is shutting down.
+type ContextInput interface {
+	Input
+
+	// GatherContext is like Gather, but must return as soon as possible once
+	// ctx is done.
+	GatherContext(ctx context.Context, acc Accumulator) error
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"log"
 	"math"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -14,11 +15,13 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/internal/models"
+	"github.com/influxdata/telegraf/logger"
 	"github.com/influxdata/telegraf/plugins/aggregators"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	"github.com/influxdata/telegraf/plugins/outputs"
@@ -55,12 +58,24 @@ type Config struct {
 	InputFilters  []string
 	OutputFilters []string
 
-	Agent       *AgentConfig
-	Inputs      []*models.RunningInput
-	Outputs     []*models.RunningOutput
-	Aggregators []*models.RunningAggregator
-	// Processors have a slice wrapper type because they need to be sorted
-	Processors models.RunningProcessors
+	Agent   *AgentConfig
+	Inputs  []*models.RunningInput
+	Outputs []*models.RunningOutput
+	// Aggregators and Processors have a slice wrapper type because they need
+	// to be sorted
+	Aggregators models.RunningAggregators
+	Processors  models.RunningProcessors
+	// AggProcessors run on the metrics leaving the aggregators
+	AggProcessors models.RunningProcessors
+	Routes        []*models.RunningRoute
+
+	// Remote configures the fetching of config files given as URLs.
+	Remote      RemoteConfig
+	remoteFiles []*remoteFile
+	// remoteHTTP is the client fetching the remote config files, created on
+	// first use as Remote is set after NewConfig
+	remoteMu   sync.Mutex
+	remoteHTTP *http.Client
 }
 
 func NewConfig() *Config {
@@ -76,6 +91,7 @@ func NewConfig() *Config {
 		Inputs:        make([]*models.RunningInput, 0),
 		Outputs:       make([]*models.RunningOutput, 0),
 		Processors:    make([]*models.RunningProcessor, 0),
+		Routes:        make([]*models.RunningRoute, 0),
 		InputFilters:  make([]string, 0),
 		OutputFilters: make([]string, 0),
 	}
@@ -140,10 +156,36 @@ type AgentConfig struct {
 	// Logfile specifies the file to send logs to
 	Logfile string
 
+	// LogFormat is the format of the log messages: "text", "json" or
+	// "logfmt"
+	LogFormat string `toml:"log_format"`
+
+	// LogfileRotationMaxAge rotates the logfile once it is older
+	LogfileRotationMaxAge internal.Duration `toml:"logfile_rotation_max_age"`
+
+	// LogfileRotationMaxSize rotates the logfile once it would grow bigger
+	LogfileRotationMaxSize internal.Size `toml:"logfile_rotation_max_size"`
+
+	// LogfileRotationMaxArchives is the number of rotated logfiles to keep,
+	// all of them are kept when 0
+	LogfileRotationMaxArchives int `toml:"logfile_rotation_max_archives"`
+
 	// Quiet is the option for running in quiet mode
 	Quiet        bool
 	Hostname     string
 	OmitHostname bool
+
+	// APIAddress is the address the HTTP API of the agent listens on, the
+	// API is disabled when it is empty.
+	APIAddress string `toml:"api_address"`
+
+	// HealthMaxFailureDuration makes the health endpoint of the API report
+	// unhealthy when the writes to an output have been failing for longer.
+	HealthMaxFailureDuration internal.Duration `toml:"health_max_failure_duration"`
+
+	// HealthMaxBufferFill makes the health endpoint of the API report
+	// unhealthy when the buffer of an output is fuller than this ratio.
+	HealthMaxBufferFill float64 `toml:"health_max_buffer_fill"`
 }
 
 // Inputs returns a list of strings of the configured inputs.
@@ -164,6 +206,33 @@ func (c *Config) OutputNames() []string {
 	return name
 }
 
+// LogLevels returns the log_level of the plugins that override the log level,
+// by the name of their logger, ie, "inputs.cpu::alias".
+func (c *Config) LogLevels() map[string]string {
+	levels := make(map[string]string)
+	for _, input := range c.Inputs {
+		if input.Config.LogLevel != "" {
+			levels[input.LogName()] = input.Config.LogLevel
+		}
+	}
+	for _, output := range c.Outputs {
+		if output.Config.LogLevel != "" {
+			levels[output.LogName()] = output.Config.LogLevel
+		}
+	}
+	for _, aggregator := range c.Aggregators {
+		if aggregator.Config.LogLevel != "" {
+			levels[aggregator.LogName()] = aggregator.Config.LogLevel
+		}
+	}
+	for _, processor := range append(c.Processors, c.AggProcessors...) {
+		if processor.Config.LogLevel != "" {
+			levels[processor.LogName()] = processor.Config.LogLevel
+		}
+	}
+	return levels
+}
+
 // ListTags returns a string of tags specified in the config,
 // line-protocol style
 func (c *Config) ListTags() string {
@@ -251,12 +320,31 @@ var header = `# Telegraf Configuration
   quiet = false
   ## Specify the log file name. The empty string means to log to stderr.
   logfile = ""
+  ## Format of the log messages: "text", "json" or "logfmt". The json and
+  ## logfmt formats have level, plugin and alias fields.
+  # log_format = "text"
+  ## Rotate the log file once it is older than this duration or once it would
+  ## grow bigger than this size, "0s" and 0 disable the rotation.
+  # logfile_rotation_max_age = "0s"
+  # logfile_rotation_max_size = "10MB"
+  ## Number of rotated log files to keep, 0 keeps all of them.
+  # logfile_rotation_max_archives = 5
 
   ## Override default hostname, if empty use os.Hostname()
   hostname = ""
   ## If set to true, do no set the "host" tag in the telegraf agent.
   omit_hostname = false
 
+  ## Address of the local HTTP API of the agent, disabled if empty.
+  ## The API is not authenticated, only listen on a trusted interface.
+  # api_address = "localhost:9271"
+  ## The /health endpoint reports unhealthy when the writes to an output have
+  ## been failing for longer than this duration, "0s" disables the check.
+  # health_max_failure_duration = "5m"
+  ## ... or when an output buffer is fuller than this ratio of
+  ## metric_buffer_limit, 0 disables the check.
+  # health_max_buffer_fill = 0.9
+
 
 ###############################################################################
 #                            OUTPUT PLUGINS                                   #
@@ -566,10 +654,26 @@ func (c *Config) LoadConfig(path string) error {
 			return err
 		}
 	}
-	tbl, err := parseFile(path)
+	var tbl *ast.Table
+	if isURL(path) {
+		var contents []byte
+		contents, err = c.fetchConfig(path)
+		if err != nil {
+			return fmt.Errorf("Error loading %s, %s", path, err)
+		}
+		tbl, err = parseConfig(contents)
+	} else {
+		tbl, err = parseFile(path)
+	}
 	if err != nil {
 		return fmt.Errorf("Error parsing %s, %s", path, err)
 	}
+	return c.loadTable(path, tbl)
+}
+
+// loadTable applies the parsed config file at path to c.
+func (c *Config) loadTable(path string, tbl *ast.Table) error {
+	var err error
 
 	// Parse tags tables first:
 	for _, tableName := range []string{"tags", "global_tags"} {
@@ -597,8 +701,25 @@ func (c *Config) LoadConfig(path string) error {
 		}
 	}
 
+	// Parse routes table:
+	if val, ok := tbl.Fields["routes"]; ok {
+		subTables, ok := val.([]*ast.Table)
+		if !ok {
+			return fmt.Errorf("%s: invalid configuration, routes must be "+
+				"defined as [[routes]]", path)
+		}
+		for _, t := range subTables {
+			if err = c.addRoute(t); err != nil {
+				return fmt.Errorf("Error parsing %s, %s", path, err)
+			}
+		}
+	}
+
 	// Parse all the rest of the plugins:
 	for name, val := range tbl.Fields {
+		if name == "routes" {
+			continue
+		}
 		subTable, ok := val.(*ast.Table)
 		if !ok {
 			return fmt.Errorf("%s: invalid configuration", path)
@@ -681,9 +802,11 @@ func (c *Config) LoadConfig(path string) error {
 		}
 	}
 
-	if len(c.Processors) > 1 {
-		sort.Sort(c.Processors)
-	}
+	// stable sorts keep the plugins of the same order in the order they are
+	// loaded
+	sort.Stable(c.Processors)
+	sort.Stable(c.AggProcessors)
+	sort.Stable(c.Aggregators)
 	return nil
 }
 
@@ -707,6 +830,12 @@ func parseFile(fpath string) (*ast.Table, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseConfig(contents)
+}
+
+// parseConfig parses the contents of a configuration file, replacing the
+// environment variables.
+func parseConfig(contents []byte) (*ast.Table, error) {
 	// ugh windows why
 	contents = trimBOM(contents)
 
@@ -738,7 +867,15 @@ func (c *Config) addAggregator(name string, table *ast.Table) error {
 		return err
 	}
 
-	c.Aggregators = append(c.Aggregators, models.NewRunningAggregator(aggregator, conf))
+	ra := models.NewRunningAggregator(aggregator, conf)
+	if err := initPlugin(ra.LogName(), aggregator); err != nil {
+		return err
+	}
+	for _, other := range c.Aggregators {
+		warnOrderTie(ra.LogName(), other.LogName(), conf.Order, other.Config.Order)
+	}
+
+	c.Aggregators = append(c.Aggregators, ra)
 	return nil
 }
 
@@ -758,13 +895,42 @@ func (c *Config) addProcessor(name string, table *ast.Table) error {
 		return err
 	}
 
-	rf := &models.RunningProcessor{
-		Name:      name,
-		Processor: processor,
-		Config:    processorConfig,
+	rf := models.NewRunningProcessor(processor, processorConfig)
+	if err := initPlugin(rf.LogName(), processor); err != nil {
+		return err
+	}
+	processors := &c.Processors
+	if processorConfig.AfterAggregators {
+		processors = &c.AggProcessors
+	}
+	for _, other := range *processors {
+		warnOrderTie(rf.LogName(), other.LogName(), processorConfig.Order,
+			other.Config.Order)
+	}
+
+	*processors = append(*processors, rf)
+	return nil
+}
+
+// warnOrderTie warns when two plugins are explicitly given the same order, as
+// they then run in the order they are loaded, which depends on the names of
+// the config files.
+func warnOrderTie(name, otherName string, order, otherOrder int64) {
+	if order != 0 && order == otherOrder {
+		log.Printf("W! %s has the same order %d as %s, they run in the order "+
+			"they are loaded", name, order, otherName)
 	}
+}
 
-	c.Processors = append(c.Processors, rf)
+// initPlugin initializes the plugin if it implements telegraf.Initializer,
+// once its logger is set, so that an invalid plugin configuration is reported
+// when the config is loaded.
+func initPlugin(name string, plugin interface{}) error {
+	if p, ok := plugin.(telegraf.Initializer); ok {
+		if err := p.Init(); err != nil {
+			return fmt.Errorf("Could not initialize %s: %s", name, err)
+		}
+	}
 	return nil
 }
 
@@ -780,9 +946,11 @@ func (c *Config) addOutput(name string, table *ast.Table) error {
 
 	// If the output has a SetSerializer function, then this means it can write
 	// arbitrary types of output, so build the serializer and set it.
+	var serializer serializers.Serializer
 	switch t := output.(type) {
 	case serializers.SerializerOutput:
-		serializer, err := buildSerializer(name, table)
+		var err error
+		serializer, err = buildSerializer(name, table)
 		if err != nil {
 			return err
 		}
@@ -798,12 +966,41 @@ func (c *Config) addOutput(name string, table *ast.Table) error {
 		return err
 	}
 
+	batchSize := c.Agent.MetricBatchSize
+	if outputConfig.MetricBatchSize > 0 {
+		batchSize = outputConfig.MetricBatchSize
+	}
+	bufferLimit := c.Agent.MetricBufferLimit
+	if outputConfig.MetricBufferLimit > 0 {
+		bufferLimit = outputConfig.MetricBufferLimit
+	}
+
 	ro := models.NewRunningOutput(name, output, outputConfig,
-		c.Agent.MetricBatchSize, c.Agent.MetricBufferLimit)
+		batchSize, bufferLimit)
+	ro.Serializer = serializer
+	if err := initPlugin(ro.LogName(), output); err != nil {
+		return err
+	}
 	c.Outputs = append(c.Outputs, ro)
 	return nil
 }
 
+func (c *Config) addRoute(table *ast.Table) error {
+	conf, err := buildRoute(len(c.Routes), table)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range c.Routes {
+		if r.Config.Name == conf.Name {
+			return fmt.Errorf("Duplicate route name: %s", conf.Name)
+		}
+	}
+
+	c.Routes = append(c.Routes, models.NewRunningRoute(conf))
+	return nil
+}
+
 func (c *Config) addInput(name string, table *ast.Table) error {
 	if len(c.InputFilters) > 0 && !sliceContains(name, c.InputFilters) {
 		return nil
@@ -821,9 +1018,11 @@ func (c *Config) addInput(name string, table *ast.Table) error {
 
 	// If the input has a SetParser function, then this means it can accept
 	// arbitrary types of input, so build the parser and set it.
+	var parser parsers.Parser
 	switch t := input.(type) {
 	case parsers.ParserInput:
-		parser, err := buildParser(name, table)
+		var err error
+		parser, err = buildParser(name, table)
 		if err != nil {
 			return err
 		}
@@ -840,6 +1039,13 @@ func (c *Config) addInput(name string, table *ast.Table) error {
 	}
 
 	rp := models.NewRunningInput(input, pluginConfig)
+	if parser != nil {
+		// The parser logs on behalf of the input it was built for.
+		models.SetLoggerOnPlugin(parser, rp.Log())
+	}
+	if err := initPlugin(rp.LogName(), input); err != nil {
+		return err
+	}
 	c.Inputs = append(c.Inputs, rp)
 	return nil
 }
@@ -888,6 +1094,45 @@ func buildAggregator(name string, tbl *ast.Table) (*models.AggregatorConfig, err
 		}
 	}
 
+	if node, ok := tbl.Fields["grace"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				dur, err := time.ParseDuration(str.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				conf.Grace = dur
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["align"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if b, ok := kv.Value.(*ast.Boolean); ok {
+				var err error
+				conf.Align, err = strconv.ParseBool(b.Value)
+				if err != nil {
+					log.Printf("Error parsing boolean value for %s: %s\n", name, err)
+				}
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["timestamp"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				switch str.Value {
+				case "start", "end", "midpoint":
+					conf.Timestamp = str.Value
+				default:
+					return nil, fmt.Errorf("invalid timestamp %q for aggregator %s, "+
+						"must be one of start, end or midpoint", str.Value, name)
+				}
+			}
+		}
+	}
+
 	if node, ok := tbl.Fields["drop_original"]; ok {
 		if kv, ok := node.(*ast.KeyValue); ok {
 			if b, ok := kv.Value.(*ast.Boolean); ok {
@@ -935,12 +1180,21 @@ func buildAggregator(name string, tbl *ast.Table) (*models.AggregatorConfig, err
 
 	delete(tbl.Fields, "period")
 	delete(tbl.Fields, "delay")
+	delete(tbl.Fields, "grace")
+	delete(tbl.Fields, "align")
+	delete(tbl.Fields, "timestamp")
 	delete(tbl.Fields, "drop_original")
 	delete(tbl.Fields, "name_prefix")
 	delete(tbl.Fields, "name_suffix")
 	delete(tbl.Fields, "name_override")
 	delete(tbl.Fields, "tags")
+	conf.Order = buildOrder(name, tbl)
 	var err error
+	conf.Alias = buildAlias(tbl)
+	conf.LogLevel, err = buildLogLevel(tbl)
+	if err != nil {
+		return nil, err
+	}
 	conf.Filter, err = buildFilter(tbl)
 	if err != nil {
 		return conf, err
@@ -961,20 +1215,26 @@ func buildProcessor(name string, tbl *ast.Table) (*models.ProcessorConfig, error
 		}
 	}
 
-	if node, ok := tbl.Fields["order"]; ok {
+	if node, ok := tbl.Fields["after_aggregators"]; ok {
 		if kv, ok := node.(*ast.KeyValue); ok {
-			if b, ok := kv.Value.(*ast.Integer); ok {
+			if b, ok := kv.Value.(*ast.Boolean); ok {
 				var err error
-				conf.Order, err = strconv.ParseInt(b.Value, 10, 64)
+				conf.AfterAggregators, err = strconv.ParseBool(b.Value)
 				if err != nil {
-					log.Printf("Error parsing int value for %s: %s\n", name, err)
+					log.Printf("Error parsing boolean value for %s: %s\n", name, err)
 				}
 			}
 		}
 	}
 
-	delete(tbl.Fields, "order")
+	delete(tbl.Fields, "after_aggregators")
+	conf.Order = buildOrder(name, tbl)
 	var err error
+	conf.Alias = buildAlias(tbl)
+	conf.LogLevel, err = buildLogLevel(tbl)
+	if err != nil {
+		return nil, err
+	}
 	conf.Filter, err = buildFilter(tbl)
 	if err != nil {
 		return conf, err
@@ -982,8 +1242,90 @@ func buildProcessor(name string, tbl *ast.Table) (*models.ProcessorConfig, error
 	return conf, nil
 }
 
+// buildRoute parses a [[routes]] table, builds the filter and returns a
+// models.RouteConfig to be inserted into models.RunningRoute
+func buildRoute(index int, tbl *ast.Table) (*models.RouteConfig, error) {
+	conf := &models.RouteConfig{Name: strconv.Itoa(index)}
+	unsupportedFields := []string{"tagexclude", "taginclude", "fielddrop",
+		"fieldpass", "drop", "pass"}
+	for _, field := range unsupportedFields {
+		if _, ok := tbl.Fields[field]; ok {
+			return nil, fmt.Errorf("%s is not supported for routes.", field)
+		}
+	}
+
+	if node, ok := tbl.Fields["name"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				conf.Name = str.Value
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["outputs"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if ary, ok := kv.Value.(*ast.Array); ok {
+				for _, elem := range ary.Value {
+					if str, ok := elem.(*ast.String); ok {
+						conf.Outputs = append(conf.Outputs, str.Value)
+					}
+				}
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["default"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if b, ok := kv.Value.(*ast.Boolean); ok {
+				var err error
+				conf.Default, err = strconv.ParseBool(b.Value)
+				if err != nil {
+					log.Printf("Error parsing boolean value for route %s: %s\n",
+						conf.Name, err)
+				}
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["exclusive"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if b, ok := kv.Value.(*ast.Boolean); ok {
+				var err error
+				conf.Exclusive, err = strconv.ParseBool(b.Value)
+				if err != nil {
+					log.Printf("Error parsing boolean value for route %s: %s\n",
+						conf.Name, err)
+				}
+			}
+		}
+	}
+
+	if len(conf.Outputs) == 0 {
+		return nil, fmt.Errorf("route %s has no outputs", conf.Name)
+	}
+
+	delete(tbl.Fields, "name")
+	delete(tbl.Fields, "outputs")
+	delete(tbl.Fields, "default")
+	delete(tbl.Fields, "exclusive")
+	var err error
+	conf.Filter, err = buildFilter(tbl)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.Default && conf.Filter.IsActive() {
+		return nil, fmt.Errorf("default route %s cannot have filters", conf.Name)
+	}
+
+	for field := range tbl.Fields {
+		return nil, fmt.Errorf("route %s: unknown option %s", conf.Name, field)
+	}
+	return conf, nil
+}
+
 // buildFilter builds a Filter
-// (tagpass/tagdrop/namepass/namedrop/fieldpass/fielddrop) to
+// (tagpass/tagdrop/namepass/namedrop/fieldpass/fielddrop/metricpass) to
 // be inserted into the models.OutputConfig/models.InputConfig
 // to be used for glob filtering on tags and measurements
 func buildFilter(tbl *ast.Table) (models.Filter, error) {
@@ -1102,6 +1444,14 @@ func buildFilter(tbl *ast.Table) (models.Filter, error) {
 			}
 		}
 	}
+	if node, ok := tbl.Fields["metricpass"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				f.MetricPass = str.Value
+			}
+		}
+	}
+
 	if err := f.Compile(); err != nil {
 		return f, err
 	}
@@ -1116,6 +1466,7 @@ func buildFilter(tbl *ast.Table) (models.Filter, error) {
 	delete(tbl.Fields, "tagpass")
 	delete(tbl.Fields, "tagexclude")
 	delete(tbl.Fields, "taginclude")
+	delete(tbl.Fields, "metricpass")
 	return f, nil
 }
 
@@ -1137,6 +1488,62 @@ func buildInput(name string, tbl *ast.Table) (*models.InputConfig, error) {
 		}
 	}
 
+	var schedule, scheduleTimezone string
+	var scheduleJitter time.Duration
+	if node, ok := tbl.Fields["schedule"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				schedule = str.Value
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["schedule_timezone"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				scheduleTimezone = str.Value
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["schedule_jitter"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				dur, err := time.ParseDuration(str.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				scheduleJitter = dur
+			}
+		}
+	}
+
+	if schedule != "" {
+		var err error
+		cp.Schedule, err = models.NewSchedule(schedule, scheduleTimezone,
+			scheduleJitter)
+		if err != nil {
+			return nil, err
+		}
+	} else if scheduleTimezone != "" || scheduleJitter != 0 {
+		return nil, fmt.Errorf("schedule_timezone and schedule_jitter " +
+			"require a schedule")
+	}
+
+	if node, ok := tbl.Fields["gather_timeout"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				dur, err := time.ParseDuration(str.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				cp.Timeout = dur
+			}
+		}
+	}
+
 	if node, ok := tbl.Fields["name_prefix"]; ok {
 		if kv, ok := node.(*ast.KeyValue); ok {
 			if str, ok := kv.Value.(*ast.String); ok {
@@ -1174,8 +1581,17 @@ func buildInput(name string, tbl *ast.Table) (*models.InputConfig, error) {
 	delete(tbl.Fields, "name_suffix")
 	delete(tbl.Fields, "name_override")
 	delete(tbl.Fields, "interval")
+	delete(tbl.Fields, "gather_timeout")
+	delete(tbl.Fields, "schedule")
+	delete(tbl.Fields, "schedule_timezone")
+	delete(tbl.Fields, "schedule_jitter")
 	delete(tbl.Fields, "tags")
 	var err error
+	cp.Alias = buildAlias(tbl)
+	cp.LogLevel, err = buildLogLevel(tbl)
+	if err != nil {
+		return nil, err
+	}
 	cp.Filter, err = buildFilter(tbl)
 	if err != nil {
 		return cp, err
@@ -1183,6 +1599,61 @@ func buildInput(name string, tbl *ast.Table) (*models.InputConfig, error) {
 	return cp, nil
 }
 
+// buildOrder parses the order of a processor or aggregator, the plugins of
+// each type are sorted by it.
+func buildOrder(name string, tbl *ast.Table) int64 {
+	var order int64
+	if node, ok := tbl.Fields["order"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if b, ok := kv.Value.(*ast.Integer); ok {
+				var err error
+				order, err = strconv.ParseInt(b.Value, 10, 64)
+				if err != nil {
+					log.Printf("Error parsing int value for %s: %s\n", name, err)
+				}
+			}
+		}
+	}
+
+	delete(tbl.Fields, "order")
+	return order
+}
+
+// buildAlias parses the alias of a plugin, used to tell apart several instances
+// of the same plugin in the logs and the internal stats.
+func buildAlias(tbl *ast.Table) string {
+	var alias string
+	if node, ok := tbl.Fields["alias"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				alias = str.Value
+			}
+		}
+	}
+
+	delete(tbl.Fields, "alias")
+	return alias
+}
+
+// buildLogLevel parses the log_level of a plugin, which overrides the agent
+// log level for the messages of the plugin.
+func buildLogLevel(tbl *ast.Table) (string, error) {
+	var level string
+	if node, ok := tbl.Fields["log_level"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				if _, err := logger.ParseLevel(str.Value); err != nil {
+					return "", err
+				}
+				level = str.Value
+			}
+		}
+	}
+
+	delete(tbl.Fields, "log_level")
+	return level, nil
+}
+
 // buildParser grabs the necessary entries from the ast.Table for creating
 // a parsers.Parser object, and creates it, which can then be added onto
 // an Input object.
@@ -1409,5 +1880,68 @@ func buildOutput(name string, tbl *ast.Table) (*models.OutputConfig, error) {
 	if len(oc.Filter.FieldPass) > 0 {
 		oc.Filter.NamePass = oc.Filter.FieldPass
 	}
+
+	if node, ok := tbl.Fields["flush_interval"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				dur, err := time.ParseDuration(str.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				oc.FlushInterval = dur
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["flush_jitter"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if str, ok := kv.Value.(*ast.String); ok {
+				dur, err := time.ParseDuration(str.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				oc.FlushJitter = dur
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["metric_batch_size"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if integer, ok := kv.Value.(*ast.Integer); ok {
+				v, err := integer.Int()
+				if err != nil {
+					return nil, err
+				}
+
+				oc.MetricBatchSize = int(v)
+			}
+		}
+	}
+
+	if node, ok := tbl.Fields["metric_buffer_limit"]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			if integer, ok := kv.Value.(*ast.Integer); ok {
+				v, err := integer.Int()
+				if err != nil {
+					return nil, err
+				}
+
+				oc.MetricBufferLimit = int(v)
+			}
+		}
+	}
+
+	delete(tbl.Fields, "flush_interval")
+	delete(tbl.Fields, "flush_jitter")
+	delete(tbl.Fields, "metric_batch_size")
+	delete(tbl.Fields, "metric_buffer_limit")
+
+	oc.Alias = buildAlias(tbl)
+	oc.LogLevel, err = buildLogLevel(tbl)
+	if err != nil {
+		return nil, err
+	}
 	return oc, nil
 }
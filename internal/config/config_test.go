@@ -6,11 +6,16 @@ import (
 	"time"
 
 	"github.com/influxdata/telegraf/internal/models"
+	_ "github.com/influxdata/telegraf/plugins/aggregators/basicstats"
+	_ "github.com/influxdata/telegraf/plugins/aggregators/minmax"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	"github.com/influxdata/telegraf/plugins/inputs/exec"
 	"github.com/influxdata/telegraf/plugins/inputs/memcached"
 	"github.com/influxdata/telegraf/plugins/inputs/procstat"
+	_ "github.com/influxdata/telegraf/plugins/outputs/file"
 	"github.com/influxdata/telegraf/plugins/parsers"
+	_ "github.com/influxdata/telegraf/plugins/processors/printer"
+	_ "github.com/influxdata/telegraf/plugins/processors/starlark"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -174,3 +179,201 @@ func TestConfig_LoadDirectory(t *testing.T) {
 	assert.Equal(t, pConfig, c.Inputs[3].Config,
 		"Merged Testdata did not produce correct procstat metadata.")
 }
+
+func TestConfig_LoadRoutes(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/routes.toml")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(c.Routes))
+
+	filter := models.Filter{
+		NamePass: []string{"cpu"},
+		TagPass: []models.TagFilter{
+			models.TagFilter{
+				Name:   "cpu",
+				Filter: []string{"cpu-total"},
+			},
+		},
+	}
+	assert.NoError(t, filter.Compile())
+	assert.Equal(t, &models.RouteConfig{
+		Name:      "cpu",
+		Outputs:   []string{"influxdb"},
+		Exclusive: true,
+		Filter:    filter,
+	}, c.Routes[0].Config)
+
+	assert.Equal(t, &models.RouteConfig{
+		Name:    "fallback",
+		Outputs: []string{"file"},
+		Default: true,
+	}, c.Routes[1].Config)
+}
+
+func TestConfig_LoadMetricPass(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/metricpass.toml")
+	assert.NoError(t, err)
+
+	filter := models.Filter{
+		MetricPass: `fields.evictions > 0 and tags.server =~ "^local"`,
+	}
+	assert.NoError(t, filter.Compile())
+	mConfig := &models.InputConfig{
+		Name:   "memcached",
+		Filter: filter,
+	}
+	mConfig.Tags = make(map[string]string)
+
+	assert.Equal(t, mConfig, c.Inputs[0].Config,
+		"Testdata did not produce correct memcached metadata.")
+}
+
+func TestConfig_LoadAggregatorPeriod(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/aggregator_period.toml")
+	assert.NoError(t, err)
+
+	aConfig := &models.AggregatorConfig{
+		Name:      "minmax",
+		Period:    5 * time.Minute,
+		Delay:     time.Second,
+		Grace:     30 * time.Second,
+		Align:     true,
+		Timestamp: "start",
+		Tags:      make(map[string]string),
+	}
+
+	assert.Len(t, c.Aggregators, 1)
+	assert.Equal(t, aConfig, c.Aggregators[0].Config,
+		"Testdata did not produce correct minmax metadata.")
+}
+
+func TestConfig_LoadGatherTimeout(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/gather_timeout.toml")
+	assert.NoError(t, err)
+
+	assert.Len(t, c.Inputs, 1)
+	assert.Equal(t, 20*time.Second, c.Inputs[0].Config.Timeout)
+	ex := c.Inputs[0].Input.(*exec.Exec)
+	assert.Equal(t, 5*time.Second, ex.Timeout.Duration)
+}
+
+func TestConfig_LoadOutputFlushSettings(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/output_flush.toml")
+	assert.NoError(t, err)
+	assert.Len(t, c.Outputs, 2)
+
+	ro := c.Outputs[0]
+	assert.Equal(t, 5*time.Minute, ro.Config.FlushInterval)
+	assert.Equal(t, 30*time.Second, ro.Config.FlushJitter)
+	assert.Equal(t, 5000, ro.MetricBatchSize)
+	assert.Equal(t, 100000, ro.MetricBufferLimit)
+
+	// unset values fall back to the agent settings
+	ro = c.Outputs[1]
+	assert.Equal(t, time.Duration(0), ro.Config.FlushInterval)
+	assert.Equal(t, 1000, ro.MetricBatchSize)
+	assert.Equal(t, 10000, ro.MetricBufferLimit)
+}
+
+func TestConfig_LoadInputSchedule(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/input_schedule.toml")
+	assert.NoError(t, err)
+
+	assert.Len(t, c.Inputs, 1)
+	s := c.Inputs[0].Config.Schedule
+	if assert.NotNil(t, s) {
+		assert.Equal(t, "5 * * * *", s.Spec)
+		assert.Equal(t, time.UTC, s.Location)
+		assert.Equal(t, 30*time.Second, s.Jitter)
+	}
+}
+
+func TestConfig_InvalidInputSchedule(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/invalid_input_schedule.toml")
+	assert.Error(t, err)
+}
+
+func TestConfig_InitError(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/invalid_starlark.toml")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "processors.starlark")
+	}
+}
+
+func TestConfig_LoadLogLevel(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/log_level.toml")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "json", c.Agent.LogFormat)
+	assert.Equal(t, 24*time.Hour, c.Agent.LogfileRotationMaxAge.Duration)
+	assert.Equal(t, int64(10*1000*1000), c.Agent.LogfileRotationMaxSize.Size)
+	assert.Equal(t, 5, c.Agent.LogfileRotationMaxArchives)
+
+	assert.Len(t, c.Inputs, 1)
+	assert.Equal(t, "debug", c.Inputs[0].Config.LogLevel)
+	assert.Len(t, c.Outputs, 1)
+	assert.Equal(t, "error", c.Outputs[0].Config.LogLevel)
+	assert.Equal(t, map[string]string{
+		"inputs.exec":  "debug",
+		"outputs.file": "error",
+	}, c.LogLevels())
+}
+
+func TestConfig_InvalidLogLevel(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/invalid_log_level.toml")
+	assert.Error(t, err)
+}
+
+func TestConfig_LoadAlias(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/alias.toml")
+	assert.NoError(t, err)
+
+	assert.Len(t, c.Inputs, 2)
+	assert.Equal(t, "first", c.Inputs[0].Config.Alias)
+	assert.Equal(t, "inputs.exec::first", c.Inputs[0].LogName())
+	assert.Equal(t, "second", c.Inputs[1].Config.Alias)
+	assert.Equal(t, []string{"/tmp/second.sh"},
+		c.Inputs[1].Input.(*exec.Exec).Commands)
+
+	assert.Len(t, c.Aggregators, 1)
+	assert.Equal(t, "hourly", c.Aggregators[0].Config.Alias)
+	assert.Equal(t, time.Hour, c.Aggregators[0].Config.Period)
+
+	assert.Len(t, c.Outputs, 1)
+	assert.Equal(t, "stdout", c.Outputs[0].Config.Alias)
+	assert.Equal(t, "outputs.file::stdout", c.Outputs[0].LogName())
+}
+
+func TestConfig_LoadOrder(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/order.toml")
+	assert.NoError(t, err)
+
+	assert.Len(t, c.Processors, 2)
+	assert.Equal(t, "first", c.Processors[0].Config.Alias)
+	assert.Equal(t, "second", c.Processors[1].Config.Alias)
+
+	assert.Len(t, c.AggProcessors, 1)
+	assert.Equal(t, "aggregates", c.AggProcessors[0].Config.Alias)
+	assert.True(t, c.AggProcessors[0].Config.AfterAggregators)
+
+	assert.Len(t, c.Aggregators, 2)
+	assert.Equal(t, "basicstats", c.Aggregators[0].Config.Name)
+	assert.Equal(t, "minmax", c.Aggregators[1].Config.Name)
+}
+
+func TestConfig_InvalidAggregatorTimestamp(t *testing.T) {
+	c := NewConfig()
+	err := c.LoadConfig("./testdata/invalid_aggregator_timestamp.toml")
+	assert.Error(t, err)
+}
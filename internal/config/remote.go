@@ -0,0 +1,277 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf/internal"
+)
+
+// RemoteConfig configures how config files given as http:// or https:// URLs
+// are fetched.
+type RemoteConfig struct {
+	// Headers are added to every request, ie, an Authorization header.
+	Headers map[string]string
+
+	// Path to CA file
+	SSLCA string
+	// Path to host cert file
+	SSLCert string
+	// Path to cert key file
+	SSLKey string
+	// Use SSL but skip chain & host verification
+	InsecureSkipVerify bool
+
+	// Timeout of each request, defaults to 10s.
+	Timeout time.Duration
+
+	// CacheDir is where the last good copy of each remote config file is
+	// kept, used when the server is unreachable. An empty string disables the
+	// cache.
+	CacheDir string
+}
+
+// remoteFile is a config file loaded from a URL, along with what is needed to
+// poll it for changes.
+type remoteFile struct {
+	url          string
+	etag         string
+	lastModified string
+	// sum is the checksum of the latest contents seen, which are not
+	// reported again as a change
+	sum [sha256.Size]byte
+
+	// contents are kept until they are cached, unless they were read from
+	// the cache
+	contents []byte
+}
+
+// isURL returns true if the config path is a http:// or https:// URL.
+func isURL(path string) bool {
+	return strings.HasPrefix(path, "http://") ||
+		strings.HasPrefix(path, "https://")
+}
+
+// remoteIdleConnTimeout closes the connections kept alive between polls that
+// are no longer used, ie, by the client of a config that has been replaced.
+const remoteIdleConnTimeout = 90 * time.Second
+
+// remoteClient returns the client fetching the remote config files, which
+// is created once per config and reused by every poll.
+func (c *Config) remoteClient() (*http.Client, error) {
+	c.remoteMu.Lock()
+	defer c.remoteMu.Unlock()
+	if c.remoteHTTP != nil {
+		return c.remoteHTTP, nil
+	}
+
+	tlsCfg, err := internal.GetTLSConfig(c.Remote.SSLCert, c.Remote.SSLKey,
+		c.Remote.SSLCA, c.Remote.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := c.Remote.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	c.remoteHTTP = &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsCfg,
+			IdleConnTimeout: remoteIdleConnTimeout,
+		},
+		Timeout: timeout,
+	}
+	return c.remoteHTTP, nil
+}
+
+// request fetches the file, conditionally on it having changed when the
+// file has an etag or a last modification time. A nil body is returned when
+// the file has not changed.
+func (c *Config) request(f *remoteFile) ([]byte, error) {
+	client, err := c.remoteClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", f.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.Remote.Headers {
+		if strings.ToLower(k) == "host" {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	if f.etag != "" {
+		req.Header.Set("If-None-Match", f.etag)
+	}
+	if f.lastModified != "" {
+		req.Header.Set("If-Modified-Since", f.lastModified)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP status %s", f.url, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.etag = resp.Header.Get("ETag")
+	f.lastModified = resp.Header.Get("Last-Modified")
+	return body, nil
+}
+
+// cachePath returns the path of the cached copy of a remote config file, or
+// an empty string when the cache is disabled.
+func (c *Config) cachePath(url string) string {
+	if c.Remote.CacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Remote.CacheDir, hex.EncodeToString(sum[:])+".conf")
+}
+
+// fetchConfig fetches a remote config file, falling back to the cached copy
+// when the server cannot be reached. The file is only cached by
+// CacheRemoteConfig, once the whole config has been loaded.
+func (c *Config) fetchConfig(url string) ([]byte, error) {
+	f := &remoteFile{url: url}
+	contents, err := c.request(f)
+	if err == nil {
+		f.contents = contents
+	} else {
+		cache := c.cachePath(url)
+		if cache == "" {
+			return nil, err
+		}
+		cached, cacheErr := ioutil.ReadFile(cache)
+		if cacheErr != nil {
+			return nil, err
+		}
+		log.Printf("W! Unable to fetch %s (%s), using the cached copy %s",
+			url, err, cache)
+		contents = cached
+	}
+
+	f.sum = sha256.Sum256(contents)
+	c.remoteFiles = append(c.remoteFiles, f)
+	return contents, nil
+}
+
+// CacheRemoteConfig keeps a copy of the config files fetched from URLs, used
+// when the server cannot be reached. It is called once the config has been
+// loaded successfully, so that only good copies are cached.
+func (c *Config) CacheRemoteConfig() {
+	for _, f := range c.remoteFiles {
+		cache := c.cachePath(f.url)
+		if cache == "" || f.contents == nil {
+			continue
+		}
+		if err := writeCache(cache, f.contents); err != nil {
+			log.Printf("W! Unable to cache %s: %s", f.url, err)
+			continue
+		}
+		f.contents = nil
+	}
+}
+
+// writeCache replaces the cached copy, going through a temporary file so that
+// a partial write never replaces a good copy.
+func writeCache(path string, contents []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, contents, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// HasRemoteConfig returns true if any of the loaded config files was fetched
+// from a URL.
+func (c *Config) HasRemoteConfig() bool {
+	return len(c.remoteFiles) > 0
+}
+
+// WatchRemoteConfig polls the config files fetched from URLs every interval,
+// and closes changed once the contents of one of them has changed and can be
+// loaded. It returns then, or on shutdown. Contents that can't be loaded are
+// logged and ignored until they change again.
+func (c *Config) WatchRemoteConfig(
+	interval time.Duration,
+	shutdown chan struct{},
+	changed chan struct{},
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-ticker.C:
+		}
+
+		for _, f := range c.remoteFiles {
+			contents, err := c.request(f)
+			if err != nil {
+				log.Printf("W! Unable to poll %s for changes: %s", f.url, err)
+				continue
+			}
+			if contents == nil {
+				continue
+			}
+			// servers that do not support conditional requests return the
+			// file every time
+			sum := sha256.Sum256(contents)
+			if sum == f.sum {
+				continue
+			}
+			f.sum = sum
+			if err := c.checkRemoteConfig(f.url, contents); err != nil {
+				log.Printf("E! Config file %s has changed but can't be loaded, "+
+					"keeping the current config: %s", f.url, err)
+				continue
+			}
+			log.Printf("I! Config file %s has changed", f.url)
+			close(changed)
+			return
+		}
+	}
+}
+
+// checkRemoteConfig loads the new contents of a remote config file into an
+// empty config, to check that the file can be loaded before reloading.
+func (c *Config) checkRemoteConfig(url string, contents []byte) error {
+	tbl, err := parseConfig(contents)
+	if err != nil {
+		return err
+	}
+	check := NewConfig()
+	check.InputFilters = c.InputFilters
+	check.OutputFilters = c.OutputFilters
+	return check.loadTable(url, tbl)
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// configServer serves a config file with an ETag, and requires a token.
+type configServer struct {
+	sync.Mutex
+	contents string
+	etag     string
+	requests int
+}
+
+func (s *configServer) set(contents, etag string) {
+	s.Lock()
+	defer s.Unlock()
+	s.contents = contents
+	s.etag = etag
+}
+
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	s.requests++
+	if r.Header.Get("Authorization") != "Token secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	w.Write([]byte(s.contents))
+}
+
+const remoteConfig = `
+[[inputs.memcached]]
+  servers = ["localhost"]
+`
+
+func TestConfig_LoadRemote(t *testing.T) {
+	server := &configServer{}
+	server.set(remoteConfig, `"1"`)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	c := NewConfig()
+	c.Remote.Headers = map[string]string{"Authorization": "Token secret"}
+	err := c.LoadConfig(ts.URL + "/telegraf.conf")
+	require.NoError(t, err)
+	assert.Len(t, c.Inputs, 1)
+	assert.True(t, c.HasRemoteConfig())
+
+	c = NewConfig()
+	err = c.LoadConfig(ts.URL + "/telegraf.conf")
+	assert.Error(t, err)
+}
+
+func TestConfig_LoadRemoteCached(t *testing.T) {
+	dir, err := ioutil.TempDir("", "telegraf")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	server := &configServer{}
+	ts := httptest.NewServer(server)
+	url := ts.URL + "/telegraf.conf"
+
+	// the file is not cached until the whole config has been loaded
+	server.set("[[inputs.memcached]", `"0"`)
+	c := NewConfig()
+	c.Remote.Headers = map[string]string{"Authorization": "Token secret"}
+	c.Remote.CacheDir = dir
+	require.Error(t, c.LoadConfig(url))
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+
+	server.set(remoteConfig, `"1"`)
+	c = NewConfig()
+	c.Remote.Headers = map[string]string{"Authorization": "Token secret"}
+	c.Remote.CacheDir = dir
+	require.NoError(t, c.LoadConfig(url))
+	c.CacheRemoteConfig()
+
+	// the cached copy is used once the server is gone
+	ts.Close()
+	c = NewConfig()
+	c.Remote.CacheDir = dir
+	require.NoError(t, c.LoadConfig(url))
+	assert.Len(t, c.Inputs, 1)
+
+	c = NewConfig()
+	assert.Error(t, c.LoadConfig(url))
+}
+
+func TestConfig_WatchRemote(t *testing.T) {
+	server := &configServer{}
+	server.set(remoteConfig, `"1"`)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	c := NewConfig()
+	c.Remote.Headers = map[string]string{"Authorization": "Token secret"}
+	require.NoError(t, c.LoadConfig(ts.URL+"/telegraf.conf"))
+
+	shutdown := make(chan struct{})
+	defer close(shutdown)
+	changed := make(chan struct{})
+	go c.WatchRemoteConfig(10*time.Millisecond, shutdown, changed)
+
+	// unchanged while the server answers not modified
+	select {
+	case <-changed:
+		t.Fatal("config reported as changed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// changes that can't be loaded are ignored
+	server.set(remoteConfig+"\n[[inputs.undefined]]\n", `"2"`)
+	select {
+	case <-changed:
+		t.Fatal("config that can't be loaded reported as changed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	server.set(remoteConfig+"\n[[inputs.memcached]]\n", `"3"`)
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("config change not detected")
+	}
+}
+
+func TestConfig_WatchRemoteReusesConnection(t *testing.T) {
+	server := &configServer{}
+	server.set(remoteConfig, `"1"`)
+	ts := httptest.NewUnstartedServer(server)
+	var mu sync.Mutex
+	conns := 0
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	c := NewConfig()
+	c.Remote.Headers = map[string]string{"Authorization": "Token secret"}
+	require.NoError(t, c.LoadConfig(ts.URL+"/telegraf.conf"))
+
+	shutdown := make(chan struct{})
+	changed := make(chan struct{})
+	go c.WatchRemoteConfig(10*time.Millisecond, shutdown, changed)
+	time.Sleep(100 * time.Millisecond)
+	close(shutdown)
+
+	server.Lock()
+	requests := server.requests
+	server.Unlock()
+	mu.Lock()
+	defer mu.Unlock()
+	assert.True(t, requests > 2, "%d requests", requests)
+	assert.Equal(t, 1, conns)
+}
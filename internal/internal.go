@@ -67,6 +67,55 @@ func (d *Duration) UnmarshalTOML(b []byte) error {
 	return nil
 }
 
+// Size is a number of bytes
+type Size struct {
+	Size int64
+}
+
+var sizeUnits = map[string]int64{
+	"B":   1,
+	"KB":  1000,
+	"KIB": 1 << 10,
+	"MB":  1000 * 1000,
+	"MIB": 1 << 20,
+	"GB":  1000 * 1000 * 1000,
+	"GIB": 1 << 30,
+}
+
+// UnmarshalTOML parses the size from the TOML config file, either as an
+// integer number of bytes or as a string with a unit, ie, "10MB" or "512KiB"
+func (s *Size) UnmarshalTOML(b []byte) error {
+	var err error
+	b = bytes.Trim(b, `'`)
+
+	s.Size, err = strconv.ParseInt(string(b), 10, 64)
+	if err == nil {
+		return nil
+	}
+
+	uq := string(b)
+	if unquoted, err := strconv.Unquote(uq); err == nil {
+		uq = unquoted
+	}
+	uq = strings.TrimSpace(uq)
+	i := strings.IndexFunc(uq, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	if i <= 0 {
+		return fmt.Errorf("invalid size %q", uq)
+	}
+	unit, ok := sizeUnits[strings.ToUpper(strings.TrimSpace(uq[i:]))]
+	if !ok {
+		return fmt.Errorf("invalid size %q, unknown unit", uq)
+	}
+	n, err := strconv.ParseInt(uq[:i], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid size %q: %s", uq, err)
+	}
+	s.Size = n * unit
+	return nil
+}
+
 // ReadLines reads contents from a file and splits them by new lines.
 // A convenience wrapper to ReadLinesOffsetN(filename, 0, -1).
 func ReadLines(filename string) ([]string, error) {
@@ -162,3 +162,22 @@ func TestDuration(t *testing.T) {
 	d.UnmarshalTOML([]byte(`1.5`))
 	assert.Equal(t, time.Second, d.Duration)
 }
+
+func TestSize(t *testing.T) {
+	var s Size
+
+	assert.NoError(t, s.UnmarshalTOML([]byte(`1024`)))
+	assert.Equal(t, int64(1024), s.Size)
+
+	s = Size{}
+	assert.NoError(t, s.UnmarshalTOML([]byte(`"10MB"`)))
+	assert.Equal(t, int64(10*1000*1000), s.Size)
+
+	s = Size{}
+	assert.NoError(t, s.UnmarshalTOML([]byte(`'512KiB'`)))
+	assert.Equal(t, int64(512*1024), s.Size)
+
+	s = Size{}
+	assert.Error(t, s.UnmarshalTOML([]byte(`"10 parsecs"`)))
+	assert.Error(t, s.UnmarshalTOML([]byte(`"MB"`)))
+}
@@ -0,0 +1,23 @@
+package models
+
+// An alias distinguishes the instances of a plugin that is configured more
+// than once, in the log messages, the internal stats and the API.
+
+// logName returns the name of a plugin in the log messages, ie, "inputs.cpu"
+// or "inputs.cpu::alias" when the plugin has an alias.
+func logName(pluginType, name, alias string) string {
+	if alias == "" {
+		return pluginType + "." + name
+	}
+	return pluginType + "." + name + "::" + alias
+}
+
+// pluginTags returns the tags of the internal stats of a plugin, the name of
+// the plugin is tagged with key and the alias, if any, with "alias".
+func pluginTags(key, name, alias string) map[string]string {
+	tags := map[string]string{key: name}
+	if alias != "" {
+		tags["alias"] = alias
+	}
+	return tags
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/influxdata/telegraf/filter"
 )
@@ -33,6 +34,11 @@ type Filter struct {
 	TagInclude []string
 	tagInclude filter.Filter
 
+	// MetricPass is a boolean expression, only metrics for which it is
+	// true are passed.
+	MetricPass string
+	metricPass *filter.Expression
+
 	isActive bool
 }
 
@@ -45,7 +51,8 @@ func (f *Filter) Compile() error {
 		len(f.TagInclude) == 0 &&
 		len(f.TagExclude) == 0 &&
 		len(f.TagPass) == 0 &&
-		len(f.TagDrop) == 0 {
+		len(f.TagDrop) == 0 &&
+		f.MetricPass == "" {
 		return nil
 	}
 
@@ -90,17 +97,25 @@ func (f *Filter) Compile() error {
 			return fmt.Errorf("Error compiling 'tagpass', %s", err)
 		}
 	}
+
+	if f.MetricPass != "" {
+		f.metricPass, err = filter.CompileExpression(f.MetricPass)
+		if err != nil {
+			return fmt.Errorf("Error compiling 'metricpass', %s", err)
+		}
+	}
 	return nil
 }
 
-// Apply applies the filter to the given measurement name, fields map, and
-// tags map. It will return false if the metric should be "filtered out", and
-// true if the metric should "pass".
+// Apply applies the filter to the given measurement name, fields map, tags
+// map and time. It will return false if the metric should be "filtered out",
+// and true if the metric should "pass".
 // It will modify tags & fields in-place if they need to be deleted.
 func (f *Filter) Apply(
 	measurement string,
 	fields map[string]interface{},
 	tags map[string]string,
+	t time.Time,
 ) bool {
 	if !f.isActive {
 		return true
@@ -116,6 +131,12 @@ func (f *Filter) Apply(
 		return false
 	}
 
+	// check if the metricpass expression is true, this is done before
+	// filtering fields and tags so that the expression sees all of them.
+	if !f.shouldMetricPass(measurement, fields, tags, t) {
+		return false
+	}
+
 	// filter fields
 	for fieldkey, _ := range fields {
 		if !f.shouldFieldPass(fieldkey) {
@@ -132,6 +153,25 @@ func (f *Filter) Apply(
 	return true
 }
 
+// Match returns true if the given metric passes the namepass/namedrop,
+// tagpass/tagdrop and metricpass rules. Unlike Apply, it never modifies the
+// metric, so it can be used to select metrics without filtering their fields
+// or tags.
+func (f *Filter) Match(
+	measurement string,
+	fields map[string]interface{},
+	tags map[string]string,
+	t time.Time,
+) bool {
+	if !f.isActive {
+		return true
+	}
+
+	return f.shouldNamePass(measurement) &&
+		f.shouldTagsPass(tags) &&
+		f.shouldMetricPass(measurement, fields, tags, t)
+}
+
 // IsActive checking if filter is active
 func (f *Filter) IsActive() bool {
 	return f.isActive
@@ -242,6 +282,20 @@ func (f *Filter) shouldTagsPass(tags map[string]string) bool {
 	return true
 }
 
+// shouldMetricPass returns true if the metric should pass, false if should
+// drop based on the metricpass expression
+func (f *Filter) shouldMetricPass(
+	measurement string,
+	fields map[string]interface{},
+	tags map[string]string,
+	t time.Time,
+) bool {
+	if f.metricPass == nil {
+		return true
+	}
+	return f.metricPass.Eval(measurement, fields, tags, t)
+}
+
 // Apply TagInclude and TagExclude filters.
 // modifies the tags map in-place.
 func (f *Filter) filterTags(tags map[string]string) {
@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,7 +13,7 @@ func TestFilter_ApplyEmpty(t *testing.T) {
 	require.NoError(t, f.Compile())
 	assert.False(t, f.IsActive())
 
-	assert.True(t, f.Apply("m", map[string]interface{}{"value": int64(1)}, map[string]string{}))
+	assert.True(t, f.Apply("m", map[string]interface{}{"value": int64(1)}, map[string]string{}, time.Now()))
 }
 
 func TestFilter_ApplyTagsDontPass(t *testing.T) {
@@ -31,7 +32,8 @@ func TestFilter_ApplyTagsDontPass(t *testing.T) {
 
 	assert.False(t, f.Apply("m",
 		map[string]interface{}{"value": int64(1)},
-		map[string]string{"cpu": "cpu-total"}))
+		map[string]string{"cpu": "cpu-total"},
+		time.Now()))
 }
 
 func TestFilter_ApplyDeleteFields(t *testing.T) {
@@ -43,7 +45,7 @@ func TestFilter_ApplyDeleteFields(t *testing.T) {
 	assert.True(t, f.IsActive())
 
 	fields := map[string]interface{}{"value": int64(1), "value2": int64(2)}
-	assert.True(t, f.Apply("m", fields, nil))
+	assert.True(t, f.Apply("m", fields, nil, time.Now()))
 	assert.Equal(t, map[string]interface{}{"value2": int64(2)}, fields)
 }
 
@@ -56,7 +58,7 @@ func TestFilter_ApplyDeleteAllFields(t *testing.T) {
 	assert.True(t, f.IsActive())
 
 	fields := map[string]interface{}{"value": int64(1), "value2": int64(2)}
-	assert.False(t, f.Apply("m", fields, nil))
+	assert.False(t, f.Apply("m", fields, nil, time.Now()))
 }
 
 func TestFilter_Empty(t *testing.T) {
@@ -442,3 +444,44 @@ func TestFilter_FilterTagsPassAndDrop(t *testing.T) {
 	}
 
 }
+
+func TestFilter_MetricPass(t *testing.T) {
+	f := Filter{
+		MetricPass: `fields.usage_idle > 99 and tags.cpu != "cpu-total"`,
+	}
+	require.NoError(t, f.Compile())
+	assert.True(t, f.IsActive())
+
+	now := time.Now()
+	assert.True(t, f.Apply("cpu",
+		map[string]interface{}{"usage_idle": 99.5},
+		map[string]string{"cpu": "cpu0"},
+		now))
+	assert.False(t, f.Apply("cpu",
+		map[string]interface{}{"usage_idle": 99.5},
+		map[string]string{"cpu": "cpu-total"},
+		now))
+	assert.False(t, f.Apply("cpu",
+		map[string]interface{}{"usage_idle": 50.0},
+		map[string]string{"cpu": "cpu0"},
+		now))
+}
+
+func TestFilter_MetricPassBeforeFieldDrop(t *testing.T) {
+	f := Filter{
+		FieldDrop:  []string{"usage_idle"},
+		MetricPass: `fields.usage_idle < 10`,
+	}
+	require.NoError(t, f.Compile())
+
+	fields := map[string]interface{}{"usage_idle": 5.0, "usage_user": 95.0}
+	assert.True(t, f.Apply("cpu", fields, map[string]string{}, time.Now()))
+	assert.Equal(t, map[string]interface{}{"usage_user": 95.0}, fields)
+}
+
+func TestFilter_MetricPassInvalid(t *testing.T) {
+	f := Filter{
+		MetricPass: `fields.usage_idle >`,
+	}
+	require.Error(t, f.Compile())
+}
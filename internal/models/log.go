@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/influxdata/telegraf"
+)
+
+// Logger is the telegraf.Logger of a plugin, its messages are prefixed with
+// the name of the plugin, ie, "E! [inputs.cpu] message".
+type Logger struct {
+	Name string
+
+	// OnError, when set, is called with each error logged, so that the errors
+	// of the plugin are counted with the errors it reports to the accumulator.
+	OnError func(err error)
+}
+
+// NewLogger returns the logger of the plugin named name, ie, "inputs.cpu".
+func NewLogger(name string) *Logger {
+	return &Logger{Name: name}
+}
+
+// Errorf logs an error message, patterned after log.Printf.
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.error(fmt.Sprintf(format, args...))
+}
+
+// Error logs an error message, patterned after log.Print.
+func (l *Logger) Error(args ...interface{}) {
+	l.error(fmt.Sprint(args...))
+}
+
+// Warnf logs a warning message, patterned after log.Printf.
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.print("W!", fmt.Sprintf(format, args...))
+}
+
+// Warn logs a warning message, patterned after log.Print.
+func (l *Logger) Warn(args ...interface{}) {
+	l.print("W!", fmt.Sprint(args...))
+}
+
+// Infof logs an information message, patterned after log.Printf.
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.print("I!", fmt.Sprintf(format, args...))
+}
+
+// Info logs an information message, patterned after log.Print.
+func (l *Logger) Info(args ...interface{}) {
+	l.print("I!", fmt.Sprint(args...))
+}
+
+// Debugf logs a debug message, patterned after log.Printf.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.print("D!", fmt.Sprintf(format, args...))
+}
+
+// Debug logs a debug message, patterned after log.Print.
+func (l *Logger) Debug(args ...interface{}) {
+	l.print("D!", fmt.Sprint(args...))
+}
+
+func (l *Logger) error(msg string) {
+	if l.OnError != nil {
+		l.OnError(errors.New(msg))
+	}
+	l.print("E!", msg)
+}
+
+func (l *Logger) print(prefix string, msg string) {
+	log.Printf("%s [%s] %s", prefix, l.Name, msg)
+}
+
+var loggerType = reflect.TypeOf((*telegraf.Logger)(nil)).Elem()
+
+// SetLoggerOnPlugin sets the exported Log field of the plugin, if it has one
+// of type telegraf.Logger.
+func SetLoggerOnPlugin(plugin interface{}, l telegraf.Logger) {
+	v := reflect.ValueOf(plugin)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	field := v.FieldByName("Log")
+	if !field.IsValid() || !field.CanSet() || field.Type() != loggerType {
+		return
+	}
+	field.Set(reflect.ValueOf(l))
+}
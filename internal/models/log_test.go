@@ -0,0 +1,59 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/influxdata/telegraf"
+	"github.com/stretchr/testify/assert"
+)
+
+type loggingInput struct {
+	testInput
+	Log telegraf.Logger `toml:"-"`
+}
+
+func TestSetLoggerOnPlugin(t *testing.T) {
+	i := &loggingInput{}
+	ri := NewRunningInput(i, &InputConfig{Name: "logging"})
+	assert.Equal(t, ri.Log(), i.Log)
+
+	// plugins without a Log field are left alone
+	SetLoggerOnPlugin(&testInput{}, ri.Log())
+	SetLoggerOnPlugin(testInput{}, ri.Log())
+}
+
+func TestLoggerPrefix(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log.SetOutput(buf)
+	defer log.SetOutput(os.Stderr)
+
+	l := NewLogger("inputs.logging")
+	l.Errorf("Failed to %s", "gather")
+	l.Debug("Gathered ", 3, " metrics")
+
+	assert.Contains(t, buf.String(), "E! [inputs.logging] Failed to gather\n")
+	assert.Contains(t, buf.String(), "D! [inputs.logging] Gathered 3 metrics\n")
+}
+
+func TestLoggerOnError(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log.SetOutput(buf)
+	defer log.SetOutput(os.Stderr)
+
+	var errs []error
+	l := NewLogger("inputs.logging")
+	l.OnError = func(err error) { errs = append(errs, err) }
+
+	l.Errorf("Failed to %s", "gather")
+	l.Error("Failed to ", "parse")
+	l.Warn("Slow gather")
+
+	assert.Equal(t, []error{
+		errors.New("Failed to gather"),
+		errors.New("Failed to parse"),
+	}, errs)
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/influxdata/telegraf/selfstat"
+)
+
+// pluginErrors counts the errors of a plugin and keeps the last one, they are
+// reported by the internal input.
+type pluginErrors struct {
+	stat *selfstat.ErrorStat
+}
+
+func newPluginErrors(measurement string, tags map[string]string) pluginErrors {
+	return pluginErrors{stat: selfstat.RegisterError(measurement, tags)}
+}
+
+// SetError counts err and records it as the last error of the plugin, nil is
+// ignored.
+func (e *pluginErrors) SetError(err error) {
+	if e.stat == nil {
+		return
+	}
+	e.stat.Set(err)
+}
+
+// Errors returns the number of errors of the plugin.
+func (e *pluginErrors) Errors() int64 {
+	if e.stat == nil {
+		return 0
+	}
+	return e.stat.Count()
+}
+
+// LastError returns the message of the last error and when it occurred. The
+// message is empty if the plugin has not had any error.
+func (e *pluginErrors) LastError() (string, time.Time) {
+	if e.stat == nil {
+		return "", time.Time{}
+	}
+	return e.stat.Last()
+}
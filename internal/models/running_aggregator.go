@@ -15,23 +15,50 @@ type RunningAggregator struct {
 
 	periodStart time.Time
 	periodEnd   time.Time
+
+	// pending holds the metrics that belong to a later period and arrived
+	// before the current period was pushed.
+	pending []telegraf.Metric
+	// pushTime is the timestamp given to the aggregates while pushing.
+	pushTime time.Time
+
+	pluginErrors
+	logger *Logger
 }
 
 func NewRunningAggregator(
 	a telegraf.Aggregator,
 	conf *AggregatorConfig,
 ) *RunningAggregator {
-	return &RunningAggregator{
+	logger := NewLogger(logName("aggregators", conf.Name, conf.Alias))
+	SetLoggerOnPlugin(a, logger)
+
+	ra := &RunningAggregator{
 		a:       a,
 		Config:  conf,
 		metrics: make(chan telegraf.Metric, 100),
+		pluginErrors: newPluginErrors(
+			"aggregate",
+			pluginTags("aggregator", conf.Name, conf.Alias),
+		),
+		logger: logger,
 	}
+	logger.OnError = ra.SetError
+	return ra
 }
 
+type RunningAggregators []*RunningAggregator
+
+func (ra RunningAggregators) Len() int           { return len(ra) }
+func (ra RunningAggregators) Swap(i, j int)      { ra[i], ra[j] = ra[j], ra[i] }
+func (ra RunningAggregators) Less(i, j int) bool { return ra[i].Config.Order < ra[j].Config.Order }
+
 // AggregatorConfig containing configuration parameters for the running
 // aggregator plugin.
 type AggregatorConfig struct {
-	Name string
+	Name  string
+	Alias string
+	Order int64
 
 	DropOriginal      bool
 	NameOverride      string
@@ -42,12 +69,34 @@ type AggregatorConfig struct {
 
 	Period time.Duration
 	Delay  time.Duration
+	// Grace is how long after the end of a period metrics with a timestamp
+	// within the period are still accepted.
+	Grace time.Duration
+	// Align the periods to multiples of Period, instead of to the time the
+	// aggregator was started.
+	Align bool
+	// Timestamp sets the time of the aggregates to the "start", "end" or
+	// "midpoint" of the period. By default the time they are pushed is used.
+	Timestamp string
+	// LogLevel, when set, overrides the log level for the aggregator.
+	LogLevel string
 }
 
 func (r *RunningAggregator) Name() string {
 	return "aggregators." + r.Config.Name
 }
 
+// LogName returns the name of the aggregator including its alias, ie,
+// "aggregators.minmax::alias".
+func (r *RunningAggregator) LogName() string {
+	return logName("aggregators", r.Config.Name, r.Config.Alias)
+}
+
+// Log returns the logger of the aggregator.
+func (r *RunningAggregator) Log() telegraf.Logger {
+	return r.logger
+}
+
 func (r *RunningAggregator) MakeMetric(
 	measurement string,
 	fields map[string]interface{},
@@ -55,6 +104,10 @@ func (r *RunningAggregator) MakeMetric(
 	mType telegraf.ValueType,
 	t time.Time,
 ) telegraf.Metric {
+	if !r.pushTime.IsZero() {
+		t = r.pushTime
+	}
+
 	m := makemetric(
 		measurement,
 		fields,
@@ -81,22 +134,49 @@ func (r *RunningAggregator) MakeMetric(
 // Before applying to the plugin, it will run any defined filters on the metric.
 // Apply returns true if the original metric should be dropped.
 func (r *RunningAggregator) Add(in telegraf.Metric) bool {
+	if in = r.filter(in); in == nil {
+		return false
+	}
+
+	r.metrics <- in
+	return r.Config.DropOriginal
+}
+
+// TestAdd is like Add, but adds the metric to the aggregator immediately,
+// regardless of its period. It is used in --test mode, when the aggregator
+// is not running.
+func (r *RunningAggregator) TestAdd(in telegraf.Metric) bool {
+	if in = r.filter(in); in == nil {
+		return false
+	}
+
+	r.add(in)
+	return r.Config.DropOriginal
+}
+
+// TestPush pushes and resets the aggregates added with TestAdd.
+func (r *RunningAggregator) TestPush(acc telegraf.Accumulator) {
+	r.push(acc)
+	r.reset()
+}
+
+// filter returns the metric the aggregator should apply, or nil if the
+// metric does not pass the filter.
+func (r *RunningAggregator) filter(in telegraf.Metric) telegraf.Metric {
 	if r.Config.Filter.IsActive() {
 		// check if the aggregator should apply this metric
 		name := in.Name()
 		fields := in.Fields()
 		tags := in.Tags()
 		t := in.Time()
-		if ok := r.Config.Filter.Apply(name, fields, tags); !ok {
+		if ok := r.Config.Filter.Apply(name, fields, tags, t); !ok {
 			// aggregator should not apply this metric
-			return false
+			return nil
 		}
 
 		in, _ = metric.New(name, tags, fields, t)
 	}
-
-	r.metrics <- in
-	return r.Config.DropOriginal
+	return in
 }
 func (r *RunningAggregator) add(in telegraf.Metric) {
 	r.a.Add(in)
@@ -111,34 +191,42 @@ func (r *RunningAggregator) reset() {
 }
 
 // Run runs the running aggregator, listens for incoming metrics, and waits
-// for period ticks to tell it when to push and reset the aggregator.
+// until the end of each period, plus the delay and grace period, to push and
+// reset the aggregator.
 func (r *RunningAggregator) Run(
 	acc telegraf.Accumulator,
 	shutdown chan struct{},
 ) {
-	// The start of the period is truncated to the nearest second.
-	//
-	// Every metric then gets it's timestamp checked and is dropped if it
-	// is not within:
+	// The first period starts at the current time truncated to the nearest
+	// second, or to a multiple of the period if the periods are aligned.
 	//
-	//   start < t < end + truncation + delay
+	// Every metric then gets its timestamp checked. Metrics within the
+	// current period are added to the aggregator, metrics from a later
+	// period are held until the current period has been pushed, and metrics
+	// from a period that has already been pushed are dropped.
 	//
-	// So if we start at now = 00:00.2 with a 10s period and 0.3s delay:
-	//   now = 00:00.2
-	//   start = 00:00
-	//   truncation = 00:00.2
-	//   end = 00:10
-	// 1st interval: 00:00 - 00:10.5
-	// 2nd interval: 00:10 - 00:20.5
+	// Unaligned periods are relative to the start of the aggregator, the
+	// first period is extended to the truncated start so that metrics
+	// gathered at the same time are included. So if we start at
+	// now = 00:00.2 with a 10s period, 0.3s delay and 1s grace:
+	// 1st period: 00:00 - 00:10.2, pushed at 00:11.5
+	// 2nd period: 00:10.2 - 00:20.2, pushed at 00:21.5
 	// etc.
 	//
+	// With aligned periods the 1st period would be 00:00 - 00:10, pushed at
+	// 00:11.3, regardless of when the aggregator was started.
+	//
 	now := time.Now()
-	r.periodStart = now.Truncate(time.Second)
-	truncation := now.Sub(r.periodStart)
-	r.periodEnd = r.periodStart.Add(r.Config.Period)
-	time.Sleep(r.Config.Delay)
-	periodT := time.NewTicker(r.Config.Period)
-	defer periodT.Stop()
+	if r.Config.Align {
+		r.periodStart = now.Truncate(r.Config.Period)
+		r.periodEnd = r.periodStart.Add(r.Config.Period)
+	} else {
+		r.periodStart = now.Truncate(time.Second)
+		r.periodEnd = now.Add(r.Config.Period)
+	}
+
+	pushT := time.NewTimer(r.pushAt().Sub(now))
+	defer pushT.Stop()
 
 	for {
 		select {
@@ -149,18 +237,58 @@ func (r *RunningAggregator) Run(
 			}
 			return
 		case m := <-r.metrics:
-			if m.Time().Before(r.periodStart) ||
-				m.Time().After(r.periodEnd.Add(truncation).Add(r.Config.Delay)) {
-				// the metric is outside the current aggregation period, so
-				// skip it.
-				continue
-			}
-			r.add(m)
-		case <-periodT.C:
-			r.periodStart = r.periodEnd
-			r.periodEnd = r.periodStart.Add(r.Config.Period)
-			r.push(acc)
-			r.reset()
+			r.addMetric(m)
+		case <-pushT.C:
+			r.pushPeriod(acc)
+			pushT.Reset(r.pushAt().Sub(time.Now()))
 		}
 	}
 }
+
+// pushAt returns the time at which the current period is pushed.
+func (r *RunningAggregator) pushAt() time.Time {
+	return r.periodEnd.Add(r.Config.Delay).Add(r.Config.Grace)
+}
+
+// addMetric adds the metric to the current period, holds it if it belongs to
+// a later period, or drops it if its period has already been pushed.
+func (r *RunningAggregator) addMetric(m telegraf.Metric) {
+	t := m.Time()
+	switch {
+	case t.Before(r.periodStart):
+		r.logger.Debugf("Dropping metric %s, its period has already been pushed",
+			m.Name())
+	case t.Before(r.periodEnd):
+		r.add(m)
+	case t.Before(r.pushAt().Add(r.Config.Period)):
+		r.pending = append(r.pending, m)
+	default:
+		r.logger.Debugf("Dropping metric %s, its timestamp is too far in the future",
+			m.Name())
+	}
+}
+
+// pushPeriod pushes the aggregates of the current period, and moves on to
+// the next period.
+func (r *RunningAggregator) pushPeriod(acc telegraf.Accumulator) {
+	switch r.Config.Timestamp {
+	case "start":
+		r.pushTime = r.periodStart
+	case "end":
+		r.pushTime = r.periodEnd
+	case "midpoint":
+		r.pushTime = r.periodStart.Add(r.periodEnd.Sub(r.periodStart) / 2)
+	}
+	r.push(acc)
+	r.reset()
+	r.pushTime = time.Time{}
+
+	r.periodStart = r.periodEnd
+	r.periodEnd = r.periodStart.Add(r.Config.Period)
+
+	pending := r.pending
+	r.pending = nil
+	for _, m := range pending {
+		r.addMetric(m)
+	}
+}
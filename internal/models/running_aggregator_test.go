@@ -230,6 +230,133 @@ func TestMakeMetricA(t *testing.T) {
 	)
 }
 
+func TestAlignedPeriodTimestamps(t *testing.T) {
+	start := time.Unix(1500000000, 0).Truncate(5 * time.Minute)
+
+	tests := []struct {
+		timestamp string
+		expected  time.Time
+	}{
+		{"start", start},
+		{"end", start.Add(5 * time.Minute)},
+		{"midpoint", start.Add(150 * time.Second)},
+	}
+
+	for _, tt := range tests {
+		ra := NewRunningAggregator(&TestAggregator{}, &AggregatorConfig{
+			Name:      "TestRunningAggregator",
+			Period:    5 * time.Minute,
+			Align:     true,
+			Timestamp: tt.timestamp,
+		})
+		ra.periodStart = start
+		ra.periodEnd = start.Add(5 * time.Minute)
+
+		acc := &makeMetricAccumulator{ra: ra}
+		ra.addMetric(testutil.TestMetric(int64(1), "a"))
+		ra.pushPeriod(acc)
+
+		assert.True(t, acc.HasTimestamp("TestMetric", tt.expected), tt.timestamp)
+	}
+}
+
+func TestLateMetricsLandInTheirPeriod(t *testing.T) {
+	a := &TestAggregator{}
+	ra := NewRunningAggregator(a, &AggregatorConfig{
+		Name:      "TestRunningAggregator",
+		Period:    time.Minute,
+		Grace:     10 * time.Second,
+		Align:     true,
+		Timestamp: "start",
+	})
+	start := time.Unix(1500000000, 0).Truncate(time.Minute)
+	ra.periodStart = start
+	ra.periodEnd = start.Add(time.Minute)
+
+	newMetric := func(value int64, t time.Time) telegraf.Metric {
+		return ra.MakeMetric("RITest",
+			map[string]interface{}{"value": value},
+			map[string]string{},
+			telegraf.Untyped,
+			t,
+		)
+	}
+
+	// arrives during the grace period of the first period
+	ra.addMetric(newMetric(1, start.Add(59*time.Second)))
+	// already belongs to the second period
+	ra.addMetric(newMetric(10, start.Add(61*time.Second)))
+	// late metric for the first period, received during the grace period
+	ra.addMetric(newMetric(100, start.Add(30*time.Second)))
+	// too far in the future
+	ra.addMetric(newMetric(1000, start.Add(time.Hour)))
+
+	acc := &makeMetricAccumulator{ra: ra}
+	ra.pushPeriod(acc)
+	ra.pushPeriod(acc)
+
+	// too late for the first period, which was already pushed
+	ra.addMetric(newMetric(10000, start.Add(30*time.Second)))
+	ra.pushPeriod(acc)
+
+	assert.Equal(t, start.Add(3*time.Minute), ra.periodStart)
+	assert.Equal(t, uint64(3), acc.NMetrics())
+	assert.Equal(t, map[string]interface{}{"sum": int64(101)}, acc.Metrics[0].Fields)
+	assert.Equal(t, start, acc.Metrics[0].Time)
+	assert.Equal(t, map[string]interface{}{"sum": int64(10)}, acc.Metrics[1].Fields)
+	assert.Equal(t, start.Add(time.Minute), acc.Metrics[1].Time)
+	assert.Equal(t, map[string]interface{}{"sum": int64(0)}, acc.Metrics[2].Fields)
+}
+
+func TestAlignedRun(t *testing.T) {
+	a := &TestAggregator{}
+	ra := NewRunningAggregator(a, &AggregatorConfig{
+		Name:      "TestRunningAggregator",
+		Period:    100 * time.Millisecond,
+		Align:     true,
+		Timestamp: "end",
+	})
+	acc := &makeMetricAccumulator{ra: ra}
+	shutdown := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ra.Run(acc, shutdown)
+	}()
+
+	acc.Wait(2)
+	close(shutdown)
+	wg.Wait()
+
+	for _, m := range acc.Metrics {
+		assert.Equal(t, int64(0), m.Time.UnixNano()%int64(100*time.Millisecond),
+			"%s is not aligned", m.Time)
+	}
+}
+
+// makeMetricAccumulator creates the metrics with the MakeMetric function of
+// the aggregator, like the accumulator of the agent.
+type makeMetricAccumulator struct {
+	testutil.Accumulator
+	ra *RunningAggregator
+}
+
+func (a *makeMetricAccumulator) AddFields(
+	measurement string,
+	fields map[string]interface{},
+	tags map[string]string,
+	t ...time.Time,
+) {
+	tm := time.Now()
+	if len(t) > 0 {
+		tm = t[0]
+	}
+	m := a.ra.MakeMetric(measurement, fields, tags, telegraf.Untyped, tm)
+	a.Accumulator.AddFields(m.Name(), m.Fields(), m.Tags(), m.Time())
+}
+
 type TestAggregator struct {
 	sum int64
 }
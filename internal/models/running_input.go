@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -18,38 +19,134 @@ type RunningInput struct {
 	defaultTags map[string]string
 
 	MetricsGathered selfstat.Stat
+	GatherTime      selfstat.Stat
+	GatherTimeouts  selfstat.Stat
+
+	pluginErrors
+	logger *Logger
 }
 
 func NewRunningInput(
 	input telegraf.Input,
 	config *InputConfig,
 ) *RunningInput {
-	return &RunningInput{
+	logger := NewLogger(logName("inputs", config.Name, config.Alias))
+	SetLoggerOnPlugin(input, logger)
+
+	ri := &RunningInput{
 		Input:  input,
 		Config: config,
 		MetricsGathered: selfstat.Register(
 			"gather",
 			"metrics_gathered",
-			map[string]string{"input": config.Name},
+			pluginTags("input", config.Name, config.Alias),
+		),
+		GatherTime: selfstat.RegisterTiming(
+			"gather",
+			"gather_time_ns",
+			pluginTags("input", config.Name, config.Alias),
+		),
+		GatherTimeouts: selfstat.Register(
+			"gather",
+			"gather_timeouts",
+			pluginTags("input", config.Name, config.Alias),
 		),
+		pluginErrors: newPluginErrors(
+			"gather",
+			pluginTags("input", config.Name, config.Alias),
+		),
+		logger: logger,
 	}
+	logger.OnError = ri.SetError
+	return ri
 }
 
 // InputConfig containing a name, interval, and filter
 type InputConfig struct {
 	Name              string
+	Alias             string
 	NameOverride      string
 	MeasurementPrefix string
 	MeasurementSuffix string
 	Tags              map[string]string
 	Filter            Filter
 	Interval          time.Duration
+	// Timeout is how long a single gather may take, defaults to the interval.
+	Timeout time.Duration
+	// Schedule, when set, is used instead of the interval.
+	Schedule *Schedule
+	// LogLevel, when set, overrides the log level for the input.
+	LogLevel string
 }
 
 func (r *RunningInput) Name() string {
 	return "inputs." + r.Config.Name
 }
 
+// LogName returns the name of the input including its alias, ie,
+// "inputs.cpu::alias".
+func (r *RunningInput) LogName() string {
+	return logName("inputs", r.Config.Name, r.Config.Alias)
+}
+
+// Log returns the logger of the input.
+func (r *RunningInput) Log() telegraf.Logger {
+	return r.logger
+}
+
+// Gather gathers from the input, giving up after the timeout. The context
+// is done when Telegraf is shutting down. Inputs that do not implement
+// telegraf.ContextInput can not be cancelled: when they time out an error is
+// reported, but Gather keeps waiting for them to return so that they are never
+// called concurrently.
+func (r *RunningInput) Gather(
+	ctx context.Context,
+	acc telegraf.Accumulator,
+	timeout time.Duration,
+) error {
+	gatherCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if input, ok := r.Input.(telegraf.ContextInput); ok {
+		err := input.GatherContext(gatherCtx, acc)
+		if gatherCtx.Err() == context.DeadlineExceeded {
+			r.GatherTimeouts.Incr(1)
+			if err != nil {
+				return fmt.Errorf("took longer to collect than timeout (%s): %s",
+					timeout, err)
+			}
+			return fmt.Errorf("took longer to collect than timeout (%s)", timeout)
+		}
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Input.Gather(acc)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-gatherCtx.Done():
+		if ctx.Err() != nil {
+			// shutting down, do not wait for the input
+			return nil
+		}
+	}
+
+	r.GatherTimeouts.Incr(1)
+	acc.AddError(fmt.Errorf("took longer to collect than timeout (%s), "+
+		"waiting for it to complete", timeout))
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
 // MakeMetric either returns a metric, or returns nil if the metric doesn't
 // need to be created (because of filtering, an error, etc.)
 func (r *RunningInput) MakeMetric(
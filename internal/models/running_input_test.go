@@ -1,12 +1,14 @@
 package models
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"testing"
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +30,25 @@ func TestMakeMetricNoFields(t *testing.T) {
 	assert.Nil(t, m)
 }
 
+func TestRunningInputAlias(t *testing.T) {
+	first := NewRunningInput(&testInput{}, &InputConfig{
+		Name:  "TestRunningInputAlias",
+		Alias: "first",
+	})
+	second := NewRunningInput(&testInput{}, &InputConfig{
+		Name:  "TestRunningInputAlias",
+		Alias: "second",
+	})
+	assert.Equal(t, "inputs.TestRunningInputAlias::first", first.LogName())
+	assert.Equal(t, "inputs.TestRunningInputAlias::second", second.LogName())
+
+	// each instance has its own stats
+	first.MetricsGathered.Incr(1)
+	assert.Equal(t, int64(1), first.MetricsGathered.Get())
+	assert.Equal(t, int64(0), second.MetricsGathered.Get())
+	assert.Equal(t, "first", first.MetricsGathered.Tags()["alias"])
+}
+
 // nil fields should get dropped
 func TestMakeMetricNilFields(t *testing.T) {
 	now := time.Now()
@@ -456,8 +477,87 @@ func TestMakeMetric_TrailingSlash(t *testing.T) {
 	}
 }
 
+func TestGatherTimeout(t *testing.T) {
+	release := make(chan struct{})
+	ri := NewRunningInput(&slowInput{release: release}, &InputConfig{
+		Name: "TestGatherTimeout",
+	})
+
+	acc := testutil.Accumulator{}
+	done := make(chan error)
+	go func() {
+		done <- ri.Gather(context.Background(), &acc, 10*time.Millisecond)
+	}()
+
+	// the input is not cancelled, Gather waits for it to return
+	acc.WaitError(1)
+	close(release)
+	require.NoError(t, <-done)
+
+	assert.Contains(t, acc.Errors[0].Error(), "took longer to collect")
+	assert.Equal(t, int64(1), ri.GatherTimeouts.Get())
+}
+
+func TestGatherContextTimeout(t *testing.T) {
+	ri := NewRunningInput(&slowContextInput{err: context.DeadlineExceeded}, &InputConfig{
+		Name: "TestGatherContextTimeout",
+	})
+
+	acc := testutil.Accumulator{}
+	err := ri.Gather(context.Background(), &acc, 10*time.Millisecond)
+	require.Error(t, err)
+	assert.Equal(t, "took longer to collect than timeout (10ms): "+
+		"context deadline exceeded", err.Error())
+	assert.Equal(t, int64(1), ri.GatherTimeouts.Get())
+
+	// inputs that return no error once cancelled
+	ri = NewRunningInput(&slowContextInput{}, &InputConfig{
+		Name: "TestGatherContextTimeout",
+	})
+	err = ri.Gather(context.Background(), &acc, 10*time.Millisecond)
+	require.Error(t, err)
+	assert.Equal(t, "took longer to collect than timeout (10ms)", err.Error())
+}
+
+func TestGatherShutdown(t *testing.T) {
+	ri := NewRunningInput(&slowInput{release: make(chan struct{})}, &InputConfig{
+		Name: "TestGatherShutdown",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	acc := testutil.Accumulator{}
+	require.NoError(t, ri.Gather(ctx, &acc, time.Minute))
+	assert.Equal(t, int64(0), ri.GatherTimeouts.Get())
+}
+
 type testInput struct{}
 
 func (t *testInput) Description() string                   { return "" }
 func (t *testInput) SampleConfig() string                  { return "" }
 func (t *testInput) Gather(acc telegraf.Accumulator) error { return nil }
+
+type slowInput struct {
+	release chan struct{}
+}
+
+func (t *slowInput) Description() string  { return "" }
+func (t *slowInput) SampleConfig() string { return "" }
+func (t *slowInput) Gather(acc telegraf.Accumulator) error {
+	<-t.release
+	return nil
+}
+
+// slowContextInput returns err once its context is done.
+type slowContextInput struct {
+	err error
+}
+
+func (t *slowContextInput) Description() string                   { return "" }
+func (t *slowContextInput) SampleConfig() string                  { return "" }
+func (t *slowContextInput) Gather(acc telegraf.Accumulator) error { return nil }
+func (t *slowContextInput) GatherContext(ctx context.Context, acc telegraf.Accumulator) error {
+	<-ctx.Done()
+	return t.err
+}
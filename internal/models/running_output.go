@@ -1,13 +1,13 @@
 package models
 
 import (
-	"log"
 	"sync"
 	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/internal/buffer"
 	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/plugins/serializers"
 	"github.com/influxdata/telegraf/selfstat"
 )
 
@@ -27,6 +27,11 @@ type RunningOutput struct {
 	MetricBufferLimit int
 	MetricBatchSize   int
 
+	// Serializer is the serializer given to outputs that support a
+	// data_format, it is used to show what the output would write in --test
+	// mode.
+	Serializer serializers.Serializer
+
 	MetricsFiltered selfstat.Stat
 	MetricsWritten  selfstat.Stat
 	BufferSize      selfstat.Stat
@@ -36,6 +41,14 @@ type RunningOutput struct {
 	metrics     *buffer.Buffer
 	failMetrics *buffer.Buffer
 
+	// failingSince is when the current run of failed writes started, it is
+	// zero while writes succeed.
+	failingSince time.Time
+	failingMu    sync.Mutex
+
+	pluginErrors
+	logger *Logger
+
 	// Guards against concurrent calls to the Output as described in #3009
 	sync.Mutex
 }
@@ -53,6 +66,9 @@ func NewRunningOutput(
 	if batchSize == 0 {
 		batchSize = DEFAULT_METRIC_BATCH_SIZE
 	}
+	logger := NewLogger(logName("outputs", name, conf.Alias))
+	SetLoggerOnPlugin(output, logger)
+
 	ro := &RunningOutput{
 		Name:              name,
 		metrics:           buffer.NewBuffer(batchSize),
@@ -64,29 +80,35 @@ func NewRunningOutput(
 		MetricsWritten: selfstat.Register(
 			"write",
 			"metrics_written",
-			map[string]string{"output": name},
+			pluginTags("output", name, conf.Alias),
 		),
 		MetricsFiltered: selfstat.Register(
 			"write",
 			"metrics_filtered",
-			map[string]string{"output": name},
+			pluginTags("output", name, conf.Alias),
 		),
 		BufferSize: selfstat.Register(
 			"write",
 			"buffer_size",
-			map[string]string{"output": name},
+			pluginTags("output", name, conf.Alias),
 		),
 		BufferLimit: selfstat.Register(
 			"write",
 			"buffer_limit",
-			map[string]string{"output": name},
+			pluginTags("output", name, conf.Alias),
 		),
 		WriteTime: selfstat.RegisterTiming(
 			"write",
 			"write_time_ns",
-			map[string]string{"output": name},
+			pluginTags("output", name, conf.Alias),
+		),
+		pluginErrors: newPluginErrors(
+			"write",
+			pluginTags("output", name, conf.Alias),
 		),
+		logger: logger,
 	}
+	logger.OnError = ro.SetError
 	ro.BufferLimit.Incr(int64(ro.MetricBufferLimit))
 	return ro
 }
@@ -97,6 +119,24 @@ func (ro *RunningOutput) AddMetric(m telegraf.Metric) {
 	if m == nil {
 		return
 	}
+	if m = ro.FilterMetric(m); m == nil {
+		ro.MetricsFiltered.Incr(1)
+		return
+	}
+
+	ro.metrics.Add(m)
+	if ro.metrics.Len() == ro.MetricBatchSize {
+		batch := ro.metrics.Batch(ro.MetricBatchSize)
+		err := ro.write(batch)
+		if err != nil {
+			ro.failMetrics.Add(batch...)
+		}
+	}
+}
+
+// FilterMetric applies the filters of the output to the metric, and returns
+// the metric that would be written, or nil if it is filtered out.
+func (ro *RunningOutput) FilterMetric(m telegraf.Metric) telegraf.Metric {
 	// Filter any tagexclude/taginclude parameters before adding metric
 	if ro.Config.Filter.IsActive() {
 		// In order to filter out tags, we need to create a new metric, since
@@ -105,30 +145,21 @@ func (ro *RunningOutput) AddMetric(m telegraf.Metric) {
 		tags := m.Tags()
 		fields := m.Fields()
 		t := m.Time()
-		if ok := ro.Config.Filter.Apply(name, fields, tags); !ok {
-			ro.MetricsFiltered.Incr(1)
-			return
+		if ok := ro.Config.Filter.Apply(name, fields, tags, t); !ok {
+			return nil
 		}
 		// error is not possible if creating from another metric, so ignore.
 		m, _ = metric.New(name, tags, fields, t)
 	}
-
-	ro.metrics.Add(m)
-	if ro.metrics.Len() == ro.MetricBatchSize {
-		batch := ro.metrics.Batch(ro.MetricBatchSize)
-		err := ro.write(batch)
-		if err != nil {
-			ro.failMetrics.Add(batch...)
-		}
-	}
+	return m
 }
 
 // Write writes all cached points to this output.
 func (ro *RunningOutput) Write() error {
 	nFails, nMetrics := ro.failMetrics.Len(), ro.metrics.Len()
 	ro.BufferSize.Set(int64(nFails + nMetrics))
-	log.Printf("D! Output [%s] buffer fullness: %d / %d metrics. ",
-		ro.Name, nFails+nMetrics, ro.MetricBufferLimit)
+	ro.logger.Debugf("Buffer fullness: %d / %d metrics",
+		nFails+nMetrics, ro.MetricBufferLimit)
 	var err error
 	if !ro.failMetrics.IsEmpty() {
 		// how many batches of failed writes we need to write.
@@ -178,17 +209,69 @@ func (ro *RunningOutput) write(metrics []telegraf.Metric) error {
 	start := time.Now()
 	err := ro.Output.Write(metrics)
 	elapsed := time.Since(start)
+	ro.setFailing(err)
 	if err == nil {
-		log.Printf("D! Output [%s] wrote batch of %d metrics in %s\n",
-			ro.Name, nMetrics, elapsed)
+		ro.logger.Debugf("Wrote batch of %d metrics in %s",
+			nMetrics, elapsed)
 		ro.MetricsWritten.Incr(int64(nMetrics))
 		ro.WriteTime.Incr(elapsed.Nanoseconds())
 	}
 	return err
 }
 
+func (ro *RunningOutput) setFailing(err error) {
+	ro.SetError(err)
+
+	ro.failingMu.Lock()
+	defer ro.failingMu.Unlock()
+	if err == nil {
+		ro.failingSince = time.Time{}
+	} else if ro.failingSince.IsZero() {
+		ro.failingSince = time.Now()
+	}
+}
+
+// LogName returns the name of the output including its alias, ie,
+// "outputs.influxdb::alias".
+func (ro *RunningOutput) LogName() string {
+	return logName("outputs", ro.Name, ro.Config.Alias)
+}
+
+// Log returns the logger of the output.
+func (ro *RunningOutput) Log() telegraf.Logger {
+	return ro.logger
+}
+
+// FailingSince returns when the writes to the output started failing, or the
+// zero time if the last write succeeded.
+func (ro *RunningOutput) FailingSince() time.Time {
+	ro.failingMu.Lock()
+	defer ro.failingMu.Unlock()
+	return ro.failingSince
+}
+
+// BufferFill returns the number of buffered metrics as a ratio of the buffer
+// limit.
+func (ro *RunningOutput) BufferFill() float64 {
+	n := ro.metrics.Len() + ro.failMetrics.Len()
+	return float64(n) / float64(ro.MetricBufferLimit)
+}
+
 // OutputConfig containing name and filter
 type OutputConfig struct {
 	Name   string
+	Alias  string
 	Filter Filter
+
+	// FlushInterval and FlushJitter override the agent settings when set.
+	FlushInterval time.Duration
+	FlushJitter   time.Duration
+
+	// MetricBatchSize and MetricBufferLimit override the agent settings when
+	// set.
+	MetricBatchSize   int
+	MetricBufferLimit int
+
+	// LogLevel, when set, overrides the log level for the output.
+	LogLevel string
 }
@@ -330,6 +330,28 @@ func TestRunningOutputWriteFail(t *testing.T) {
 	assert.Len(t, m.Metrics(), 10)
 }
 
+func TestRunningOutputWriteFailLastError(t *testing.T) {
+	conf := &OutputConfig{
+		Filter: Filter{},
+	}
+
+	m := &mockOutput{}
+	m.failWrite = true
+	ro := NewRunningOutput("lasterror", m, conf, 4, 12)
+
+	msg, _ := ro.LastError()
+	assert.Empty(t, msg)
+
+	ro.AddMetric(first5[0])
+	require.Error(t, ro.Write())
+	require.Error(t, ro.Write())
+
+	assert.Equal(t, int64(2), ro.Errors())
+	msg, ts := ro.LastError()
+	assert.Equal(t, "Failed Write!", msg)
+	assert.False(t, ts.IsZero())
+}
+
 // Verify that the order of points is preserved during a write failure.
 func TestRunningOutputWriteFailOrder(t *testing.T) {
 	conf := &OutputConfig{
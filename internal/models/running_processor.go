@@ -12,6 +12,30 @@ type RunningProcessor struct {
 	sync.Mutex
 	Processor telegraf.Processor
 	Config    *ProcessorConfig
+
+	pluginErrors
+	logger *Logger
+}
+
+func NewRunningProcessor(
+	processor telegraf.Processor,
+	conf *ProcessorConfig,
+) *RunningProcessor {
+	logger := NewLogger(logName("processors", conf.Name, conf.Alias))
+	SetLoggerOnPlugin(processor, logger)
+
+	rp := &RunningProcessor{
+		Name:      conf.Name,
+		Processor: processor,
+		Config:    conf,
+		pluginErrors: newPluginErrors(
+			"process",
+			pluginTags("processor", conf.Name, conf.Alias),
+		),
+		logger: logger,
+	}
+	logger.OnError = rp.SetError
+	return rp
 }
 
 type RunningProcessors []*RunningProcessor
@@ -23,10 +47,33 @@ func (rp RunningProcessors) Less(i, j int) bool { return rp[i].Config.Order < rp
 // FilterConfig containing a name and filter
 type ProcessorConfig struct {
 	Name   string
+	Alias  string
 	Order  int64
 	Filter Filter
+	// AfterAggregators runs the processor on the metrics leaving the
+	// aggregators, including the aggregates, instead of on the gathered
+	// metrics.
+	AfterAggregators bool
+	// LogLevel, when set, overrides the log level for the processor.
+	LogLevel string
+}
+
+// LogName returns the name of the processor including its alias, ie,
+// "processors.rename::alias".
+func (rp *RunningProcessor) LogName() string {
+	return logName("processors", rp.Config.Name, rp.Config.Alias)
+}
+
+// Log returns the logger of the processor.
+func (rp *RunningProcessor) Log() telegraf.Logger {
+	return rp.logger
 }
 
+// Apply runs the processor on the metrics that pass its filter. The metrics
+// emitted by streaming processors and the aggregates are processed in their
+// own goroutines, so Apply may be called concurrently; the calls of the
+// processor are serialized, so that processors do not need to be safe for
+// concurrent use.
 func (rp *RunningProcessor) Apply(in ...telegraf.Metric) []telegraf.Metric {
 	rp.Lock()
 	defer rp.Unlock()
@@ -36,7 +83,7 @@ func (rp *RunningProcessor) Apply(in ...telegraf.Metric) []telegraf.Metric {
 	for _, metric := range in {
 		if rp.Config.Filter.IsActive() {
 			// check if the filter should be applied to this metric
-			if ok := rp.Config.Filter.Apply(metric.Name(), metric.Fields(), metric.Tags()); !ok {
+			if ok := rp.Config.Filter.Apply(metric.Name(), metric.Fields(), metric.Tags(), metric.Time()); !ok {
 				// this means filter should not be applied
 				ret = append(ret, metric)
 				continue
@@ -1,7 +1,10 @@
 package models
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/testutil"
@@ -115,3 +118,61 @@ func TestRunningProcessor_DroppedMetric(t *testing.T) {
 	}
 	assert.Equal(t, expectedNames, actualNames)
 }
+
+// concurrencyProcessor records whether Apply is called concurrently.
+type concurrencyProcessor struct {
+	running    int32
+	concurrent int32
+}
+
+func (p *concurrencyProcessor) SampleConfig() string { return "" }
+func (p *concurrencyProcessor) Description() string  { return "" }
+func (p *concurrencyProcessor) Apply(in ...telegraf.Metric) []telegraf.Metric {
+	if atomic.AddInt32(&p.running, 1) > 1 {
+		atomic.StoreInt32(&p.concurrent, 1)
+	}
+	defer atomic.AddInt32(&p.running, -1)
+	time.Sleep(time.Millisecond)
+	return in
+}
+
+func TestRunningProcessor_SerializesApply(t *testing.T) {
+	processor := &concurrencyProcessor{}
+	rp := NewRunningProcessor(processor, &ProcessorConfig{Name: "concurrency"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rp.Apply(testutil.TestMetric(1))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&processor.concurrent))
+}
+
+// errorProcessor logs an error for every metric it is applied to.
+type errorProcessor struct {
+	Log telegraf.Logger `toml:"-"`
+}
+
+func (p *errorProcessor) SampleConfig() string { return "" }
+func (p *errorProcessor) Description() string  { return "" }
+func (p *errorProcessor) Apply(in ...telegraf.Metric) []telegraf.Metric {
+	for _, m := range in {
+		p.Log.Errorf("Failed to process %s", m.Name())
+	}
+	return in
+}
+
+func TestRunningProcessor_CountsLoggedErrors(t *testing.T) {
+	rp := NewRunningProcessor(&errorProcessor{}, &ProcessorConfig{Name: "error"})
+
+	rp.Apply(testutil.TestMetric(1, "foo"), testutil.TestMetric(1, "bar"))
+
+	assert.Equal(t, int64(2), rp.Errors())
+	last, _ := rp.LastError()
+	assert.Equal(t, "Failed to process bar", last)
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"log"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/selfstat"
+)
+
+// RouteConfig containing the outputs of a route and the filter used to
+// select the metrics that follow it.
+type RouteConfig struct {
+	Name    string
+	Outputs []string
+
+	// Default routes receive the metrics that matched no other route.
+	Default bool
+	// Exclusive routes stop the evaluation of all following routes once a
+	// metric has matched them.
+	Exclusive bool
+
+	Filter Filter
+}
+
+type RunningRoute struct {
+	Config *RouteConfig
+
+	MetricsRouted selfstat.Stat
+}
+
+func NewRunningRoute(conf *RouteConfig) *RunningRoute {
+	return &RunningRoute{
+		Config: conf,
+		MetricsRouted: selfstat.Register(
+			"route",
+			"metrics_routed",
+			map[string]string{"route": conf.Name},
+		),
+	}
+}
+
+// Match returns true if the metric should be sent along this route.
+func (r *RunningRoute) Match(m telegraf.Metric) bool {
+	return r.Config.Filter.Match(m.Name(), m.Fields(), m.Tags(), m.Time())
+}
+
+// Router selects the outputs each metric is written to based on the
+// configured routes. Outputs that are not named by any route receive every
+// metric, so that adding a route never silently disables an output.
+type Router struct {
+	outputs []*RunningOutput
+
+	routes   []*RunningRoute
+	defaults []*RunningRoute
+
+	// targets maps each route to the outputs it sends to.
+	targets map[*RunningRoute][]*RunningOutput
+	// unrouted is the set of outputs that no route refers to.
+	unrouted map[*RunningOutput]bool
+
+	MetricsUnrouted selfstat.Stat
+}
+
+// NewRouter creates a Router for the given routes and outputs. Outputs named
+// by a route but not present in outputs, for example because they were
+// excluded with --output-filter, are skipped with a warning.
+func NewRouter(routes []*RunningRoute, outputs []*RunningOutput) *Router {
+	r := &Router{
+		outputs:  outputs,
+		targets:  make(map[*RunningRoute][]*RunningOutput),
+		unrouted: make(map[*RunningOutput]bool),
+		MetricsUnrouted: selfstat.Register(
+			"agent",
+			"metrics_unrouted",
+			map[string]string{},
+		),
+	}
+
+	for _, o := range outputs {
+		r.unrouted[o] = true
+	}
+
+	for _, route := range routes {
+		for _, name := range route.Config.Outputs {
+			found := false
+			for _, o := range outputs {
+				if o.Name == name || o.Config.Alias == name {
+					r.targets[route] = append(r.targets[route], o)
+					delete(r.unrouted, o)
+					found = true
+				}
+			}
+			if !found {
+				log.Printf("W! Route [%s] refers to output [%s] which is not loaded",
+					route.Config.Name, name)
+			}
+		}
+
+		if route.Config.Default {
+			r.defaults = append(r.defaults, route)
+		} else {
+			r.routes = append(r.routes, route)
+		}
+	}
+
+	return r
+}
+
+// Outputs returns the outputs the metric should be written to, in the order
+// the outputs were configured.
+func (r *Router) Outputs(m telegraf.Metric) []*RunningOutput {
+	if len(r.routes) == 0 && len(r.defaults) == 0 {
+		return r.outputs
+	}
+
+	selected := make(map[*RunningOutput]bool)
+	matched := false
+	for _, route := range r.routes {
+		if !route.Match(m) {
+			continue
+		}
+		matched = true
+		route.MetricsRouted.Incr(1)
+		for _, o := range r.targets[route] {
+			selected[o] = true
+		}
+		if route.Config.Exclusive {
+			break
+		}
+	}
+
+	if !matched {
+		for _, route := range r.defaults {
+			route.MetricsRouted.Incr(1)
+			for _, o := range r.targets[route] {
+				selected[o] = true
+			}
+		}
+		if len(r.defaults) == 0 {
+			r.MetricsUnrouted.Incr(1)
+		}
+	}
+
+	outputs := make([]*RunningOutput, 0, len(r.outputs))
+	for _, o := range r.outputs {
+		if selected[o] || r.unrouted[o] {
+			outputs = append(outputs, o)
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouteOutput(name string) *RunningOutput {
+	return NewRunningOutput(name, &mockOutput{}, &OutputConfig{Filter: Filter{}}, 1000, 10000)
+}
+
+func newTestRoute(t *testing.T, conf *RouteConfig) *RunningRoute {
+	require.NoError(t, conf.Filter.Compile())
+	return NewRunningRoute(conf)
+}
+
+func outputNames(outputs []*RunningOutput) []string {
+	names := []string{}
+	for _, o := range outputs {
+		names = append(names, o.Name)
+	}
+	return names
+}
+
+func TestRouterNoRoutes(t *testing.T) {
+	outputs := []*RunningOutput{newTestRouteOutput("a"), newTestRouteOutput("b")}
+	r := NewRouter(nil, outputs)
+
+	assert.Equal(t, []string{"a", "b"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "cpu"))))
+}
+
+func TestRouterMatch(t *testing.T) {
+	outputs := []*RunningOutput{
+		newTestRouteOutput("a"),
+		newTestRouteOutput("b"),
+		newTestRouteOutput("c"),
+	}
+	routes := []*RunningRoute{
+		newTestRoute(t, &RouteConfig{
+			Name:    "cpu",
+			Outputs: []string{"a"},
+			Filter:  Filter{NamePass: []string{"cpu*"}},
+		}),
+		newTestRoute(t, &RouteConfig{
+			Name:    "mem",
+			Outputs: []string{"b"},
+			Filter:  Filter{NamePass: []string{"mem"}},
+		}),
+	}
+	r := NewRouter(routes, outputs)
+
+	// output "c" is not routed and receives everything
+	assert.Equal(t, []string{"a", "c"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "cpu_idle"))))
+	assert.Equal(t, []string{"b", "c"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "mem"))))
+	assert.Equal(t, []string{"c"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "disk"))))
+
+	assert.Equal(t, int64(1), routes[0].MetricsRouted.Get())
+	assert.Equal(t, int64(1), routes[1].MetricsRouted.Get())
+}
+
+func TestRouterTagMatch(t *testing.T) {
+	outputs := []*RunningOutput{newTestRouteOutput("a"), newTestRouteOutput("b")}
+	routes := []*RunningRoute{
+		newTestRoute(t, &RouteConfig{
+			Name:    "tagged",
+			Outputs: []string{"a"},
+			Filter: Filter{
+				TagPass: []TagFilter{{Name: "tag1", Filter: []string{"value1"}}},
+			},
+		}),
+		newTestRoute(t, &RouteConfig{
+			Name:    "other",
+			Outputs: []string{"b"},
+			Filter: Filter{
+				TagDrop: []TagFilter{{Name: "tag1", Filter: []string{"value1"}}},
+			},
+		}),
+	}
+	r := NewRouter(routes, outputs)
+
+	m, err := metric.New("cpu",
+		map[string]string{"tag1": "value1"},
+		map[string]interface{}{"value": int64(1)},
+		time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, outputNames(r.Outputs(m)))
+
+	m, err = metric.New("cpu",
+		map[string]string{"tag1": "value2"},
+		map[string]interface{}{"value": int64(1)},
+		time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, outputNames(r.Outputs(m)))
+}
+
+func TestRouterExclusive(t *testing.T) {
+	outputs := []*RunningOutput{newTestRouteOutput("a"), newTestRouteOutput("b")}
+	routes := []*RunningRoute{
+		newTestRoute(t, &RouteConfig{
+			Name:      "first",
+			Outputs:   []string{"a"},
+			Exclusive: true,
+			Filter:    Filter{NamePass: []string{"cpu"}},
+		}),
+		newTestRoute(t, &RouteConfig{
+			Name:    "all",
+			Outputs: []string{"b"},
+		}),
+	}
+	r := NewRouter(routes, outputs)
+
+	assert.Equal(t, []string{"a"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "cpu"))))
+	assert.Equal(t, []string{"b"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "mem"))))
+}
+
+func TestRouterDefault(t *testing.T) {
+	outputs := []*RunningOutput{newTestRouteOutput("a"), newTestRouteOutput("b")}
+	routes := []*RunningRoute{
+		newTestRoute(t, &RouteConfig{
+			Name:    "fallback",
+			Outputs: []string{"b"},
+			Default: true,
+		}),
+		newTestRoute(t, &RouteConfig{
+			Name:    "cpu",
+			Outputs: []string{"a"},
+			Filter:  Filter{NamePass: []string{"cpu"}},
+		}),
+	}
+	r := NewRouter(routes, outputs)
+
+	assert.Equal(t, []string{"a"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "cpu"))))
+	assert.Equal(t, []string{"b"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "mem"))))
+}
+
+func TestRouterUnrouted(t *testing.T) {
+	outputs := []*RunningOutput{newTestRouteOutput("a")}
+	routes := []*RunningRoute{
+		newTestRoute(t, &RouteConfig{
+			Name:    "cpu",
+			Outputs: []string{"a", "missing"},
+			Filter:  Filter{NamePass: []string{"cpu"}},
+		}),
+	}
+	r := NewRouter(routes, outputs)
+	before := r.MetricsUnrouted.Get()
+
+	assert.Equal(t, []string{"a"},
+		outputNames(r.Outputs(testutil.TestMetric(1, "cpu"))))
+	assert.Empty(t, r.Outputs(testutil.TestMetric(1, "mem")))
+	assert.Equal(t, before+1, r.MetricsUnrouted.Get())
+}
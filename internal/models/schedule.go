@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// Schedule tells when an input is gathered, as an alternative to a fixed
+// interval.
+type Schedule struct {
+	// Spec is a standard 5 field cron expression, eg: "5 * * * *", or a
+	// descriptor such as "@daily".
+	Spec string
+	// Location is the time zone the expression is evaluated in.
+	Location *time.Location
+	// Jitter delays each gather by a random amount up to Jitter.
+	Jitter time.Duration
+
+	schedule cron.Schedule
+}
+
+// NewSchedule parses the cron expression. An empty timezone uses the local
+// time zone of the host.
+func NewSchedule(spec, timezone string, jitter time.Duration) (*Schedule, error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %s", spec, err)
+	}
+
+	location := time.Local
+	if timezone != "" {
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule timezone %q: %s",
+				timezone, err)
+		}
+	}
+
+	return &Schedule{
+		Spec:     spec,
+		Location: location,
+		Jitter:   jitter,
+		schedule: schedule,
+	}, nil
+}
+
+// Next returns the first scheduled time after t.
+func (s *Schedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t.In(s.Location))
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	s, err := NewSchedule("5 * * * *", "", 0)
+	require.NoError(t, err)
+
+	now := time.Date(2018, 1, 10, 13, 20, 0, 0, time.Local)
+	assert.Equal(t, time.Date(2018, 1, 10, 14, 5, 0, 0, time.Local), s.Next(now))
+}
+
+func TestScheduleTimezone(t *testing.T) {
+	s, err := NewSchedule("0 2 * * *", "America/New_York", 0)
+	require.NoError(t, err)
+
+	now := time.Date(2018, 1, 10, 0, 0, 0, 0, time.UTC)
+	next := s.Next(now)
+	// 02:00 EST is 07:00 UTC
+	assert.Equal(t, time.Date(2018, 1, 10, 7, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestScheduleDescriptor(t *testing.T) {
+	s, err := NewSchedule("@daily", "UTC", 0)
+	require.NoError(t, err)
+
+	now := time.Date(2018, 1, 10, 13, 20, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2018, 1, 11, 0, 0, 0, 0, time.UTC), s.Next(now))
+}
+
+func TestScheduleInvalid(t *testing.T) {
+	_, err := NewSchedule("5 * *", "", 0)
+	assert.Error(t, err)
+
+	_, err = NewSchedule("5 * * * *", "Not/AZone", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,282 @@
+package process
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+const (
+	// DefaultRestartDelay is the delay before the first restart of a process
+	// that has exited.
+	DefaultRestartDelay = 10 * time.Second
+	// DefaultMaxRestartDelay is the longest delay between restarts.
+	DefaultMaxRestartDelay = 5 * time.Minute
+
+	// MaxLineSize is the longest line accepted by the scanners returned by
+	// NewScanner.
+	MaxLineSize = 1024 * 1024
+
+	// killTimeout is how long Stop waits for the process to exit after its
+	// stdin has been closed before killing it.
+	killTimeout = 5 * time.Second
+)
+
+// Process is a long-running child process that is restarted whenever it
+// exits. The delay before each restart doubles while the process keeps
+// failing, up to MaxRestartDelay, and is reset once the process has stayed
+// up for longer than MaxRestartDelay.
+type Process struct {
+	Command []string
+	// Log is the logger of the plugin running the process.
+	Log telegraf.Logger
+
+	RestartDelay    time.Duration
+	MaxRestartDelay time.Duration
+
+	// ReadStdout and ReadStderr are called in their own goroutine each time
+	// the process is started and must read until an error or EOF. By default
+	// stdout is discarded and each line of stderr is logged. If ReadStdout
+	// returns while the process is running, the process is killed and
+	// restarted, as it would otherwise block once the pipe is full.
+	ReadStdout func(io.Reader)
+	ReadStderr func(io.Reader)
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	stopped bool
+
+	readers sync.WaitGroup
+	cancel  chan struct{}
+	done    chan struct{}
+}
+
+// New returns a Process for the given command and arguments, logging to the
+// logger of the plugin running it.
+func New(command []string, log telegraf.Logger) (*Process, error) {
+	if len(command) == 0 {
+		return nil, errors.New("no command given")
+	}
+
+	return &Process{
+		Command:         command,
+		Log:             log,
+		RestartDelay:    DefaultRestartDelay,
+		MaxRestartDelay: DefaultMaxRestartDelay,
+	}, nil
+}
+
+// Start starts the process and keeps restarting it until Stop is called. An
+// error is only returned if the process could not be started the first time.
+func (p *Process) Start() error {
+	if p.RestartDelay <= 0 {
+		p.RestartDelay = DefaultRestartDelay
+	}
+	if p.MaxRestartDelay < p.RestartDelay {
+		p.MaxRestartDelay = p.RestartDelay
+	}
+	if p.ReadStdout == nil {
+		p.ReadStdout = func(r io.Reader) {
+			io.Copy(ioutil.Discard, r)
+		}
+	}
+	if p.ReadStderr == nil {
+		p.ReadStderr = p.logStderr
+	}
+
+	p.cancel = make(chan struct{})
+	p.done = make(chan struct{})
+
+	if err := p.cmdStart(); err != nil {
+		return err
+	}
+
+	go p.supervise()
+	return nil
+}
+
+// Stop closes the stdin of the process, giving it a chance to exit on its
+// own, and kills it if it is still running after a few seconds. Once Stop
+// returns the output readers have finished.
+func (p *Process) Stop() {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.stopped = true
+	close(p.cancel)
+	if p.stdin != nil {
+		p.stdin.Close()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return
+	case <-time.After(killTimeout):
+	}
+
+	p.mu.Lock()
+	if p.cmd != nil {
+		p.Log.Warnf("Process did not exit after %s, killing it", killTimeout)
+		p.cmd.Process.Kill()
+	}
+	p.mu.Unlock()
+	<-p.done
+}
+
+// Write writes b to the stdin of the process. It fails while the process is
+// being restarted.
+func (p *Process) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	stdin := p.stdin
+	p.mu.Unlock()
+
+	if stdin == nil {
+		return 0, errors.New("process is not running")
+	}
+	return stdin.Write(b)
+}
+
+// Signal sends a signal to the process.
+func (p *Process) Signal(sig os.Signal) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cmd == nil {
+		return errors.New("process is not running")
+	}
+	return p.cmd.Process.Signal(sig)
+}
+
+func (p *Process) cmdStart() error {
+	cmd := exec.Command(p.Command[0], p.Command[1:]...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stopped {
+		return errors.New("process has been stopped")
+	}
+
+	p.Log.Debugf("Starting process: %v", p.Command)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	p.cmd = cmd
+	p.stdin = stdin
+
+	p.readers.Add(2)
+	go func() {
+		defer p.readers.Done()
+		p.ReadStdout(stdout)
+		// a no-op if the process has exited, which closed its stdout
+		cmd.Process.Kill()
+	}()
+	go func() {
+		defer p.readers.Done()
+		p.ReadStderr(stderr)
+	}()
+	return nil
+}
+
+// cmdWait waits for the running process to exit. The readers must be done
+// before calling Wait, which closes the pipes they are reading from.
+func (p *Process) cmdWait() error {
+	p.readers.Wait()
+	err := p.cmd.Wait()
+
+	p.mu.Lock()
+	p.cmd = nil
+	p.stdin = nil
+	p.mu.Unlock()
+	return err
+}
+
+func (p *Process) supervise() {
+	defer close(p.done)
+
+	delay := p.RestartDelay
+	for {
+		started := time.Now()
+		err := p.cmdWait()
+
+		select {
+		case <-p.cancel:
+			return
+		default:
+		}
+
+		if err != nil {
+			p.Log.Errorf("Process exited: %s", err)
+		} else {
+			p.Log.Error("Process exited")
+		}
+
+		if time.Since(started) > p.MaxRestartDelay {
+			delay = p.RestartDelay
+		}
+
+		for {
+			p.Log.Infof("Restarting in %s", delay)
+			select {
+			case <-p.cancel:
+				return
+			case <-time.After(delay):
+			}
+
+			delay *= 2
+			if delay > p.MaxRestartDelay {
+				delay = p.MaxRestartDelay
+			}
+
+			if err := p.cmdStart(); err != nil {
+				p.Log.Errorf("Error starting process: %s", err)
+				continue
+			}
+			break
+		}
+	}
+}
+
+func (p *Process) logStderr(r io.Reader) {
+	scanner := NewScanner(r)
+	for scanner.Scan() {
+		p.Log.Errorf("stderr: %q", scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		p.Log.Errorf("Error reading stderr: %s", err)
+		// keep reading so that the process does not block writing to stderr
+		io.Copy(ioutil.Discard, r)
+	}
+}
+
+// NewScanner returns a scanner of the lines of r, accepting lines up to
+// MaxLineSize rather than the 64KB of bufio.Scanner.
+func NewScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxLineSize)
+	return scanner
+}
@@ -0,0 +1,152 @@
+// +build !windows
+
+package process
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartWithBackoff(t *testing.T) {
+	var mu sync.Mutex
+	starts := []time.Time{}
+
+	p, err := New([]string{"sh", "-c", "echo started"}, testutil.Logger{})
+	require.NoError(t, err)
+	p.RestartDelay = 10 * time.Millisecond
+	p.MaxRestartDelay = time.Second
+	p.ReadStdout = func(r io.Reader) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+		}
+	}
+
+	require.NoError(t, p.Start())
+	time.Sleep(200 * time.Millisecond)
+	p.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	// 0 + 10 + 20 + 40 + 80ms
+	require.True(t, len(starts) >= 4, "process started %d times", len(starts))
+	for i := 2; i < len(starts); i++ {
+		assert.True(t, starts[i].Sub(starts[i-1]) > starts[i-1].Sub(starts[i-2]),
+			"restart delay did not increase")
+	}
+}
+
+func TestWriteAndStop(t *testing.T) {
+	lines := make(chan string, 10)
+
+	p, err := New([]string{"cat"}, testutil.Logger{})
+	require.NoError(t, err)
+	p.ReadStdout = func(r io.Reader) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}
+
+	require.NoError(t, p.Start())
+	_, err = p.Write([]byte("hello\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", <-lines)
+
+	// cat exits once its stdin is closed
+	done := make(chan struct{})
+	go func() {
+		p.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(killTimeout):
+		t.Fatal("process was not stopped by closing stdin")
+	}
+
+	_, ok := <-lines
+	assert.False(t, ok)
+
+	_, err = p.Write([]byte("hello\n"))
+	assert.Error(t, err)
+}
+
+func TestStartError(t *testing.T) {
+	_, err := New(nil, testutil.Logger{})
+	assert.Error(t, err)
+
+	p, err := New([]string{"/nonexistent/command"}, testutil.Logger{})
+	require.NoError(t, err)
+	assert.Error(t, p.Start())
+}
+
+func TestLongLines(t *testing.T) {
+	lines := make(chan int, 10)
+
+	// a line longer than the 64KB default of bufio.Scanner
+	p, err := New([]string{"sh", "-c",
+		"head -c 100000 /dev/zero | tr '\\0' a; echo; exec cat"},
+		testutil.Logger{})
+	require.NoError(t, err)
+	p.ReadStdout = func(r io.Reader) {
+		scanner := NewScanner(r)
+		for scanner.Scan() {
+			lines <- len(scanner.Text())
+		}
+	}
+
+	require.NoError(t, p.Start())
+	defer p.Stop()
+	select {
+	case n := <-lines:
+		assert.Equal(t, 100000, n)
+	case <-time.After(5 * time.Second):
+		t.Fatal("line was not read")
+	}
+}
+
+func TestRestartWhenStdoutIsNoLongerRead(t *testing.T) {
+	var mu sync.Mutex
+	reads := 0
+
+	// the process would block writing its output if it was not killed
+	p, err := New([]string{"yes"}, testutil.Logger{})
+	require.NoError(t, err)
+	p.RestartDelay = 10 * time.Millisecond
+	p.ReadStdout = func(r io.Reader) {
+		mu.Lock()
+		reads++
+		mu.Unlock()
+		// stop reading after the first line
+		bufio.NewReader(r).ReadString('\n')
+	}
+
+	require.NoError(t, p.Start())
+	time.Sleep(200 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		p.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(killTimeout):
+		t.Fatal("process was not stopped")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.True(t, reads >= 2, "process started %d times", reads)
+}
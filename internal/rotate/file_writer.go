@@ -0,0 +1,200 @@
+package rotate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// archiveTimeFormat is the timestamp inserted in the name of a rotated file,
+// ie, telegraf.log is archived as telegraf.2018-01-24T10-32-00.log
+const archiveTimeFormat = "2006-01-02T15-04-05"
+
+// FileWriter is a file that is rotated once it is bigger than maxSize or older
+// than maxAge. The rotated files are kept next to it.
+type FileWriter struct {
+	filename    string
+	maxAge      time.Duration
+	maxSize     int64
+	maxArchives int
+
+	sync.Mutex
+	file    *os.File
+	size    int64
+	created time.Time
+}
+
+// NewFileWriter opens filename in append mode. A zero maxAge or maxSize
+// disables the matching rotation, and a zero maxArchives keeps all the rotated
+// files.
+func NewFileWriter(
+	filename string,
+	maxAge time.Duration,
+	maxSize int64,
+	maxArchives int,
+) (*FileWriter, error) {
+	w := &FileWriter{
+		filename:    filename,
+		maxAge:      maxAge,
+		maxSize:     maxSize,
+		maxArchives: maxArchives,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *FileWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.needsRotation(len(p)) {
+		// a failed rotation is retried on the next write, the message is
+		// written to the current file meanwhile rather than lost
+		w.rotate()
+	}
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file.
+func (w *FileWriter) Close() error {
+	w.Lock()
+	defer w.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *FileWriter) open() error {
+	file, err := os.OpenFile(w.filename,
+		os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = stat.Size()
+	w.created = time.Now()
+	return nil
+}
+
+func (w *FileWriter) needsRotation(n int) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.maxSize > 0 && w.size+int64(n) > w.maxSize {
+		return true
+	}
+	return w.maxAge > 0 && time.Since(w.created) >= w.maxAge
+}
+
+// rotate archives the current file and opens a new one. The file is closed
+// even if the rotation fails, so that it is reopened by the next write. The
+// rotation succeeds once the new file is open, even if the oldest archives
+// could not be removed.
+func (w *FileWriter) rotate() error {
+	err := w.file.Close()
+	w.file = nil
+	if err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(w.filename)
+	base := strings.TrimSuffix(w.filename, ext)
+	archive := fmt.Sprintf("%s.%s%s", base,
+		time.Now().UTC().Format(archiveTimeFormat), ext)
+	for i := 1; exists(archive); i++ {
+		archive = fmt.Sprintf("%s.%s-%d%s", base,
+			time.Now().UTC().Format(archiveTimeFormat), i, ext)
+	}
+	if err := os.Rename(w.filename, archive); err != nil {
+		return err
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+	w.removeArchives(base, ext)
+	return nil
+}
+
+// removeArchives removes the oldest rotated files above maxArchives. Only
+// the files named as the archives created by rotate are removed, other files
+// sharing the name of the log, ie, telegraf.debug.log, are left alone.
+func (w *FileWriter) removeArchives(base, ext string) error {
+	if w.maxArchives <= 0 {
+		return nil
+	}
+
+	files, err := ioutil.ReadDir(filepath.Dir(base))
+	if err != nil {
+		return err
+	}
+	prefix := filepath.Base(base) + "."
+	var archives []string
+	for _, file := range files {
+		if isArchive(file.Name(), prefix, ext) {
+			archives = append(archives, filepath.Join(filepath.Dir(base), file.Name()))
+		}
+	}
+	// the archive names sort in the order they were rotated, without the
+	// extension so that a name sorts before its duplicates
+	sort.Slice(archives, func(i, j int) bool {
+		return strings.TrimSuffix(archives[i], ext) <
+			strings.TrimSuffix(archives[j], ext)
+	})
+	for len(archives) > w.maxArchives {
+		if err := os.Remove(archives[0]); err != nil {
+			return err
+		}
+		archives = archives[1:]
+	}
+	return nil
+}
+
+// isArchive returns true if name is prefix followed by the archive time,
+// an optional "-N" suffix for duplicates, and ext.
+func isArchive(name, prefix, ext string) bool {
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) ||
+		len(name) < len(prefix)+len(archiveTimeFormat)+len(ext) {
+		return false
+	}
+	stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+	if _, err := time.Parse(archiveTimeFormat, stamp[:len(archiveTimeFormat)]); err != nil {
+		return false
+	}
+	dup := stamp[len(archiveTimeFormat):]
+	if dup == "" {
+		return true
+	}
+	_, err := strconv.Atoi(strings.TrimPrefix(dup, "-"))
+	return strings.HasPrefix(dup, "-") && err == nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
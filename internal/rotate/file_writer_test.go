@@ -0,0 +1,177 @@
+package rotate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWriter_NoRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := NewFileWriter(filepath.Join(dir, "test.log"), 0, 0, 0)
+	require.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("Hello World"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("Hello World 2"))
+	require.NoError(t, err)
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 1)
+}
+
+func TestFileWriter_SizeRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "test.log")
+	w, err := NewFileWriter(filename, 0, 20, 0)
+	require.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("Hello World"))
+	require.NoError(t, err)
+	// does not fit anymore, the first line is archived
+	_, err = w.Write([]byte("Hello World 2"))
+	require.NoError(t, err)
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 2)
+
+	content, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World 2", string(content))
+}
+
+func TestFileWriter_AgeRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := NewFileWriter(filepath.Join(dir, "test.log"),
+		10*time.Millisecond, 0, 0)
+	require.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("Hello World"))
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = w.Write([]byte("Hello World 2"))
+	require.NoError(t, err)
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 2)
+}
+
+func TestFileWriter_MaxArchives(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := NewFileWriter(filepath.Join(dir, "test.log"), 0, 5, 2)
+	require.NoError(t, err)
+	defer w.Close()
+
+	for i := 0; i < 5; i++ {
+		_, err = w.Write([]byte("Hello World"))
+		require.NoError(t, err)
+	}
+
+	// the current file and the 2 most recent archives
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 3)
+}
+
+func TestFileWriter_RenameError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "test.log")
+	w, err := NewFileWriter(filename, 0, 20, 0)
+	require.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("Hello World"))
+	require.NoError(t, err)
+	// the file can't be archived once it has been removed, it is reopened
+	require.NoError(t, os.Remove(filename))
+	_, err = w.Write([]byte("Hello World 2"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("Hello World 3"))
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World 3", string(content))
+}
+
+func TestFileWriter_RemoveArchivesError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the oldest archive can't be removed as it is a directory with a file
+	stuck := filepath.Join(dir, "test.2000-01-01T00-00-00.log")
+	require.NoError(t, os.MkdirAll(filepath.Join(stuck, "file"), 0755))
+
+	w, err := NewFileWriter(filepath.Join(dir, "test.log"), 0, 5, 1)
+	require.NoError(t, err)
+	defer w.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err = w.Write([]byte("Hello World"))
+		require.NoError(t, err)
+	}
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 4)
+}
+
+func TestFileWriter_MaxArchivesKeepsOtherFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	others := []string{"test.debug.log", "test.old.log", "test.2000-01-01.log",
+		"test.2000-01-01T00-00-00-old.log", "other.2000-01-01T00-00-00.log"}
+	for _, name := range others {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	// the name is not a valid glob pattern
+	w, err := NewFileWriter(filepath.Join(dir, "test[.log"), 0, 5, 1)
+	require.NoError(t, err)
+	defer w.Close()
+
+	for i := 0; i < 5; i++ {
+		_, err = w.Write([]byte("Hello World"))
+		require.NoError(t, err)
+	}
+
+	// the current file and the most recent archive
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, len(others)+2)
+	for _, name := range others {
+		assert.True(t, exists(filepath.Join(dir, name)), name)
+	}
+}
+
+func TestIsArchive(t *testing.T) {
+	assert.True(t, isArchive("test.2018-01-24T10-32-00.log", "test.", ".log"))
+	assert.True(t, isArchive("test.2018-01-24T10-32-00-2.log", "test.", ".log"))
+	assert.False(t, isArchive("test.debug.log", "test.", ".log"))
+	assert.False(t, isArchive("test.2018-01-24T10-32-00-x.log", "test.", ".log"))
+	assert.False(t, isArchive("test.2018-01-24T10-32-00", "test.", ".log"))
+	assert.False(t, isArchive("test.log", "test.", ".log"))
+}
@@ -1,69 +1,274 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/influxdata/telegraf/internal/rotate"
 	"github.com/influxdata/wlog"
 )
 
-var prefixRegex = regexp.MustCompile("^[DIWE]!")
+// Log formats
+const (
+	// FormatText writes the messages as they are logged, prefixed with the
+	// time.
+	FormatText = "text"
+	// FormatJSON writes a JSON object per message.
+	FormatJSON = "json"
+	// FormatLogfmt writes a line of key=value pairs per message.
+	FormatLogfmt = "logfmt"
+)
+
+// prefixRegex matches the level prefix of a message, and the name of the
+// plugin logging it as written by the plugin loggers, ie, "E! [inputs.cpu] "
+var prefixRegex = regexp.MustCompile(`^([DIWE])! (?:\[([^\]\s]+)\] )?`)
+
+var levelNames = map[wlog.Level]string{
+	wlog.DEBUG: "debug",
+	wlog.INFO:  "info",
+	wlog.WARN:  "warn",
+	wlog.ERROR: "error",
+}
+
+var (
+	levelsMu     sync.RWMutex
+	pluginLevels = map[string]wlog.Level{}
+)
+
+// ParseLevel parses a log level name: "debug", "info", "warn" or "error".
+func ParseLevel(name string) (wlog.Level, error) {
+	for level, n := range levelNames {
+		if strings.ToLower(name) == n {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid log level %q, must be one of debug, "+
+		"info, warn or error", name)
+}
+
+func levelFor(plugin string) wlog.Level {
+	if plugin != "" {
+		levelsMu.RLock()
+		level, ok := pluginLevels[plugin]
+		levelsMu.RUnlock()
+		if ok {
+			return level
+		}
+	}
+	return wlog.LogLevel()
+}
+
+// entry is a parsed log message.
+type entry struct {
+	time    time.Time
+	level   wlog.Level
+	plugin  string
+	alias   string
+	message string
+}
+
+// name returns the name of the plugin logger, including the alias.
+func (e entry) name() string {
+	if e.alias != "" {
+		return e.plugin + "::" + e.alias
+	}
+	return e.plugin
+}
+
+func parseEntry(b []byte) entry {
+	e := entry{
+		time:  time.Now().UTC(),
+		level: wlog.INFO,
+	}
+	match := prefixRegex.FindSubmatch(b)
+	if match == nil {
+		e.message = string(bytes.TrimRight(b, "\n"))
+		return e
+	}
+
+	e.level = wlog.Levels[match[1][0]]
+	// plugin loggers name the plugin "inputs.cpu::alias" when it has an alias
+	parts := strings.SplitN(string(match[2]), "::", 2)
+	e.plugin = parts[0]
+	if len(parts) == 2 {
+		e.alias = parts[1]
+	}
+	e.message = string(bytes.TrimRight(b[len(match[0]):], "\n"))
+	return e
+}
 
 // newTelegrafWriter returns a logging-wrapped writer.
 func newTelegrafWriter(w io.Writer) io.Writer {
 	return &telegrafLog{
-		writer: wlog.NewWriter(w),
+		writer: w,
+		format: FormatText,
 	}
 }
 
 type telegrafLog struct {
 	writer io.Writer
+	format string
 }
 
 func (t *telegrafLog) Write(b []byte) (n int, err error) {
+	e := parseEntry(b)
+	if e.level < levelFor(e.name()) {
+		return len(b), nil
+	}
+
 	var line []byte
-	if !prefixRegex.Match(b) {
-		line = append([]byte(time.Now().UTC().Format(time.RFC3339)+" I! "), b...)
-	} else {
-		line = append([]byte(time.Now().UTC().Format(time.RFC3339)+" "), b...)
+	switch t.format {
+	case FormatJSON:
+		line = formatJSON(e)
+	case FormatLogfmt:
+		line = formatLogfmt(e)
+	default:
+		line = []byte(e.time.Format(time.RFC3339) + " ")
+		if !prefixRegex.Match(b) {
+			line = append(line, "I! "...)
+		}
+		line = append(line, b...)
 	}
 	return t.writer.Write(line)
 }
 
+type jsonEntry struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Plugin  string `json:"plugin,omitempty"`
+	Alias   string `json:"alias,omitempty"`
+	Message string `json:"message"`
+}
+
+func formatJSON(e entry) []byte {
+	b, err := json.Marshal(jsonEntry{
+		Time:    e.time.Format(time.RFC3339Nano),
+		Level:   levelNames[e.level],
+		Plugin:  e.plugin,
+		Alias:   e.alias,
+		Message: e.message,
+	})
+	if err != nil {
+		return []byte(e.message + "\n")
+	}
+	return append(b, '\n')
+}
+
+func formatLogfmt(e entry) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("time=" + e.time.Format(time.RFC3339Nano))
+	buf.WriteString(" level=" + levelNames[e.level])
+	if e.plugin != "" {
+		buf.WriteString(" plugin=" + logfmtValue(e.plugin))
+	}
+	if e.alias != "" {
+		buf.WriteString(" alias=" + logfmtValue(e.alias))
+	}
+	buf.WriteString(" msg=" + logfmtValue(e.message))
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+func logfmtValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\\\n\t") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// LogConfig is the configuration of the logging.
+type LogConfig struct {
+	// Debug sets the log level to DEBUG
+	Debug bool
+	// Quiet sets the log level to ERROR
+	Quiet bool
+	// Logfile directs the logging output to a file. Empty string is
+	// interpreted as stderr. If there is an error opening the file the logger
+	// will fallback to stderr.
+	Logfile string
+	// Format is one of FormatText, FormatJSON or FormatLogfmt, empty string
+	// is interpreted as FormatText.
+	Format string
+	// RotationMaxAge rotates the logfile once it is older, 0 disables it.
+	RotationMaxAge time.Duration
+	// RotationMaxSize rotates the logfile once it would grow bigger, in
+	// bytes, 0 disables it.
+	RotationMaxSize int64
+	// RotationMaxArchives is the number of rotated logfiles to keep, 0 keeps
+	// all of them.
+	RotationMaxArchives int
+	// PluginLevels overrides the log level for the messages of plugins, by
+	// the name of their logger, ie, "inputs.cpu" or "inputs.cpu::alias".
+	// The levels of a previous setup are discarded.
+	PluginLevels map[string]string
+}
+
+// logfile is the file currently logged to, closed when the logging is set up
+// again.
+var logfile *rotate.FileWriter
+
 // SetupLogging configures the logging output.
-//   debug   will set the log level to DEBUG
-//   quiet   will set the log level to ERROR
-//   logfile will direct the logging output to a file. Empty string is
-//           interpreted as stderr. If there is an error opening the file the
-//           logger will fallback to stderr.
-func SetupLogging(debug, quiet bool, logfile string) {
+func SetupLogging(config LogConfig) {
 	log.SetFlags(0)
-	if debug {
+	switch {
+	case config.Quiet:
+		wlog.SetLevel(wlog.ERROR)
+	case config.Debug:
 		wlog.SetLevel(wlog.DEBUG)
+	default:
+		wlog.SetLevel(wlog.INFO)
 	}
-	if quiet {
-		wlog.SetLevel(wlog.ERROR)
+
+	levels := make(map[string]wlog.Level, len(config.PluginLevels))
+	for plugin, name := range config.PluginLevels {
+		level, err := ParseLevel(name)
+		if err != nil {
+			log.Printf("E! [%s] %s", plugin, err)
+			continue
+		}
+		levels[plugin] = level
 	}
+	levelsMu.Lock()
+	pluginLevels = levels
+	levelsMu.Unlock()
 
-	var oFile *os.File
-	if logfile != "" {
-		if _, err := os.Stat(logfile); os.IsNotExist(err) {
-			if oFile, err = os.Create(logfile); err != nil {
-				log.Printf("E! Unable to create %s (%s), using stderr", logfile, err)
-				oFile = os.Stderr
-			}
+	var w io.Writer = os.Stderr
+	var file *rotate.FileWriter
+	if config.Logfile != "" {
+		var err error
+		file, err = rotate.NewFileWriter(config.Logfile,
+			config.RotationMaxAge, config.RotationMaxSize,
+			config.RotationMaxArchives)
+		if err != nil {
+			log.Printf("E! Unable to open %s (%s), using stderr",
+				config.Logfile, err)
 		} else {
-			if oFile, err = os.OpenFile(logfile, os.O_APPEND|os.O_WRONLY, os.ModeAppend); err != nil {
-				log.Printf("E! Unable to append to %s (%s), using stderr", logfile, err)
-				oFile = os.Stderr
-			}
+			w = file
 		}
-	} else {
-		oFile = os.Stderr
 	}
 
-	log.SetOutput(newTelegrafWriter(oFile))
+	format := config.Format
+	switch format {
+	case "":
+		format = FormatText
+	case FormatText, FormatJSON, FormatLogfmt:
+	default:
+		log.Printf("E! Invalid log format %q, using %s", format, FormatText)
+		format = FormatText
+	}
+
+	log.SetOutput(&telegrafLog{writer: w, format: format})
+	if logfile != nil {
+		logfile.Close()
+	}
+	logfile = file
 }
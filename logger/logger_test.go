@@ -2,12 +2,14 @@ package logger
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"log"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWriteLogToFile(t *testing.T) {
@@ -15,7 +17,7 @@ func TestWriteLogToFile(t *testing.T) {
 	assert.NoError(t, err)
 	defer func() { os.Remove(tmpfile.Name()) }()
 
-	SetupLogging(false, false, tmpfile.Name())
+	SetupLogging(LogConfig{Logfile: tmpfile.Name()})
 	log.Printf("I! TEST")
 	log.Printf("D! TEST") // <- should be ignored
 
@@ -29,7 +31,7 @@ func TestDebugWriteLogToFile(t *testing.T) {
 	assert.NoError(t, err)
 	defer func() { os.Remove(tmpfile.Name()) }()
 
-	SetupLogging(true, false, tmpfile.Name())
+	SetupLogging(LogConfig{Debug: true, Logfile: tmpfile.Name()})
 	log.Printf("D! TEST")
 
 	f, err := ioutil.ReadFile(tmpfile.Name())
@@ -42,7 +44,7 @@ func TestErrorWriteLogToFile(t *testing.T) {
 	assert.NoError(t, err)
 	defer func() { os.Remove(tmpfile.Name()) }()
 
-	SetupLogging(false, true, tmpfile.Name())
+	SetupLogging(LogConfig{Quiet: true, Logfile: tmpfile.Name()})
 	log.Printf("E! TEST")
 	log.Printf("I! TEST") // <- should be ignored
 
@@ -56,7 +58,7 @@ func TestAddDefaultLogLevel(t *testing.T) {
 	assert.NoError(t, err)
 	defer func() { os.Remove(tmpfile.Name()) }()
 
-	SetupLogging(true, false, tmpfile.Name())
+	SetupLogging(LogConfig{Debug: true, Logfile: tmpfile.Name()})
 	log.Printf("TEST")
 
 	f, err := ioutil.ReadFile(tmpfile.Name())
@@ -64,6 +66,81 @@ func TestAddDefaultLogLevel(t *testing.T) {
 	assert.Equal(t, f[19:], []byte("Z I! TEST\n"))
 }
 
+func TestJSONWriteLogToFile(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "")
+	assert.NoError(t, err)
+	defer func() { os.Remove(tmpfile.Name()) }()
+
+	SetupLogging(LogConfig{Logfile: tmpfile.Name(), Format: FormatJSON})
+	log.Printf("E! [inputs.cpu::percpu] TEST \"quoted\"")
+	log.Printf("W! TEST")
+
+	f, err := ioutil.ReadFile(tmpfile.Name())
+	assert.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(f), []byte{'\n'})
+	require.Len(t, lines, 2)
+
+	var e map[string]string
+	require.NoError(t, json.Unmarshal(lines[0], &e))
+	assert.Equal(t, "error", e["level"])
+	assert.Equal(t, "inputs.cpu", e["plugin"])
+	assert.Equal(t, "percpu", e["alias"])
+	assert.Equal(t, `TEST "quoted"`, e["message"])
+	assert.NotEmpty(t, e["time"])
+
+	e = nil
+	require.NoError(t, json.Unmarshal(lines[1], &e))
+	assert.Equal(t, "warn", e["level"])
+	assert.NotContains(t, e, "plugin")
+	assert.Equal(t, "TEST", e["message"])
+}
+
+func TestLogfmtWriteLogToFile(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "")
+	assert.NoError(t, err)
+	defer func() { os.Remove(tmpfile.Name()) }()
+
+	SetupLogging(LogConfig{Logfile: tmpfile.Name(), Format: FormatLogfmt})
+	log.Printf("I! [outputs.file] wrote batch")
+
+	f, err := ioutil.ReadFile(tmpfile.Name())
+	assert.NoError(t, err)
+	assert.Regexp(t, `^time=\S+ level=info plugin=outputs.file msg="wrote batch"\n$`,
+		string(f))
+}
+
+func TestPluginLogLevel(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "")
+	assert.NoError(t, err)
+	defer func() { os.Remove(tmpfile.Name()) }()
+
+	SetupLogging(LogConfig{
+		Logfile: tmpfile.Name(),
+		PluginLevels: map[string]string{
+			"inputs.debugged": "debug",
+			"inputs.quieted":  "error",
+		},
+	})
+	log.Printf("D! [inputs.debugged] TEST")
+	log.Printf("D! [inputs.other] TEST")   // <- should be ignored
+	log.Printf("W! [inputs.quieted] TEST") // <- should be ignored
+
+	f, err := ioutil.ReadFile(tmpfile.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, f[19:], []byte("Z D! [inputs.debugged] TEST\n"))
+
+	// the levels of the previous setup are discarded
+	require.NoError(t, os.Truncate(tmpfile.Name(), 0))
+	SetupLogging(LogConfig{Logfile: tmpfile.Name()})
+	log.Printf("D! [inputs.debugged] TEST") // <- should be ignored
+	log.Printf("W! [inputs.quieted] TEST")
+
+	f, err = ioutil.ReadFile(tmpfile.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, f[19:], []byte("Z W! [inputs.quieted] TEST\n"))
+	SetupLogging(LogConfig{})
+}
+
 func BenchmarkTelegrafLogWrite(b *testing.B) {
 	var msg = []byte("test")
 	var buf bytes.Buffer
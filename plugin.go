@@ -0,0 +1,10 @@
+package telegraf
+
+// Initializer is an interface that Inputs, Outputs, Processors and
+// Aggregators can optionally implement to initialize the plugin once its
+// configuration has been loaded.
+type Initializer interface {
+	// Init performs one time setup of the plugin and returns an error if the
+	// configuration is invalid.
+	Init() error
+}
@@ -1,7 +1,6 @@
 package basicstats
 
 import (
-	"log"
 	"math"
 
 	"github.com/influxdata/telegraf"
@@ -11,6 +10,8 @@ import (
 type BasicStats struct {
 	Stats []string `toml:"stats"`
 
+	Log telegraf.Logger `toml:"-"`
+
 	cache       map[uint64]aggregate
 	statsConfig *configuredStats
 }
@@ -167,11 +168,11 @@ func (m *BasicStats) Push(acc telegraf.Accumulator) {
 	}
 }
 
-func parseStats(names []string) *configuredStats {
+func (m *BasicStats) parseStats() *configuredStats {
 
 	parsed := &configuredStats{}
 
-	for _, name := range names {
+	for _, name := range m.Stats {
 
 		switch name {
 
@@ -189,7 +190,7 @@ func parseStats(names []string) *configuredStats {
 			parsed.stdev = true
 
 		default:
-			log.Printf("W! Unrecognized basic stat '%s', ignoring", name)
+			m.Log.Warnf("Unrecognized basic stat %q, ignoring", name)
 		}
 	}
 
@@ -217,7 +218,7 @@ func getConfiguredStats(m *BasicStats) *configuredStats {
 		if m.Stats == nil {
 			m.statsConfig = defaultStats()
 		} else {
-			m.statsConfig = parseStats(m.Stats)
+			m.statsConfig = m.parseStats()
 		}
 	}
 
@@ -35,6 +35,7 @@ var m2, _ = metric.New("m1",
 
 func BenchmarkApply(b *testing.B) {
 	minmax := NewBasicStats()
+	minmax.Log = testutil.Logger{}
 
 	for n := 0; n < b.N; n++ {
 		minmax.Add(m1)
@@ -46,6 +47,7 @@ func BenchmarkApply(b *testing.B) {
 func TestBasicStatsWithPeriod(t *testing.T) {
 	acc := testutil.Accumulator{}
 	minmax := NewBasicStats()
+	minmax.Log = testutil.Logger{}
 
 	minmax.Add(m1)
 	minmax.Add(m2)
@@ -92,6 +94,7 @@ func TestBasicStatsWithPeriod(t *testing.T) {
 func TestBasicStatsDifferentPeriods(t *testing.T) {
 	acc := testutil.Accumulator{}
 	minmax := NewBasicStats()
+	minmax.Log = testutil.Logger{}
 
 	minmax.Add(m1)
 	minmax.Push(&acc)
@@ -154,6 +157,7 @@ func TestBasicStatsDifferentPeriods(t *testing.T) {
 func TestBasicStatsWithOnlyCount(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{"count"}
 
 	aggregator.Add(m1)
@@ -179,6 +183,7 @@ func TestBasicStatsWithOnlyCount(t *testing.T) {
 func TestBasicStatsWithOnlyMin(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{"min"}
 
 	aggregator.Add(m1)
@@ -204,6 +209,7 @@ func TestBasicStatsWithOnlyMin(t *testing.T) {
 func TestBasicStatsWithOnlyMax(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{"max"}
 
 	aggregator.Add(m1)
@@ -229,6 +235,7 @@ func TestBasicStatsWithOnlyMax(t *testing.T) {
 func TestBasicStatsWithOnlyMean(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{"mean"}
 
 	aggregator.Add(m1)
@@ -254,6 +261,7 @@ func TestBasicStatsWithOnlyMean(t *testing.T) {
 func TestBasicStatsWithOnlyVariance(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{"s2"}
 
 	aggregator.Add(m1)
@@ -278,6 +286,7 @@ func TestBasicStatsWithOnlyVariance(t *testing.T) {
 func TestBasicStatsWithOnlyStandardDeviation(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{"stdev"}
 
 	aggregator.Add(m1)
@@ -302,6 +311,7 @@ func TestBasicStatsWithOnlyStandardDeviation(t *testing.T) {
 func TestBasicStatsWithMinAndMax(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{"min", "max"}
 
 	aggregator.Add(m1)
@@ -332,6 +342,7 @@ func TestBasicStatsWithMinAndMax(t *testing.T) {
 func TestBasicStatsWithNoStats(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{}
 
 	aggregator.Add(m1)
@@ -347,6 +358,7 @@ func TestBasicStatsWithNoStats(t *testing.T) {
 func TestBasicStatsWithUnknownStat(t *testing.T) {
 
 	aggregator := NewBasicStats()
+	aggregator.Log = testutil.Logger{}
 	aggregator.Stats = []string{"crazy"}
 
 	aggregator.Add(m1)
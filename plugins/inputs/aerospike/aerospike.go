@@ -2,7 +2,6 @@ package aerospike
 
 import (
 	"errors"
-	"log"
 	"net"
 	"strconv"
 	"strings"
@@ -17,6 +16,8 @@ import (
 
 type Aerospike struct {
 	Servers []string
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 var sampleConfig = `
@@ -85,7 +86,7 @@ func (a *Aerospike) gatherServer(hostport string, acc telegraf.Accumulator) erro
 			if err == nil {
 				fields[strings.Replace(k, "-", "_", -1)] = val
 			} else {
-				log.Printf("I! skipping aerospike field %v with int64 overflow: %q", k, v)
+				a.Log.Infof("skipping aerospike field %v with int64 overflow: %q", k, v)
 			}
 		}
 		acc.AddFields("aerospike_node", fields, tags, time.Now())
@@ -117,7 +118,7 @@ func (a *Aerospike) gatherServer(hostport string, acc telegraf.Accumulator) erro
 				if err == nil {
 					nFields[strings.Replace(parts[0], "-", "_", -1)] = val
 				} else {
-					log.Printf("I! skipping aerospike field %v with int64 overflow: %q", parts[0], parts[1])
+					a.Log.Infof("skipping aerospike field %v with int64 overflow: %q", parts[0], parts[1])
 				}
 			}
 			acc.AddFields("aerospike_namespace", nFields, nTags, time.Now())
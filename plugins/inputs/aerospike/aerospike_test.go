@@ -14,6 +14,7 @@ func TestAerospikeStatistics(t *testing.T) {
 	}
 
 	a := &Aerospike{
+		Log:     testutil.Logger{},
 		Servers: []string{testutil.GetLocalHost() + ":3000"},
 	}
 
@@ -35,6 +36,7 @@ func TestAerospikeStatisticsPartialErr(t *testing.T) {
 	}
 
 	a := &Aerospike{
+		Log: testutil.Logger{},
 		Servers: []string{
 			testutil.GetLocalHost() + ":3000",
 			testutil.GetLocalHost() + ":9999",
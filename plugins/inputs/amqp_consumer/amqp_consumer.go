@@ -2,7 +2,6 @@ package amqp_consumer
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 	"time"
@@ -40,6 +39,8 @@ type AMQPConsumer struct {
 	// Use SSL but skip chain & host verification
 	InsecureSkipVerify bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	parser parsers.Parser
 	conn   *amqp.Connection
 	wg     *sync.WaitGroup
@@ -150,11 +151,11 @@ func (a *AMQPConsumer) Start(acc telegraf.Accumulator) error {
 			return
 		}
 
-		log.Printf("I! AMQP consumer connection closed: %s; trying to reconnect", err)
+		a.Log.Infof("AMQP consumer connection closed: %s; trying to reconnect", err)
 		for {
 			msgs, err := a.connect(amqpConf)
 			if err != nil {
-				log.Printf("E! AMQP connection failed: %s", err)
+				a.Log.Errorf("AMQP connection failed: %s", err)
 				time.Sleep(10 * time.Second)
 				continue
 			}
@@ -238,7 +239,7 @@ func (a *AMQPConsumer) connect(amqpConf *amqp.Config) (<-chan amqp.Delivery, err
 		return nil, fmt.Errorf("Failed establishing connection to queue: %s", err)
 	}
 
-	log.Println("I! Started AMQP consumer")
+	a.Log.Info("Started AMQP consumer")
 	return msgs, err
 }
 
@@ -248,7 +249,7 @@ func (a *AMQPConsumer) process(msgs <-chan amqp.Delivery, acc telegraf.Accumulat
 	for d := range msgs {
 		metrics, err := a.parser.Parse(d.Body)
 		if err != nil {
-			log.Printf("E! %v: error parsing metric - %v", err, string(d.Body))
+			a.Log.Errorf("%v: error parsing metric - %v", err, string(d.Body))
 		} else {
 			for _, m := range metrics {
 				acc.AddFields(m.Name(), m.Fields(), m.Tags(), m.Time())
@@ -257,17 +258,17 @@ func (a *AMQPConsumer) process(msgs <-chan amqp.Delivery, acc telegraf.Accumulat
 
 		d.Ack(false)
 	}
-	log.Printf("I! AMQP consumer queue closed")
+	a.Log.Infof("AMQP consumer queue closed")
 }
 
 func (a *AMQPConsumer) Stop() {
 	err := a.conn.Close()
 	if err != nil && err != amqp.ErrClosed {
-		log.Printf("E! Error closing AMQP connection: %s", err)
+		a.Log.Errorf("Error closing AMQP connection: %s", err)
 		return
 	}
 	a.wg.Wait()
-	log.Println("I! Stopped AMQP service")
+	a.Log.Info("Stopped AMQP service")
 }
 
 func init() {
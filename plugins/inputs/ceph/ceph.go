@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -33,6 +32,8 @@ type Ceph struct {
 	CephConfig             string
 	GatherAdminSocketStats bool
 	GatherClusterStats     bool
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 func (c *Ceph) Description() string {
@@ -104,7 +105,7 @@ func (c *Ceph) gatherAdminSocketStats(acc telegraf.Accumulator) error {
 			acc.AddError(fmt.Errorf("E! error reading from socket '%s': %v", s.socket, err))
 			continue
 		}
-		data, err := parseDump(dump)
+		data, err := c.parseDump(dump)
 		if err != nil {
 			acc.AddError(fmt.Errorf("E! error parsing dump from socket '%s': %v", s.socket, err))
 			continue
@@ -245,23 +246,23 @@ type taggedMetricMap map[string]metricMap
 
 // Parses a raw JSON string into a taggedMetricMap
 // Delegates the actual parsing to newTaggedMetricMap(..)
-func parseDump(dump string) (taggedMetricMap, error) {
+func (c *Ceph) parseDump(dump string) (taggedMetricMap, error) {
 	data := make(map[string]interface{})
 	err := json.Unmarshal([]byte(dump), &data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse json: '%s': %v", dump, err)
 	}
 
-	return newTaggedMetricMap(data), nil
+	return c.newTaggedMetricMap(data), nil
 }
 
 // Builds a TaggedMetricMap out of a generic string map.
 // The top-level key is used as a tag and all sub-keys are flattened into metrics
-func newTaggedMetricMap(data map[string]interface{}) taggedMetricMap {
+func (c *Ceph) newTaggedMetricMap(data map[string]interface{}) taggedMetricMap {
 	tmm := make(taggedMetricMap)
 	for tag, datapoints := range data {
 		mm := make(metricMap)
-		for _, m := range flatten(datapoints) {
+		for _, m := range c.flatten(datapoints) {
 			mm[m.name()] = m.value
 		}
 		tmm[tag] = mm
@@ -273,7 +274,7 @@ func newTaggedMetricMap(data map[string]interface{}) taggedMetricMap {
 // Nested keys are flattened into ordered slices associated with a metric value.
 // The key slices are treated as stacks, and are expected to be reversed and concatenated
 // when passed as metrics to the accumulator. (see (*metric).name())
-func flatten(data interface{}) []*metric {
+func (c *Ceph) flatten(data interface{}) []*metric {
 	var metrics []*metric
 
 	switch val := data.(type) {
@@ -282,13 +283,13 @@ func flatten(data interface{}) []*metric {
 	case map[string]interface{}:
 		metrics = make([]*metric, 0, len(val))
 		for k, v := range val {
-			for _, m := range flatten(v) {
+			for _, m := range c.flatten(v) {
 				m.pathStack = append(m.pathStack, k)
 				metrics = append(metrics, m)
 			}
 		}
 	default:
-		log.Printf("I! Ignoring unexpected type '%T' for value %v", val, val)
+		c.Log.Infof("Ignoring unexpected type '%T' for value %v", val, val)
 	}
 
 	return metrics
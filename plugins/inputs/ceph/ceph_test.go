@@ -24,14 +24,16 @@ func TestParseSockId(t *testing.T) {
 }
 
 func TestParseMonDump(t *testing.T) {
-	dump, err := parseDump(monPerfDump)
+	c := &Ceph{Log: testutil.Logger{}}
+	dump, err := c.parseDump(monPerfDump)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, int64(5678670180), dump["cluster"]["osd_kb_used"], epsilon)
 	assert.InEpsilon(t, 6866.540527000, dump["paxos"]["store_state_latency.sum"], epsilon)
 }
 
 func TestParseOsdDump(t *testing.T) {
-	dump, err := parseDump(osdPerfDump)
+	c := &Ceph{Log: testutil.Logger{}}
+	dump, err := c.parseDump(osdPerfDump)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 552132.109360000, dump["filestore"]["commitcycle_interval.sum"], epsilon)
 	assert.Equal(t, float64(0), dump["mutex-FileJournal::finisher_lock"]["wait.avgcount"])
@@ -77,7 +79,7 @@ func TestGather(t *testing.T) {
 	}
 
 	acc := &testutil.Accumulator{}
-	c := &Ceph{}
+	c := &Ceph{Log: testutil.Logger{}}
 	c.Gather(acc)
 
 }
@@ -90,6 +92,7 @@ func TestFindSockets(t *testing.T) {
 		assert.NoError(t, err)
 	}()
 	c := &Ceph{
+		Log:                    testutil.Logger{},
 		CephBinary:             "foo",
 		OsdPrefix:              "ceph-osd",
 		MonPrefix:              "ceph-mon",
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -28,6 +27,10 @@ type Docker struct {
 	ContainerNames []string
 
 	GatherServices bool `toml:"gather_services"`
+	GatherStopped  bool `toml:"gather_stopped"`
+
+	GatherEvents bool     `toml:"gather_events"`
+	EventActions []string `toml:"event_actions"`
 
 	Timeout        internal.Duration
 	PerDevice      bool     `toml:"perdevice"`
@@ -44,6 +47,8 @@ type Docker struct {
 	SSLKey             string `toml:"ssl_key"`
 	InsecureSkipVerify bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	newEnvClient func() (Client, error)
 	newClient    func(string, *tls.Config) (Client, error)
 
@@ -53,6 +58,9 @@ type Docker struct {
 	filtersCreated  bool
 	labelFilter     filter.Filter
 	containerFilter filter.Filter
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 // KB, MB, GB, TB, PB...human friendly
@@ -79,6 +87,16 @@ var sampleConfig = `
   ## Set to true to collect Swarm metrics(desired_replicas, running_replicas)
   gather_services = false
 
+  ## Set to true to also report the status of stopped containers
+  gather_stopped = false
+
+  ## Set to true to add a metric for each container lifecycle event, such as
+  ## die, oom and restart, as they happen
+  gather_events = false
+  ## Container events to subscribe to, defaults to create, start, restart,
+  ## stop, die, kill, oom, pause, unpause, destroy and health_status
+  # event_actions = ["die", "oom", "restart", "health_status"]
+
   ## Only collect metrics for these containers, collect all if empty
   container_names = []
 
@@ -118,37 +136,8 @@ func (d *Docker) Description() string {
 func (d *Docker) SampleConfig() string { return sampleConfig }
 
 func (d *Docker) Gather(acc telegraf.Accumulator) error {
-	if d.client == nil {
-		var c Client
-		var err error
-		if d.Endpoint == "ENV" {
-			c, err = d.newEnvClient()
-		} else {
-			tlsConfig, err := internal.GetTLSConfig(
-				d.SSLCert, d.SSLKey, d.SSLCA, d.InsecureSkipVerify)
-			if err != nil {
-				return err
-			}
-
-			c, err = d.newClient(d.Endpoint, tlsConfig)
-		}
-		if err != nil {
-			return err
-		}
-		d.client = c
-	}
-
-	// Create label filters if not already created
-	if !d.filtersCreated {
-		err := d.createLabelFilters()
-		if err != nil {
-			return err
-		}
-		err = d.createContainerFilters()
-		if err != nil {
-			return err
-		}
-		d.filtersCreated = true
+	if err := d.initialize(); err != nil {
+		return err
 	}
 
 	// Get daemon info
@@ -164,8 +153,9 @@ func (d *Docker) Gather(acc telegraf.Accumulator) error {
 		}
 	}
 
-	// List containers
-	opts := types.ContainerListOptions{}
+	// List containers, including the stopped containers which only report
+	// their status if requested
+	opts := types.ContainerListOptions{All: d.GatherStopped}
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
 	defer cancel()
 	containers, err := d.client.ContainerList(ctx, opts)
@@ -191,6 +181,44 @@ func (d *Docker) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
+// initialize creates the docker client and the filters, if not already
+// created.
+func (d *Docker) initialize() error {
+	if d.client == nil {
+		var c Client
+		var err error
+		if d.Endpoint == "ENV" {
+			c, err = d.newEnvClient()
+		} else {
+			tlsConfig, err := internal.GetTLSConfig(
+				d.SSLCert, d.SSLKey, d.SSLCA, d.InsecureSkipVerify)
+			if err != nil {
+				return err
+			}
+
+			c, err = d.newClient(d.Endpoint, tlsConfig)
+		}
+		if err != nil {
+			return err
+		}
+		d.client = c
+	}
+
+	// Create label filters if not already created
+	if !d.filtersCreated {
+		err := d.createLabelFilters()
+		if err != nil {
+			return err
+		}
+		err = d.createContainerFilters()
+		if err != nil {
+			return err
+		}
+		d.filtersCreated = true
+	}
+	return nil
+}
+
 func (d *Docker) gatherSwarmInfo(acc telegraf.Accumulator) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
@@ -247,7 +275,7 @@ func (d *Docker) gatherSwarmInfo(acc telegraf.Accumulator) error {
 				fields["tasks_running"] = running[service.ID]
 				fields["tasks_desired"] = tasksNoShutdown[service.ID]
 			} else {
-				log.Printf("E! Unknow Replicas Mode")
+				d.Log.Errorf("Unknow Replicas Mode")
 			}
 			// Add metrics
 			acc.AddFields("docker_swarm",
@@ -345,17 +373,7 @@ func (d *Docker) gatherContainer(
 		cname = strings.TrimPrefix(container.Names[0], "/")
 	}
 
-	// the image name sometimes has a version part, or a private repo
-	//   ie, rabbitmq:3-management or docker.someco.net:4443/rabbitmq:3-management
-	imageName := ""
-	imageVersion := "unknown"
-	i := strings.LastIndex(container.Image, ":") // index of last ':' character
-	if i > -1 {
-		imageVersion = container.Image[i+1:]
-		imageName = container.Image[:i]
-	} else {
-		imageName = container.Image
-	}
+	imageName, imageVersion := parseImage(container.Image)
 
 	tags := map[string]string{
 		"engine_host":       d.engine_host,
@@ -370,19 +388,6 @@ func (d *Docker) gatherContainer(
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
 	defer cancel()
-	r, err := d.client.ContainerStats(ctx, container.ID, false)
-	if err != nil {
-		return fmt.Errorf("Error getting docker stats: %s", err.Error())
-	}
-	defer r.Body.Close()
-	dec := json.NewDecoder(r.Body)
-	if err = dec.Decode(&v); err != nil {
-		if err == io.EOF {
-			return nil
-		}
-		return fmt.Errorf("Error decoding: %s", err.Error())
-	}
-	daemonOSType := r.OSType
 
 	// Add labels to tags
 	for k, label := range container.Labels {
@@ -417,6 +422,44 @@ func (d *Docker) gatherContainer(
 		acc.AddFields("docker_container_health", healthfields, tags, time.Now())
 	}
 
+	if info.State != nil {
+		statustags := copyTags(tags)
+		statustags["container_status"] = info.State.Status
+		statusfields := map[string]interface{}{
+			"oomkilled":     info.State.OOMKilled,
+			"pid":           info.State.Pid,
+			"exitcode":      info.State.ExitCode,
+			"restart_count": info.RestartCount,
+			"container_id":  container.ID,
+		}
+		if startedAt, ok := parseStateTime(info.State.StartedAt); ok {
+			statusfields["started_at"] = startedAt.UnixNano()
+		}
+		if finishedAt, ok := parseStateTime(info.State.FinishedAt); ok {
+			statusfields["finished_at"] = finishedAt.UnixNano()
+		}
+		acc.AddFields("docker_container_status", statusfields, statustags, time.Now())
+
+		// stopped containers have no stats
+		if !info.State.Running && !info.State.Paused {
+			return nil
+		}
+	}
+
+	r, err := d.client.ContainerStats(ctx, container.ID, false)
+	if err != nil {
+		return fmt.Errorf("Error getting docker stats: %s", err.Error())
+	}
+	defer r.Body.Close()
+	dec := json.NewDecoder(r.Body)
+	if err = dec.Decode(&v); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return fmt.Errorf("Error decoding: %s", err.Error())
+	}
+	daemonOSType := r.OSType
+
 	gatherContainerStats(v, acc, tags, container.ID, d.PerDevice, d.Total, daemonOSType)
 
 	return nil
@@ -707,6 +750,32 @@ func gatherBlockIOMetrics(
 	}
 }
 
+// parseImage splits an image into its name and version. The image name
+// sometimes has a version part, or a private repo, ie, rabbitmq:3-management
+// or docker.someco.net:4443/rabbitmq:3-management.
+func parseImage(image string) (string, string) {
+	imageName := ""
+	imageVersion := "unknown"
+	i := strings.LastIndex(image, ":") // index of last ':' character
+	if i > -1 {
+		imageVersion = image[i+1:]
+		imageName = image[:i]
+	} else {
+		imageName = image
+	}
+	return imageName, imageVersion
+}
+
+// parseStateTime parses the time a container started or finished at, which is
+// the zero time if it never did.
+func parseStateTime(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil || t.IsZero() {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
 func copyTags(in map[string]string) map[string]string {
 	out := make(map[string]string)
 	for k, v := range in {
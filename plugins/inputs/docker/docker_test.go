@@ -3,11 +3,16 @@ package docker
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/testutil"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +25,7 @@ type MockClient struct {
 	ServiceListF      func(ctx context.Context, options types.ServiceListOptions) ([]swarm.Service, error)
 	TaskListF         func(ctx context.Context, options types.TaskListOptions) ([]swarm.Task, error)
 	NodeListF         func(ctx context.Context, options types.NodeListOptions) ([]swarm.Node, error)
+	EventsF           func(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error)
 }
 
 func (c *MockClient) Info(ctx context.Context) (types.Info, error) {
@@ -69,6 +75,13 @@ func (c *MockClient) NodeList(
 	return c.NodeListF(ctx, options)
 }
 
+func (c *MockClient) Events(
+	ctx context.Context,
+	options types.EventsOptions,
+) (<-chan events.Message, <-chan error) {
+	return c.EventsF(ctx, options)
+}
+
 var baseClient = MockClient{
 	InfoF: func(context.Context) (types.Info, error) {
 		return info, nil
@@ -91,6 +104,13 @@ var baseClient = MockClient{
 	NodeListF: func(context.Context, types.NodeListOptions) ([]swarm.Node, error) {
 		return NodeList, nil
 	},
+	EventsF: func(context.Context, types.EventsOptions) (<-chan events.Message, <-chan error) {
+		msgs := make(chan events.Message, len(containerEvents))
+		for _, msg := range containerEvents {
+			msgs <- msg
+		}
+		return msgs, make(chan error)
+	},
 }
 
 func newClient(host string, tlsConfig *tls.Config) (Client, error) {
@@ -249,6 +269,7 @@ func TestDocker_WindowsMemoryContainerStats(t *testing.T) {
 	var acc testutil.Accumulator
 
 	d := Docker{
+		Log: testutil.Logger{},
 		newClient: func(string, *tls.Config) (Client, error) {
 			return &MockClient{
 				InfoF: func(ctx context.Context) (types.Info, error) {
@@ -401,6 +422,7 @@ func TestContainerLabels(t *testing.T) {
 			}
 
 			d := Docker{
+				Log:          testutil.Logger{},
 				newClient:    newClientFunc,
 				LabelInclude: tt.include,
 				LabelExclude: tt.exclude,
@@ -563,6 +585,7 @@ func TestContainerNames(t *testing.T) {
 			}
 
 			d := Docker{
+				Log:              testutil.Logger{},
 				newClient:        newClientFunc,
 				ContainerInclude: tt.include,
 				ContainerExclude: tt.exclude,
@@ -593,6 +616,7 @@ func TestContainerNames(t *testing.T) {
 func TestDockerGatherInfo(t *testing.T) {
 	var acc testutil.Accumulator
 	d := Docker{
+		Log:       testutil.Logger{},
 		newClient: newClient,
 		TagEnvironment: []string{"ENVVAR1", "ENVVAR2", "ENVVAR3", "ENVVAR5",
 			"ENVVAR6", "ENVVAR7", "ENVVAR8", "ENVVAR9"},
@@ -676,6 +700,7 @@ func TestDockerGatherInfo(t *testing.T) {
 func TestDockerGatherSwarmInfo(t *testing.T) {
 	var acc testutil.Accumulator
 	d := Docker{
+		Log:       testutil.Logger{},
 		newClient: newClient,
 	}
 
@@ -711,3 +736,194 @@ func TestDockerGatherSwarmInfo(t *testing.T) {
 		},
 	)
 }
+
+func TestDockerGatherContainerStatus(t *testing.T) {
+	var acc testutil.Accumulator
+	d := Docker{
+		Log:       testutil.Logger{},
+		newClient: newClient,
+	}
+
+	err := acc.GatherError(d.Gather)
+	require.NoError(t, err)
+
+	acc.AssertContainsTaggedFields(t,
+		"docker_container_status",
+		map[string]interface{}{
+			"oomkilled":     false,
+			"pid":           1234,
+			"exitcode":      0,
+			"restart_count": 2,
+			"started_at":    time.Date(2018, 6, 14, 5, 48, 53, 266176036, time.UTC).UnixNano(),
+			"container_id":  "b7dfbb9478a6ae55e237d4d74f8bbb753f0817192b5081334dc78476296e2173",
+		},
+		map[string]string{
+			"engine_host":       "absol",
+			"container_name":    "etcd2",
+			"container_image":   "quay.io:4443/coreos/etcd",
+			"container_version": "v2.2.2",
+			"container_status":  "running",
+			"label1":            "test_value_1",
+			"label2":            "test_value_2",
+		},
+	)
+}
+
+func TestDockerGatherStoppedContainerStatus(t *testing.T) {
+	var acc testutil.Accumulator
+	client := baseClient
+	client.ContainerListF = func(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+		if !options.All {
+			return nil, nil
+		}
+		return containerList[:1], nil
+	}
+	client.ContainerStatsF = func(context.Context, string, bool) (types.ContainerStats, error) {
+		return types.ContainerStats{}, errors.New("container is not running")
+	}
+	client.ContainerInspectF = func(context.Context, string) (types.ContainerJSON, error) {
+		return types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{
+					Status:     "exited",
+					OOMKilled:  true,
+					ExitCode:   137,
+					StartedAt:  "2018-06-14T05:48:53.266176036Z",
+					FinishedAt: "2018-06-14T05:53:53.266176036Z",
+				},
+			},
+		}, nil
+	}
+	d := Docker{
+		Log:           testutil.Logger{},
+		GatherStopped: true,
+		newClient:     func(string, *tls.Config) (Client, error) { return &client, nil },
+	}
+
+	require.NoError(t, acc.GatherError(d.Gather))
+
+	acc.AssertContainsTaggedFields(t,
+		"docker_container_status",
+		map[string]interface{}{
+			"oomkilled":     true,
+			"pid":           0,
+			"exitcode":      137,
+			"restart_count": 0,
+			"started_at":    time.Date(2018, 6, 14, 5, 48, 53, 266176036, time.UTC).UnixNano(),
+			"finished_at":   time.Date(2018, 6, 14, 5, 53, 53, 266176036, time.UTC).UnixNano(),
+			"container_id":  "e2173b9478a6ae55e237d4d74f8bbb753f0817192b5081334dc78476296b7dfb",
+		},
+		map[string]string{
+			"engine_host":       "absol",
+			"container_name":    "etcd",
+			"container_image":   "quay.io/coreos/etcd",
+			"container_version": "v2.2.2",
+			"container_status":  "exited",
+			"label1":            "test_value_1",
+			"label2":            "test_value_2",
+		},
+	)
+	// the stats of the stopped container are not gathered
+	require.False(t, acc.HasMeasurement("docker_container_mem"))
+}
+
+func TestDockerGatherStoppedContainersDisabled(t *testing.T) {
+	var acc testutil.Accumulator
+	var all bool
+	client := baseClient
+	client.ContainerListF = func(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+		all = options.All
+		return nil, nil
+	}
+	d := Docker{
+		Log:       testutil.Logger{},
+		newClient: func(string, *tls.Config) (Client, error) { return &client, nil },
+	}
+
+	require.NoError(t, acc.GatherError(d.Gather))
+	require.False(t, all)
+}
+
+func TestDockerGatherEvents(t *testing.T) {
+	var acc testutil.Accumulator
+	var opts types.EventsOptions
+	client := baseClient
+	client.EventsF = func(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+		opts = options
+		return baseClient.EventsF(ctx, options)
+	}
+	d := Docker{
+		Log: testutil.Logger{},
+		newClient: func(string, *tls.Config) (Client, error) {
+			return &client, nil
+		},
+		GatherEvents:     true,
+		EventActions:     []string{"oom", "die", "health_status"},
+		ContainerExclude: []string{"etcd2"},
+		Timeout:          internal.Duration{Duration: time.Second},
+	}
+
+	require.NoError(t, d.Start(&acc))
+	acc.Wait(2)
+	d.Stop()
+
+	require.Equal(t, []string{"container"}, opts.Filters.Get("type"))
+	actions := opts.Filters.Get("event")
+	sort.Strings(actions)
+	require.Equal(t, []string{"die", "health_status", "oom"}, actions)
+
+	tags := map[string]string{
+		"engine_host":       "absol",
+		"container_name":    "etcd",
+		"container_image":   "quay.io/coreos/etcd",
+		"container_version": "v2.2.2",
+		"label1":            "test_value_1",
+	}
+	tags["action"] = "oom"
+	acc.AssertContainsTaggedFields(t,
+		"docker_container_event",
+		map[string]interface{}{
+			"container_id": "e2173b9478a6",
+		},
+		copyTags(tags),
+	)
+	tags["action"] = "die"
+	acc.AssertContainsTaggedFields(t,
+		"docker_container_event",
+		map[string]interface{}{
+			"container_id": "e2173b9478a6",
+			"exitcode":     137,
+		},
+		copyTags(tags),
+	)
+	for _, m := range acc.Metrics {
+		if m.Tags["action"] == "die" {
+			require.Equal(t, time.Unix(0, 1529070000100000000), m.Time)
+		}
+	}
+	// the excluded container's health status is not reported
+	require.Equal(t, 2, len(acc.Metrics))
+}
+
+func TestDockerAddEventHealthStatus(t *testing.T) {
+	var acc testutil.Accumulator
+	d := Docker{Log: testutil.Logger{}}
+	require.NoError(t, d.createContainerFilters())
+	require.NoError(t, d.createLabelFilters())
+
+	d.addEvent(&acc, "absol", containerEvents[2])
+	acc.AssertContainsTaggedFields(t,
+		"docker_container_event",
+		map[string]interface{}{
+			"container_id":  "b7dfbb9478a6",
+			"health_status": "unhealthy",
+		},
+		map[string]string{
+			"engine_host":       "absol",
+			"container_name":    "etcd2",
+			"container_image":   "quay.io:4443/coreos/etcd",
+			"container_version": "v2.2.2",
+			"action":            "health_status",
+		},
+	)
+}
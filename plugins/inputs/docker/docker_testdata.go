@@ -7,6 +7,7 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/swarm"
 )
@@ -483,6 +484,56 @@ var containerInspect = types.ContainerJSON{
 				FailingStreak: 1,
 				Status:        "Unhealthy",
 			},
+			Status:     "running",
+			Running:    true,
+			OOMKilled:  false,
+			Pid:        1234,
+			ExitCode:   0,
+			StartedAt:  "2018-06-14T05:48:53.266176036Z",
+			FinishedAt: "0001-01-01T00:00:00Z",
 		},
+		RestartCount: 2,
+	},
+}
+
+var containerEvents = []events.Message{
+	{
+		Type:   "container",
+		Action: "oom",
+		Actor: events.Actor{
+			ID: "e2173b9478a6",
+			Attributes: map[string]string{
+				"image":  "quay.io/coreos/etcd:v2.2.2",
+				"name":   "etcd",
+				"label1": "test_value_1",
+			},
+		},
+		TimeNano: 1529070000000000000,
+	},
+	{
+		Type:   "container",
+		Action: "die",
+		Actor: events.Actor{
+			ID: "e2173b9478a6",
+			Attributes: map[string]string{
+				"exitCode": "137",
+				"image":    "quay.io/coreos/etcd:v2.2.2",
+				"name":     "etcd",
+				"label1":   "test_value_1",
+			},
+		},
+		TimeNano: 1529070000100000000,
+	},
+	{
+		Type:   "container",
+		Action: "health_status: unhealthy",
+		Actor: events.Actor{
+			ID: "b7dfbb9478a6",
+			Attributes: map[string]string{
+				"image": "quay.io:4443/coreos/etcd:v2.2.2",
+				"name":  "etcd2",
+			},
+		},
+		TimeNano: 1529070000200000000,
 	},
 }
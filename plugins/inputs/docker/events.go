@@ -0,0 +1,195 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/influxdata/telegraf"
+)
+
+// eventsRetryInterval is how long to wait before subscribing to the events
+// again when the stream fails
+const eventsRetryInterval = 5 * time.Second
+
+// the container lifecycle events subscribed to when event_actions is not set
+var defaultEventActions = []string{
+	"create",
+	"start",
+	"restart",
+	"stop",
+	"die",
+	"kill",
+	"oom",
+	"pause",
+	"unpause",
+	"destroy",
+	"health_status",
+}
+
+// event attributes which are not container labels
+var eventAttributes = map[string]bool{
+	"name":     true,
+	"image":    true,
+	"exitCode": true,
+	"signal":   true,
+	"execID":   true,
+}
+
+// Start subscribes to the container events of the docker daemon when
+// gather_events is set.
+func (d *Docker) Start(acc telegraf.Accumulator) error {
+	if !d.GatherEvents {
+		return nil
+	}
+	if err := d.initialize(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.streamEvents(ctx, acc)
+	}()
+	return nil
+}
+
+// Stop unsubscribes from the container events.
+func (d *Docker) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+		d.wg.Wait()
+	}
+}
+
+// streamEvents adds a metric for each container event until the context is
+// done, subscribing again from the last event received when the stream
+// fails.
+func (d *Docker) streamEvents(ctx context.Context, acc telegraf.Accumulator) {
+	since := time.Now()
+	for {
+		last, err := d.watchEvents(ctx, acc, since)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			acc.AddError(fmt.Errorf("error watching docker events: %s", err))
+		}
+		if !last.IsZero() {
+			since = last.Add(time.Nanosecond)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventsRetryInterval):
+		}
+	}
+}
+
+// watchEvents subscribes to the events since the given time, returning the
+// time of the last event received when the stream ends.
+func (d *Docker) watchEvents(
+	ctx context.Context,
+	acc telegraf.Accumulator,
+	since time.Time,
+) (time.Time, error) {
+	var last time.Time
+
+	infoCtx, cancel := context.WithTimeout(ctx, d.Timeout.Duration)
+	info, err := d.client.Info(infoCtx)
+	cancel()
+	if err != nil {
+		return last, err
+	}
+
+	actions := d.EventActions
+	if len(actions) == 0 {
+		actions = defaultEventActions
+	}
+	args := filters.NewArgs()
+	args.Add("type", "container")
+	for _, action := range actions {
+		args.Add("event", action)
+	}
+	opts := types.EventsOptions{
+		Since:   fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond()),
+		Filters: args,
+	}
+
+	msgs, errs := d.client.Events(ctx, opts)
+	for {
+		select {
+		case <-ctx.Done():
+			return last, nil
+		case err := <-errs:
+			return last, err
+		case msg, ok := <-msgs:
+			if !ok {
+				return last, nil
+			}
+			last = time.Unix(0, msg.TimeNano)
+			d.addEvent(acc, info.Name, msg)
+		}
+	}
+}
+
+// addEvent adds the metric of a container event.
+func (d *Docker) addEvent(acc telegraf.Accumulator, engineHost string, msg events.Message) {
+	cname := msg.Actor.Attributes["name"]
+	if !d.containerFilter.Match(cname) {
+		return
+	}
+	imageName, imageVersion := parseImage(msg.Actor.Attributes["image"])
+
+	// actions like health_status carry their value, "health_status: healthy"
+	action := msg.Action
+	var value string
+	if i := strings.Index(action, ":"); i >= 0 {
+		value = strings.TrimSpace(action[i+1:])
+		action = action[:i]
+	}
+
+	tags := map[string]string{
+		"engine_host":       engineHost,
+		"container_name":    cname,
+		"container_image":   imageName,
+		"container_version": imageVersion,
+		"action":            action,
+	}
+	for k, v := range msg.Actor.Attributes {
+		if !eventAttributes[k] && d.labelFilter.Match(k) {
+			tags[k] = v
+		}
+	}
+
+	fields := map[string]interface{}{
+		"container_id": msg.Actor.ID,
+	}
+	if action == "health_status" && value != "" {
+		fields["health_status"] = value
+	}
+	if v, ok := msg.Actor.Attributes["exitCode"]; ok {
+		if code, err := strconv.Atoi(v); err == nil {
+			fields["exitcode"] = code
+		}
+	}
+	if v, ok := msg.Actor.Attributes["signal"]; ok {
+		if signal, err := strconv.Atoi(v); err == nil {
+			fields["signal"] = signal
+		}
+	}
+
+	tm := time.Unix(0, msg.TimeNano)
+	if msg.TimeNano == 0 {
+		tm = time.Now()
+	}
+	acc.AddFields("docker_container_event", fields, tags, tm)
+}
@@ -2,6 +2,7 @@ package exec
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -59,7 +60,7 @@ func NewExec() *Exec {
 }
 
 type Runner interface {
-	Run(*Exec, string, telegraf.Accumulator) ([]byte, error)
+	Run(context.Context, *Exec, string, telegraf.Accumulator) ([]byte, error)
 }
 
 type CommandRunner struct{}
@@ -85,6 +86,7 @@ func AddNagiosState(exitCode error, acc telegraf.Accumulator) error {
 }
 
 func (c CommandRunner) Run(
+	ctx context.Context,
 	e *Exec,
 	command string,
 	acc telegraf.Accumulator,
@@ -94,12 +96,15 @@ func (c CommandRunner) Run(
 		return nil, fmt.Errorf("exec: unable to parse command, %s", err)
 	}
 
-	cmd := exec.Command(split_cmd[0], split_cmd[1:]...)
+	cmd := exec.CommandContext(ctx, split_cmd[0], split_cmd[1:]...)
 
 	var out bytes.Buffer
 	cmd.Stdout = &out
 
 	if err := internal.RunTimeout(cmd, e.Timeout.Duration); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("exec: %s for command '%s'", ctx.Err(), command)
+		}
 		switch e.parser.(type) {
 		case *nagios.NagiosParser:
 			AddNagiosState(err, acc)
@@ -143,10 +148,15 @@ func removeCarriageReturns(b bytes.Buffer) bytes.Buffer {
 
 }
 
-func (e *Exec) ProcessCommand(command string, acc telegraf.Accumulator, wg *sync.WaitGroup) {
+func (e *Exec) ProcessCommand(
+	ctx context.Context,
+	command string,
+	acc telegraf.Accumulator,
+	wg *sync.WaitGroup,
+) {
 	defer wg.Done()
 
-	out, err := e.runner.Run(e, command, acc)
+	out, err := e.runner.Run(ctx, e, command, acc)
 	if err != nil {
 		acc.AddError(err)
 		return
@@ -175,6 +185,12 @@ func (e *Exec) SetParser(parser parsers.Parser) {
 }
 
 func (e *Exec) Gather(acc telegraf.Accumulator) error {
+	return e.GatherContext(context.Background(), acc)
+}
+
+// GatherContext runs all commands, killing those still running once ctx is
+// done.
+func (e *Exec) GatherContext(ctx context.Context, acc telegraf.Accumulator) error {
 	var wg sync.WaitGroup
 	// Legacy single command support
 	if e.Command != "" {
@@ -215,7 +231,7 @@ func (e *Exec) Gather(acc telegraf.Accumulator) error {
 
 	wg.Add(len(commands))
 	for _, command := range commands {
-		go e.ProcessCommand(command, acc, &wg)
+		go e.ProcessCommand(ctx, command, acc, &wg)
 	}
 	wg.Wait()
 	return nil
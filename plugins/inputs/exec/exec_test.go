@@ -2,9 +2,11 @@ package exec
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/parsers"
@@ -85,7 +87,7 @@ func newRunnerMock(out []byte, err error) Runner {
 	}
 }
 
-func (r runnerMock) Run(e *Exec, command string, acc telegraf.Accumulator) ([]byte, error) {
+func (r runnerMock) Run(ctx context.Context, e *Exec, command string, acc telegraf.Accumulator) ([]byte, error) {
 	if r.err != nil {
 		return nil, r.err
 	}
@@ -269,6 +271,26 @@ func TestExecCommandWithoutGlobAndPath(t *testing.T) {
 	acc.AssertContainsFields(t, "metric", fields)
 }
 
+func TestExecGatherContextCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows")
+	}
+	parser, _ := parsers.NewValueParser("metric", "string", nil)
+	e := NewExec()
+	e.Commands = []string{"sleep 10"}
+	e.SetParser(parser)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var acc testutil.Accumulator
+	start := time.Now()
+	require.NoError(t, e.GatherContext(ctx, &acc))
+	assert.True(t, time.Since(start) < 5*time.Second)
+	require.Len(t, acc.Errors, 1)
+	assert.Contains(t, acc.Errors[0].Error(), "context deadline exceeded")
+}
+
 func TestRemoveCarriageReturns(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		// Test that all carriage returns are removed
@@ -0,0 +1,142 @@
+package execd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/internal/process"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/influxdata/telegraf/plugins/parsers"
+)
+
+const sampleConfig = `
+  ## Program to run as daemon, followed by its arguments.
+  command = ["telegraf-smartctl", "-d", "/dev/sda"]
+
+  ## Define how the process is signaled on each collection interval.
+  ## Valid values are:
+  ##   "none"    : Do not signal anything.
+  ##               The process must output metrics by itself.
+  ##   "STDIN"   : Send a newline on STDIN.
+  ##   "SIGHUP"  : Send a HUP signal. Not available on Windows.
+  ##   "SIGUSR1" : Send a USR1 signal. Not available on Windows.
+  ##   "SIGUSR2" : Send a USR2 signal. Not available on Windows.
+  signal = "none"
+
+  ## Delay before the process is restarted after an unexpected termination.
+  ## The delay doubles on each consecutive failure, up to 5 minutes.
+  restart_delay = "10s"
+
+  ## Data format to consume.
+  ## Each data format has its own unique set of configuration options, read
+  ## more about them here:
+  ## https://github.com/influxdata/telegraf/blob/master/docs/DATA_FORMATS_INPUT.md
+  data_format = "influx"
+`
+
+type Execd struct {
+	Command      []string
+	Signal       string
+	RestartDelay internal.Duration
+
+	Log telegraf.Logger `toml:"-"`
+
+	acc     telegraf.Accumulator
+	parser  parsers.Parser
+	process *process.Process
+}
+
+func NewExecd() *Execd {
+	return &Execd{
+		Signal:       "none",
+		RestartDelay: internal.Duration{Duration: process.DefaultRestartDelay},
+	}
+}
+
+func (e *Execd) SampleConfig() string {
+	return sampleConfig
+}
+
+func (e *Execd) Description() string {
+	return "Run executable as long-running input plugin"
+}
+
+func (e *Execd) SetParser(parser parsers.Parser) {
+	e.parser = parser
+}
+
+func (e *Execd) Start(acc telegraf.Accumulator) error {
+	if e.Signal != "none" && e.Signal != "STDIN" {
+		if _, err := parseSignal(e.Signal); err != nil {
+			return err
+		}
+	}
+
+	e.acc = acc
+
+	var err error
+	e.process, err = process.New(e.Command, e.Log)
+	if err != nil {
+		return fmt.Errorf("error creating process: %s", err)
+	}
+	e.process.RestartDelay = e.RestartDelay.Duration
+	e.process.ReadStdout = e.cmdReadOut
+
+	if err := e.process.Start(); err != nil {
+		return fmt.Errorf("failed to start process %s: %s", e.Command, err)
+	}
+	return nil
+}
+
+func (e *Execd) Stop() {
+	e.process.Stop()
+}
+
+// Gather signals the process to output its metrics, the metrics themselves
+// are read continuously from its stdout.
+func (e *Execd) Gather(acc telegraf.Accumulator) error {
+	switch e.Signal {
+	case "none":
+		return nil
+	case "STDIN":
+		if _, err := e.process.Write([]byte{'\n'}); err != nil {
+			return fmt.Errorf("error writing to stdin: %s", err)
+		}
+		return nil
+	}
+
+	sig, err := parseSignal(e.Signal)
+	if err != nil {
+		return err
+	}
+	if err := e.process.Signal(sig); err != nil {
+		return fmt.Errorf("error sending %s: %s", e.Signal, err)
+	}
+	return nil
+}
+
+func (e *Execd) cmdReadOut(out io.Reader) {
+	scanner := process.NewScanner(out)
+	for scanner.Scan() {
+		metrics, err := e.parser.Parse(scanner.Bytes())
+		if err != nil {
+			e.acc.AddError(fmt.Errorf("parse error: %s", err))
+		}
+
+		for _, m := range metrics {
+			e.acc.AddFields(m.Name(), m.Fields(), m.Tags(), m.Time())
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		e.acc.AddError(fmt.Errorf("error reading stdout: %s", err))
+	}
+}
+
+func init() {
+	inputs.Add("execd", func() telegraf.Input {
+		return NewExecd()
+	})
+}
@@ -0,0 +1,83 @@
+// +build !windows
+
+package execd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/plugins/parsers"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const counterScript = `
+count=0
+while read line; do
+	count=$((count+1))
+	echo "counter,source=stdin count=${count}i"
+done
+`
+
+func newTestExecd(t *testing.T, command []string, signal string) *Execd {
+	parser, err := parsers.NewInfluxParser()
+	require.NoError(t, err)
+
+	e := NewExecd()
+	e.Command = command
+	e.Signal = signal
+	e.RestartDelay = internal.Duration{Duration: 10 * time.Millisecond}
+	e.SetParser(parser)
+	e.Log = testutil.Logger{}
+	return e
+}
+
+func TestSignalStdin(t *testing.T) {
+	e := newTestExecd(t, []string{"sh", "-c", counterScript}, "STDIN")
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Start(acc))
+	defer e.Stop()
+
+	require.NoError(t, e.Gather(acc))
+	acc.Wait(1)
+	require.NoError(t, e.Gather(acc))
+	acc.Wait(2)
+
+	require.Len(t, acc.Metrics, 2)
+	assert.Equal(t, "counter", acc.Metrics[1].Measurement)
+	assert.Equal(t, map[string]interface{}{"count": int64(2)}, acc.Metrics[1].Fields)
+	assert.Equal(t, map[string]string{"source": "stdin"}, acc.Metrics[1].Tags)
+}
+
+func TestSignalHUP(t *testing.T) {
+	script := `trap 'echo "hup value=1i"' HUP; echo ready; while read line; do :; done`
+	e := newTestExecd(t, []string{"sh", "-c", script}, "SIGHUP")
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Start(acc))
+	defer e.Stop()
+
+	// "ready" is not valid line protocol, the parse error tells us that the
+	// trap has been installed
+	acc.WaitError(1)
+	require.NoError(t, e.Gather(acc))
+	acc.Wait(1)
+	assert.True(t, acc.HasInt64Field("hup", "value"))
+}
+
+func TestRestart(t *testing.T) {
+	e := newTestExecd(t, []string{"sh", "-c", `echo "restart value=1i"`}, "none")
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Start(acc))
+	acc.Wait(3)
+	e.Stop()
+}
+
+func TestInvalidSignal(t *testing.T) {
+	e := newTestExecd(t, []string{"cat"}, "SIGFOO")
+	assert.Error(t, e.Start(&testutil.Accumulator{}))
+}
@@ -0,0 +1,12 @@
+// +build windows
+
+package execd
+
+import (
+	"fmt"
+	"os"
+)
+
+func parseSignal(name string) (os.Signal, error) {
+	return nil, fmt.Errorf("signal %q is not supported on Windows", name)
+}
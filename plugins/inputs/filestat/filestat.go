@@ -4,7 +4,6 @@ import (
 	"crypto/md5"
 	"fmt"
 	"io"
-	"log"
 	"os"
 
 	"github.com/influxdata/telegraf"
@@ -31,6 +30,8 @@ type FileStat struct {
 	Md5   bool
 	Files []string
 
+	Log telegraf.Logger `toml:"-"`
+
 	// maps full file paths to globmatch obj
 	globs map[string]*globpath.GlobPath
 }
@@ -82,7 +83,7 @@ func (f *FileStat) Gather(acc telegraf.Accumulator) error {
 			}
 
 			if fileInfo == nil {
-				log.Printf("E! Unable to get info for file [%s], possible permissions issue",
+				f.Log.Errorf("Unable to get info for file [%s], possible permissions issue",
 					fileName)
 			} else {
 				fields["size_bytes"] = fileInfo.Size()
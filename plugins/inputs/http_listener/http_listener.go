@@ -3,19 +3,22 @@ package http_listener
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/subtle"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"io"
 	"io/ioutil"
-	"log"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/influxdata/telegraf/plugins/parsers"
 	"github.com/influxdata/telegraf/plugins/parsers/influx"
 	"github.com/influxdata/telegraf/selfstat"
 )
@@ -30,6 +33,14 @@ const (
 	// a single InfluxDB point.
 	// 64 KB
 	DEFAULT_MAX_LINE_SIZE = 64 * 1024
+
+	// V2_WRITE_PATH is the path of the InfluxDB v2 write API
+	V2_WRITE_PATH = "/api/v2/write"
+)
+
+var (
+	defaultPaths   = []string{"/write"}
+	defaultMethods = []string{"POST", "PUT"}
 )
 
 type HTTPListener struct {
@@ -40,6 +51,19 @@ type HTTPListener struct {
 	MaxLineSize    int
 	Port           int
 
+	Paths   []string `toml:"paths"`
+	Methods []string `toml:"methods"`
+
+	BasicUsername string   `toml:"basic_username"`
+	BasicPassword string   `toml:"basic_password"`
+	BearerTokens  []string `toml:"bearer_tokens"`
+
+	BucketTag string `toml:"bucket_tag"`
+	OrgTag    string `toml:"org_tag"`
+
+	HTTPHeaderTags map[string]string `toml:"http_header_tags"`
+	ClientIPTag    string            `toml:"client_ip_tag"`
+
 	TlsAllowedCacerts []string
 	TlsCert           string
 	TlsKey            string
@@ -49,7 +73,7 @@ type HTTPListener struct {
 
 	listener net.Listener
 
-	parser influx.InfluxParser
+	parser parsers.Parser
 	acc    telegraf.Accumulator
 	pool   *pool
 
@@ -63,7 +87,10 @@ type HTTPListener struct {
 	QueriesRecv     selfstat.Stat
 	PingsRecv       selfstat.Stat
 	NotFoundsServed selfstat.Stat
+	AuthFailures    selfstat.Stat
 	BuffersCreated  selfstat.Stat
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 const sampleConfig = `
@@ -83,6 +110,29 @@ const sampleConfig = `
   ## 0 means to use the default of 65536 bytes (64 kibibytes)
   max_line_size = 0
 
+  ## Paths to accept writes on, the /query and /ping endpoints are always
+  ## served.
+  # paths = ["/write"]
+
+  ## HTTP methods to accept writes with, others get a 405 response.
+  # methods = ["POST", "PUT"]
+
+  ## Optional username and password to accept for HTTP basic authentication.
+  ## You probably want to make sure you have TLS configured below for this.
+  # basic_username = "foobar"
+  # basic_password = "barfoo"
+
+  ## Optional tokens to accept in an "Authorization: Bearer <token>" header,
+  ## or an "Authorization: Token <token>" header as sent by InfluxDB v2
+  ## clients. When both basic authentication and tokens are set, either is
+  ## accepted.
+  # bearer_tokens = ["my-secret-token"]
+
+  ## Optional tags to add the bucket and organization of the writes to the
+  ## /api/v2/write endpoint as.
+  # bucket_tag = "bucket"
+  # org_tag = "org"
+
   ## Set one or more allowed client CA certificate file names to 
   ## enable mutually authenticated TLS connections
   tls_allowed_cacerts = ["/etc/telegraf/clientca.pem"]
@@ -90,6 +140,22 @@ const sampleConfig = `
   ## Add service certificate and key
   tls_cert = "/etc/telegraf/cert.pem"
   tls_key = "/etc/telegraf/key.pem"
+
+  ## Optional tag to add the IP address of the client to the metrics as.
+  # client_ip_tag = "source_ip"
+
+  ## Data format to consume.
+  ## Each data format has its own unique set of configuration options, read
+  ## more about them here:
+  ## https://github.com/influxdata/telegraf/blob/master/docs/DATA_FORMATS_INPUT.md
+  ## Only the influx data format supports the precision query parameter and
+  ## max_line_size, the other formats read the whole request body.
+  data_format = "influx"
+
+  ## Optional request headers to add as tags to the metrics, with the tag
+  ## name they are added as.
+  # [inputs.http_listener.http_header_tags]
+  #   X-Source = "source"
 `
 
 func (h *HTTPListener) SampleConfig() string {
@@ -100,6 +166,10 @@ func (h *HTTPListener) Description() string {
 	return "Influx HTTP write listener"
 }
 
+func (h *HTTPListener) SetParser(parser parsers.Parser) {
+	h.parser = parser
+}
+
 func (h *HTTPListener) Gather(_ telegraf.Accumulator) error {
 	h.BuffersCreated.Set(h.pool.ncreated())
 	return nil
@@ -123,6 +193,7 @@ func (h *HTTPListener) Start(acc telegraf.Accumulator) error {
 	h.QueriesRecv = selfstat.Register("http_listener", "queries_received", tags)
 	h.PingsRecv = selfstat.Register("http_listener", "pings_received", tags)
 	h.NotFoundsServed = selfstat.Register("http_listener", "not_founds_served", tags)
+	h.AuthFailures = selfstat.Register("http_listener", "auth_failures", tags)
 	h.BuffersCreated = selfstat.Register("http_listener", "buffers_created", tags)
 
 	if h.MaxBodySize == 0 {
@@ -138,6 +209,15 @@ func (h *HTTPListener) Start(acc telegraf.Accumulator) error {
 	if h.WriteTimeout.Duration < time.Second {
 		h.WriteTimeout.Duration = time.Second * 10
 	}
+	if len(h.Paths) == 0 {
+		h.Paths = defaultPaths
+	}
+	if len(h.Methods) == 0 {
+		h.Methods = defaultMethods
+	}
+	if h.parser == nil {
+		h.parser = &influx.InfluxParser{}
+	}
 
 	h.acc = acc
 	h.pool = NewPool(200, h.MaxLineSize)
@@ -171,7 +251,7 @@ func (h *HTTPListener) Start(acc telegraf.Accumulator) error {
 		server.Serve(h.listener)
 	}()
 
-	log.Printf("I! Started HTTP listener service on %s\n", h.ServiceAddress)
+	h.Log.Infof("Started HTTP listener service on %s", h.ServiceAddress)
 
 	return nil
 }
@@ -184,27 +264,51 @@ func (h *HTTPListener) Stop() {
 	h.listener.Close()
 	h.wg.Wait()
 
-	log.Println("I! Stopped HTTP listener service on ", h.ServiceAddress)
+	h.Log.Infof("Stopped HTTP listener service on %s", h.ServiceAddress)
 }
 
 func (h *HTTPListener) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	h.RequestsRecv.Incr(1)
 	defer h.RequestsServed.Incr(1)
-	switch req.URL.Path {
-	case "/write":
+	switch {
+	case h.isWritePath(req.URL.Path):
 		h.WritesRecv.Incr(1)
 		defer h.WritesServed.Incr(1)
-		h.serveWrite(res, req)
-	case "/query":
+		if !h.allowedMethod(req.Method) {
+			res.Header().Set("Allow", strings.Join(h.Methods, ", "))
+			http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !h.authorized(res, req) {
+			return
+		}
+		tags := h.requestTags(req)
+		h.serveWrite(res, req, h.parser, req.URL.Query().Get("precision"), tags)
+	case req.URL.Path == V2_WRITE_PATH:
+		h.WritesRecv.Incr(1)
+		defer h.WritesServed.Incr(1)
+		if req.Method != "POST" {
+			res.Header().Set("Allow", "POST")
+			v2Error(res, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		if !h.authorized(res, req) {
+			return
+		}
+		h.serveWriteV2(res, req)
+	case req.URL.Path == "/query":
 		h.QueriesRecv.Incr(1)
 		defer h.QueriesServed.Incr(1)
+		if !h.authorized(res, req) {
+			return
+		}
 		// Deliver a dummy response to the query endpoint, as some InfluxDB
 		// clients test endpoint availability with a query
 		res.Header().Set("Content-Type", "application/json")
 		res.Header().Set("X-Influxdb-Version", "1.0")
 		res.WriteHeader(http.StatusOK)
 		res.Write([]byte("{\"results\":[]}"))
-	case "/ping":
+	case req.URL.Path == "/ping":
 		h.PingsRecv.Incr(1)
 		defer h.PingsServed.Incr(1)
 		// respond to ping requests
@@ -216,7 +320,109 @@ func (h *HTTPListener) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func (h *HTTPListener) serveWrite(res http.ResponseWriter, req *http.Request) {
+func (h *HTTPListener) isWritePath(path string) bool {
+	for _, p := range h.Paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *HTTPListener) allowedMethod(method string) bool {
+	for _, m := range h.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// authorized checks the basic authentication credentials or bearer token of
+// the request if any are configured, responding with a 401 if they are
+// missing or wrong.
+func (h *HTTPListener) authorized(res http.ResponseWriter, req *http.Request) bool {
+	basicAuth := h.BasicUsername != "" || h.BasicPassword != ""
+	if !basicAuth && len(h.BearerTokens) == 0 {
+		return true
+	}
+
+	if basicAuth {
+		username, password, ok := req.BasicAuth()
+		if ok &&
+			subtle.ConstantTimeCompare([]byte(username), []byte(h.BasicUsername)) == 1 &&
+			subtle.ConstantTimeCompare([]byte(password), []byte(h.BasicPassword)) == 1 {
+			return true
+		}
+	}
+
+	auth := req.Header.Get("Authorization")
+	for _, scheme := range []string{"Bearer ", "Token "} {
+		if len(auth) > len(scheme) && strings.EqualFold(auth[:len(scheme)], scheme) &&
+			h.validToken(auth[len(scheme):]) {
+			return true
+		}
+	}
+
+	h.AuthFailures.Incr(1)
+	if basicAuth {
+		res.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
+	} else {
+		res.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	http.Error(res, "Unauthorized", http.StatusUnauthorized)
+	return false
+}
+
+func (h *HTTPListener) validToken(token string) bool {
+	valid := false
+	for _, t := range h.BearerTokens {
+		// compare against every token so the time taken does not tell which
+		// one matched
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// serveWriteV2 serves the InfluxDB v2 write API, whose body is always in line
+// protocol.
+func (h *HTTPListener) serveWriteV2(res http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	bucket := query.Get("bucket")
+	if bucket == "" {
+		v2Error(res, http.StatusBadRequest, "bucket is required")
+		return
+	}
+
+	// v2 precisions are ns, us, ms and s, us being u in v1
+	precision := query.Get("precision")
+	switch precision {
+	case "", "ns", "ms", "s":
+	case "us":
+		precision = "u"
+	default:
+		v2Error(res, http.StatusBadRequest, "invalid precision "+precision)
+		return
+	}
+
+	tags := h.requestTags(req)
+	if h.BucketTag != "" {
+		tags[h.BucketTag] = bucket
+	}
+	if org := query.Get("org"); h.OrgTag != "" && org != "" {
+		tags[h.OrgTag] = org
+	}
+
+	parser, ok := h.parser.(*influx.InfluxParser)
+	if !ok {
+		parser = &influx.InfluxParser{}
+	}
+	h.serveWrite(res, req, parser, precision, tags)
+}
+
+func (h *HTTPListener) serveWrite(res http.ResponseWriter, req *http.Request, parser parsers.Parser, precision string, tags map[string]string) {
 	// Check that the content length is not too large for us to handle.
 	if req.ContentLength > h.MaxBodySize {
 		tooLarge(res)
@@ -224,22 +430,26 @@ func (h *HTTPListener) serveWrite(res http.ResponseWriter, req *http.Request) {
 	}
 	now := time.Now()
 
-	precision := req.URL.Query().Get("precision")
-
 	// Handle gzip request bodies
 	body := req.Body
 	if req.Header.Get("Content-Encoding") == "gzip" {
-		var err error
-		body, err = gzip.NewReader(req.Body)
-		defer body.Close()
+		gz, err := gzip.NewReader(req.Body)
 		if err != nil {
-			log.Println("E! " + err.Error())
+			h.Log.Error(err.Error())
 			badRequest(res)
 			return
 		}
+		defer gz.Close()
+		body = gz
 	}
 	body = http.MaxBytesReader(res, body, h.MaxBodySize)
 
+	influxParser, ok := parser.(*influx.InfluxParser)
+	if !ok {
+		h.serveWholeBody(res, body, parser, tags)
+		return
+	}
+
 	var return400 bool
 	var hangingBytes bool
 	buf := h.pool.get()
@@ -248,7 +458,7 @@ func (h *HTTPListener) serveWrite(res http.ResponseWriter, req *http.Request) {
 	for {
 		n, err := io.ReadFull(body, buf[bufStart:])
 		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
-			log.Println("E! " + err.Error())
+			h.Log.Error(err.Error())
 			// problem reading the request body
 			badRequest(res)
 			return
@@ -282,8 +492,8 @@ func (h *HTTPListener) serveWrite(res http.ResponseWriter, req *http.Request) {
 
 		if err == io.ErrUnexpectedEOF {
 			// finished reading the request body
-			if err := h.parse(buf[:n+bufStart], now, precision); err != nil {
-				log.Println("E! " + err.Error())
+			if err := h.parse(influxParser, buf[:n+bufStart], now, precision, tags); err != nil {
+				h.Log.Error(err.Error())
 				return400 = true
 			}
 			if return400 {
@@ -300,15 +510,15 @@ func (h *HTTPListener) serveWrite(res http.ResponseWriter, req *http.Request) {
 		i := bytes.LastIndexByte(buf, '\n')
 		if i == -1 {
 			// drop any line longer than the max buffer size
-			log.Printf("E! http_listener received a single line longer than the maximum of %d bytes",
+			h.Log.Errorf("Received a single line longer than the maximum of %d bytes",
 				len(buf))
 			hangingBytes = true
 			return400 = true
 			bufStart = 0
 			continue
 		}
-		if err := h.parse(buf[:i+1], now, precision); err != nil {
-			log.Println("E! " + err.Error())
+		if err := h.parse(influxParser, buf[:i+1], now, precision, tags); err != nil {
+			h.Log.Error(err.Error())
 			return400 = true
 		}
 		// rotate the bit remaining after the last newline to the front of the buffer
@@ -320,16 +530,60 @@ func (h *HTTPListener) serveWrite(res http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func (h *HTTPListener) parse(b []byte, t time.Time, precision string) error {
-	metrics, err := h.parser.ParseWithDefaultTimePrecision(b, t, precision)
+// serveWholeBody parses the whole request body at once, for the data formats
+// which cannot be parsed line by line.
+func (h *HTTPListener) serveWholeBody(res http.ResponseWriter, body io.Reader, parser parsers.Parser, tags map[string]string) {
+	b, err := ioutil.ReadAll(body)
+	h.BytesRecv.Incr(int64(len(b)))
+	if err != nil {
+		h.Log.Error(err.Error())
+		badRequest(res)
+		return
+	}
 
-	for _, m := range metrics {
-		h.acc.AddFields(m.Name(), m.Fields(), m.Tags(), m.Time())
+	metrics, err := parser.Parse(b)
+	h.addMetrics(metrics, tags)
+	if err != nil {
+		h.Log.Error(err.Error())
+		badRequest(res)
+		return
 	}
+	res.WriteHeader(http.StatusNoContent)
+}
 
+func (h *HTTPListener) parse(parser *influx.InfluxParser, b []byte, t time.Time, precision string, tags map[string]string) error {
+	metrics, err := parser.ParseWithDefaultTimePrecision(b, t, precision)
+	h.addMetrics(metrics, tags)
 	return err
 }
 
+func (h *HTTPListener) addMetrics(metrics []telegraf.Metric, tags map[string]string) {
+	for _, m := range metrics {
+		mTags := m.Tags()
+		for k, v := range tags {
+			mTags[k] = v
+		}
+		h.acc.AddFields(m.Name(), m.Fields(), mTags, m.Time())
+	}
+}
+
+// requestTags returns the tags to add to the metrics of a request, from the
+// configured headers and the client IP.
+func (h *HTTPListener) requestTags(req *http.Request) map[string]string {
+	tags := make(map[string]string)
+	for header, tag := range h.HTTPHeaderTags {
+		if v := req.Header.Get(header); v != "" {
+			tags[tag] = v
+		}
+	}
+	if h.ClientIPTag != "" {
+		if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			tags[h.ClientIPTag] = host
+		}
+	}
+	return tags
+}
+
 func tooLarge(res http.ResponseWriter) {
 	res.Header().Set("Content-Type", "application/json")
 	res.Header().Set("X-Influxdb-Version", "1.0")
@@ -344,6 +598,18 @@ func badRequest(res http.ResponseWriter) {
 	res.Write([]byte(`{"error":"http: bad request"}`))
 }
 
+// v2Error responds with an error in the format of the InfluxDB v2 API.
+func v2Error(res http.ResponseWriter, status int, message string) {
+	code := "invalid"
+	if status == http.StatusMethodNotAllowed {
+		code = "method not allowed"
+	}
+	res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res.WriteHeader(status)
+	b, _ := json.Marshal(map[string]string{"code": code, "message": message})
+	res.Write(b)
+}
+
 func (h *HTTPListener) getTLSConfig() *tls.Config {
 	tlsConf := &tls.Config{
 		InsecureSkipVerify: false,
@@ -380,6 +646,8 @@ func init() {
 	inputs.Add("http_listener", func() telegraf.Input {
 		return &HTTPListener{
 			ServiceAddress: ":8186",
+			Paths:          defaultPaths,
+			Methods:        defaultMethods,
 		}
 	})
 }
@@ -1,10 +1,10 @@
 package http_response
 
 import (
+	"context"
 	"errors"
 	"io"
 	"io/ioutil"
-	"log"
 	"net"
 	"net/http"
 	"net/url"
@@ -36,6 +36,8 @@ type HTTPResponse struct {
 	// Use SSL but skip chain & host verification
 	InsecureSkipVerify bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	compiledStringMatch *regexp.Regexp
 	client              *http.Client
 }
@@ -114,7 +116,7 @@ func (h *HTTPResponse) createHttpClient() (*http.Client, error) {
 }
 
 // HTTPGather gathers all fields and returns any errors it encounters
-func (h *HTTPResponse) httpGather() (map[string]interface{}, error) {
+func (h *HTTPResponse) httpGather(ctx context.Context) (map[string]interface{}, error) {
 	// Prepare fields
 	fields := make(map[string]interface{})
 
@@ -126,6 +128,7 @@ func (h *HTTPResponse) httpGather() (map[string]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	request = request.WithContext(ctx)
 
 	for key, val := range h.Headers {
 		request.Header.Add(key, val)
@@ -139,6 +142,9 @@ func (h *HTTPResponse) httpGather() (map[string]interface{}, error) {
 	resp, err := h.client.Do(request)
 
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 			fields["result_type"] = "timeout"
 			return fields, nil
@@ -169,7 +175,7 @@ func (h *HTTPResponse) httpGather() (map[string]interface{}, error) {
 		if h.compiledStringMatch == nil {
 			h.compiledStringMatch = regexp.MustCompile(h.ResponseStringMatch)
 			if err != nil {
-				log.Printf("E! Failed to compile regular expression %s : %s", h.ResponseStringMatch, err)
+				h.Log.Errorf("Failed to compile regular expression %s : %s", h.ResponseStringMatch, err)
 				fields["result_type"] = "response_string_mismatch"
 				return fields, nil
 			}
@@ -177,7 +183,7 @@ func (h *HTTPResponse) httpGather() (map[string]interface{}, error) {
 
 		bodyBytes, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			log.Printf("E! Failed to read body of HTTP Response : %s", err)
+			h.Log.Errorf("Failed to read body of HTTP Response : %s", err)
 			fields["result_type"] = "response_string_mismatch"
 			fields["response_string_match"] = 0
 			return fields, nil
@@ -199,6 +205,11 @@ func (h *HTTPResponse) httpGather() (map[string]interface{}, error) {
 
 // Gather gets all metric fields and tags and returns any errors it encounters
 func (h *HTTPResponse) Gather(acc telegraf.Accumulator) error {
+	return h.GatherContext(context.Background(), acc)
+}
+
+// GatherContext is like Gather, but aborts the request once ctx is done.
+func (h *HTTPResponse) GatherContext(ctx context.Context, acc telegraf.Accumulator) error {
 	// Set default values
 	if h.ResponseTimeout.Duration < time.Second {
 		h.ResponseTimeout.Duration = time.Second * 5
@@ -230,7 +241,7 @@ func (h *HTTPResponse) Gather(acc telegraf.Accumulator) error {
 	}
 
 	// Gather data
-	fields, err = h.httpGather()
+	fields, err = h.httpGather(ctx)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package http_response
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -66,6 +67,7 @@ func TestHeaders(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL,
 		Method:          "GET",
 		ResponseTimeout: internal.Duration{Duration: time.Second * 2},
@@ -89,6 +91,7 @@ func TestFields(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/good",
 		Body:            "{ 'test': 'data'}",
 		Method:          "GET",
@@ -117,6 +120,7 @@ func TestRedirects(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/redirect",
 		Body:            "{ 'test': 'data'}",
 		Method:          "GET",
@@ -135,6 +139,7 @@ func TestRedirects(t *testing.T) {
 	require.Equal(t, http.StatusOK, value)
 
 	h = &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/badredirect",
 		Body:            "{ 'test': 'data'}",
 		Method:          "GET",
@@ -161,6 +166,7 @@ func TestMethod(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/mustbepostmethod",
 		Body:            "{ 'test': 'data'}",
 		Method:          "POST",
@@ -179,6 +185,7 @@ func TestMethod(t *testing.T) {
 	require.Equal(t, http.StatusOK, value)
 
 	h = &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/mustbepostmethod",
 		Body:            "{ 'test': 'data'}",
 		Method:          "GET",
@@ -198,6 +205,7 @@ func TestMethod(t *testing.T) {
 
 	//check that lowercase methods work correctly
 	h = &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/mustbepostmethod",
 		Body:            "{ 'test': 'data'}",
 		Method:          "head",
@@ -222,6 +230,7 @@ func TestBody(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/musthaveabody",
 		Body:            "{ 'test': 'data'}",
 		Method:          "GET",
@@ -240,6 +249,7 @@ func TestBody(t *testing.T) {
 	require.Equal(t, http.StatusOK, value)
 
 	h = &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/musthaveabody",
 		Method:          "GET",
 		ResponseTimeout: internal.Duration{Duration: time.Second * 20},
@@ -263,6 +273,7 @@ func TestStringMatch(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:                 testutil.Logger{},
 		Address:             ts.URL + "/good",
 		Body:                "{ 'test': 'data'}",
 		Method:              "GET",
@@ -296,6 +307,7 @@ func TestStringMatchJson(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:                 testutil.Logger{},
 		Address:             ts.URL + "/jsonresponse",
 		Body:                "{ 'test': 'data'}",
 		Method:              "GET",
@@ -329,6 +341,7 @@ func TestStringMatchFail(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:                 testutil.Logger{},
 		Address:             ts.URL + "/good",
 		Body:                "{ 'test': 'data'}",
 		Method:              "GET",
@@ -367,6 +380,7 @@ func TestTimeout(t *testing.T) {
 	defer ts.Close()
 
 	h := &HTTPResponse{
+		Log:             testutil.Logger{},
 		Address:         ts.URL + "/twosecondnap",
 		Body:            "{ 'test': 'data'}",
 		Method:          "GET",
@@ -388,3 +402,27 @@ func TestTimeout(t *testing.T) {
 	_, ok = acc.FloatField("http_response", "response_time")
 	require.False(t, ok)
 }
+
+func TestGatherContextCancel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping test with sleep in short mode.")
+	}
+
+	mux := setUpTestMux()
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	h := &HTTPResponse{
+		Log:             testutil.Logger{},
+		Address:         ts.URL + "/twosecondnap",
+		Method:          "GET",
+		ResponseTimeout: internal.Duration{Duration: 5 * time.Second},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var acc testutil.Accumulator
+	err := h.GatherContext(ctx, &acc)
+	require.Equal(t, context.DeadlineExceeded, err)
+	assert.False(t, acc.HasMeasurement("http_response"))
+}
@@ -2,6 +2,7 @@ package httpjson
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -130,6 +131,11 @@ func (h *HttpJson) Description() string {
 
 // Gathers data for all servers.
 func (h *HttpJson) Gather(acc telegraf.Accumulator) error {
+	return h.GatherContext(context.Background(), acc)
+}
+
+// Gathers data for all servers, aborting the requests once ctx is done.
+func (h *HttpJson) GatherContext(ctx context.Context, acc telegraf.Accumulator) error {
 	var wg sync.WaitGroup
 
 	if h.client.HTTPClient() == nil {
@@ -153,7 +159,7 @@ func (h *HttpJson) Gather(acc telegraf.Accumulator) error {
 		wg.Add(1)
 		go func(server string) {
 			defer wg.Done()
-			acc.AddError(h.gatherServer(acc, server))
+			acc.AddError(h.gatherServer(ctx, acc, server))
 		}(server)
 	}
 
@@ -164,6 +170,7 @@ func (h *HttpJson) Gather(acc telegraf.Accumulator) error {
 
 // Gathers data from a particular server
 // Parameters:
+//     ctx      : aborts the request when done
 //     acc      : The telegraf Accumulator to use
 //     serverURL: endpoint to send request to
 //     service  : the service being queried
@@ -171,10 +178,11 @@ func (h *HttpJson) Gather(acc telegraf.Accumulator) error {
 // Returns:
 //     error: Any error that may have occurred
 func (h *HttpJson) gatherServer(
+	ctx context.Context,
 	acc telegraf.Accumulator,
 	serverURL string,
 ) error {
-	resp, responseTime, err := h.sendRequest(serverURL)
+	resp, responseTime, err := h.sendRequest(ctx, serverURL)
 	if err != nil {
 		return err
 	}
@@ -213,12 +221,13 @@ func (h *HttpJson) gatherServer(
 // Sends an HTTP request to the server using the HttpJson object's HTTPClient.
 // This request can be either a GET or a POST.
 // Parameters:
+//     ctx      : aborts the request when done
 //     serverURL: endpoint to send request to
 //
 // Returns:
 //     string: body of the response
 //     error : Any error that may have occurred
-func (h *HttpJson) sendRequest(serverURL string) (string, float64, error) {
+func (h *HttpJson) sendRequest(ctx context.Context, serverURL string) (string, float64, error) {
 	// Prepare URL
 	requestURL, err := url.Parse(serverURL)
 	if err != nil {
@@ -247,6 +256,7 @@ func (h *HttpJson) sendRequest(serverURL string) (string, float64, error) {
 	if err != nil {
 		return "", -1, err
 	}
+	req = req.WithContext(ctx)
 
 	// Add header parameters
 	for k, v := range h.Headers {
@@ -146,6 +146,7 @@ func (c jolokiaClientStub) MakeRequest(req *http.Request) (*http.Response, error
 //     *HttpJson: Pointer to an HttpJson object that uses the generated mock HTTP client
 func genJolokiaClientStub(response string, statusCode int, servers []Server, metrics []Metric) *Jolokia {
 	return &Jolokia{
+		Log:       testutil.Logger{},
 		jClient:   jolokiaClientStub{responseBody: response, statusCode: statusCode},
 		Servers:   servers,
 		Metrics:   metrics,
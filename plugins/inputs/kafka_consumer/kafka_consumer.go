@@ -2,7 +2,6 @@ package kafka_consumer
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 
@@ -43,6 +42,9 @@ type Kafka struct {
 	PointBuffer int
 
 	Offset string
+
+	Log telegraf.Logger `toml:"-"`
+
 	parser parsers.Parser
 
 	sync.Mutex
@@ -123,12 +125,12 @@ func (k *Kafka) Start(acc telegraf.Accumulator) error {
 	}
 
 	if tlsConfig != nil {
-		log.Printf("D! TLS Enabled")
+		k.Log.Debugf("TLS Enabled")
 		config.Net.TLS.Config = tlsConfig
 		config.Net.TLS.Enable = true
 	}
 	if k.SASLUsername != "" && k.SASLPassword != "" {
-		log.Printf("D! Using SASL auth with username '%s',",
+		k.Log.Debugf("Using SASL auth with username '%s',",
 			k.SASLUsername)
 		config.Net.SASL.User = k.SASLUsername
 		config.Net.SASL.Password = k.SASLPassword
@@ -141,7 +143,7 @@ func (k *Kafka) Start(acc telegraf.Accumulator) error {
 	case "newest":
 		config.Consumer.Offsets.Initial = sarama.OffsetNewest
 	default:
-		log.Printf("I! WARNING: Kafka consumer invalid offset '%s', using 'oldest'\n",
+		k.Log.Infof("WARNING: Kafka consumer invalid offset '%s', using 'oldest'",
 			k.Offset)
 		config.Consumer.Offsets.Initial = sarama.OffsetOldest
 	}
@@ -155,7 +157,7 @@ func (k *Kafka) Start(acc telegraf.Accumulator) error {
 		)
 
 		if clusterErr != nil {
-			log.Printf("E! Error when creating Kafka Consumer, brokers: %v, topics: %v\n",
+			k.Log.Errorf("Error when creating Kafka Consumer, brokers: %v, topics: %v",
 				k.Brokers, k.Topics)
 			return clusterErr
 		}
@@ -168,7 +170,7 @@ func (k *Kafka) Start(acc telegraf.Accumulator) error {
 	k.done = make(chan struct{})
 	// Start the kafka message reader
 	go k.receiver()
-	log.Printf("I! Started the kafka consumer service, brokers: %v, topics: %v\n",
+	k.Log.Infof("Started the kafka consumer service, brokers: %v, topics: %v",
 		k.Brokers, k.Topics)
 	return nil
 }
@@ -2,7 +2,6 @@ package kafka_consumer_legacy
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 
@@ -28,6 +27,9 @@ type Kafka struct {
 	PointBuffer int
 
 	Offset string
+
+	Log telegraf.Logger `toml:"-"`
+
 	parser parsers.Parser
 
 	sync.Mutex
@@ -96,7 +98,7 @@ func (k *Kafka) Start(acc telegraf.Accumulator) error {
 	case "newest":
 		config.Offsets.Initial = sarama.OffsetNewest
 	default:
-		log.Printf("I! WARNING: Kafka consumer invalid offset '%s', using 'oldest'\n",
+		k.Log.Infof("WARNING: Kafka consumer invalid offset '%s', using 'oldest'",
 			k.Offset)
 		config.Offsets.Initial = sarama.OffsetOldest
 	}
@@ -121,7 +123,7 @@ func (k *Kafka) Start(acc telegraf.Accumulator) error {
 
 	// Start the kafka message reader
 	go k.receiver()
-	log.Printf("I! Started the kafka consumer service, peers: %v, topics: %v\n",
+	k.Log.Infof("Started the kafka consumer service, peers: %v, topics: %v",
 		k.ZookeeperPeers, k.Topics)
 	return nil
 }
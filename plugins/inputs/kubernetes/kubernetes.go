@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
@@ -18,6 +19,17 @@ import (
 type Kubernetes struct {
 	URL string
 
+	// URL of the API server to gather the inventory of the cluster from
+	APIURL string `toml:"api_url"`
+	// Namespace to gather the inventory of, all if empty
+	Namespace string `toml:"namespace"`
+
+	// Pod labels to add as tags, globs accepted
+	LabelInclude []string `toml:"label_include"`
+	LabelExclude []string `toml:"label_exclude"`
+	// Tag the pods with the kind and name of their owner
+	OwnerTags bool `toml:"owner_tags"`
+
 	// Bearer Token authorization file path
 	BearerToken string `toml:"bearer_token"`
 
@@ -34,12 +46,31 @@ type Kubernetes struct {
 	ResponseTimeout internal.Duration
 
 	RoundTripper http.RoundTripper
+
+	labelFilter filter.Filter
 }
 
 var sampleConfig = `
-  ## URL for the kubelet
+  ## URL for the kubelet, the kubelet is not queried if empty
   url = "http://1.1.1.1:10255"
 
+  ## URL for the API server, to gather the inventory of the deployments,
+  ## stateful sets, daemon sets, nodes, persistent volume claims and pods of
+  ## the cluster. This is best done from a single telegraf in the cluster
+  ## rather than from every node.
+  # api_url = "https://kubernetes.default.svc"
+  ## Namespace to gather the inventory of, all namespaces if empty
+  # namespace = ""
+
+  ## Pod labels to add as tags, globs accepted. No labels are added if
+  ## label_include is empty.
+  # label_include = []
+  # label_exclude = ["*.kubernetes.io/*"]
+
+  ## Tag the pods with the kind and name of the object owning them, ie, the
+  ## ReplicaSet or the DaemonSet
+  # owner_tags = false
+
   ## Use bearer token for authorization
   # bearer_token = /path/to/bearer/token
 
@@ -56,6 +87,7 @@ var sampleConfig = `
 
 const (
 	summaryEndpoint = `%s/stats/summary`
+	podsEndpoint    = `%s/pods`
 )
 
 func init() {
@@ -76,12 +108,29 @@ func (k *Kubernetes) Description() string {
 
 //Gather collects kubernetes metrics from a given URL
 func (k *Kubernetes) Gather(acc telegraf.Accumulator) error {
+	if k.labelFilter == nil {
+		labelFilter, err := filter.NewIncludeExcludeFilter(k.LabelInclude, k.LabelExclude)
+		if err != nil {
+			return err
+		}
+		k.labelFilter = labelFilter
+	}
+
 	var wg sync.WaitGroup
-	wg.Add(1)
-	go func(k *Kubernetes) {
-		defer wg.Done()
-		acc.AddError(k.gatherSummary(k.URL, acc))
-	}(k)
+	if k.URL != "" {
+		wg.Add(1)
+		go func(k *Kubernetes) {
+			defer wg.Done()
+			acc.AddError(k.gatherSummary(k.URL, acc))
+		}(k)
+	}
+	if k.APIURL != "" {
+		wg.Add(1)
+		go func(k *Kubernetes) {
+			defer wg.Done()
+			k.gatherInventory(k.APIURL, acc)
+		}(k)
+	}
 	wg.Wait()
 	return nil
 }
@@ -96,10 +145,40 @@ func buildURL(endpoint string, base string) (*url.URL, error) {
 }
 
 func (k *Kubernetes) gatherSummary(baseURL string, acc telegraf.Accumulator) error {
-	url := fmt.Sprintf("%s/stats/summary", baseURL)
-	var req, err = http.NewRequest("GET", url, nil)
-	var token []byte
-	var resp *http.Response
+	summaryMetrics := &SummaryMetrics{}
+	err := k.getJSON(fmt.Sprintf(summaryEndpoint, baseURL), summaryMetrics)
+	if err != nil {
+		return err
+	}
+
+	var podTags map[string]map[string]string
+	if k.podTagsEnabled() {
+		pods := &PodList{}
+		err := k.getJSON(fmt.Sprintf(podsEndpoint, baseURL), pods)
+		if err != nil {
+			// the pod metrics are still reported, without the pod tags
+			acc.AddError(fmt.Errorf("Unable to get the pod tags: %s", err))
+		} else {
+			podTags = make(map[string]map[string]string, len(pods.Items))
+			for _, pod := range pods.Items {
+				podTags[pod.Metadata.Namespace+"/"+pod.Metadata.Name] = k.podTags(pod)
+			}
+		}
+	}
+
+	buildSystemContainerMetrics(summaryMetrics, acc)
+	buildNodeMetrics(summaryMetrics, acc)
+	buildPodMetrics(summaryMetrics, podTags, acc)
+	return nil
+}
+
+// getJSON decodes the JSON response of a GET request to the kubelet or the
+// API server.
+func (k *Kubernetes) getJSON(url string, v interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
 
 	tlsCfg, err := internal.GetTLSConfig(k.SSLCert, k.SSLKey, k.SSLCA, k.InsecureSkipVerify)
 	if err != nil {
@@ -119,14 +198,14 @@ func (k *Kubernetes) gatherSummary(baseURL string, acc telegraf.Accumulator) err
 	}
 
 	if k.BearerToken != "" {
-		token, err = ioutil.ReadFile(k.BearerToken)
+		token, err := ioutil.ReadFile(k.BearerToken)
 		if err != nil {
 			return err
 		}
 		req.Header.Set("Authorization", "Bearer "+string(token))
 	}
 
-	resp, err = k.RoundTripper.RoundTrip(req)
+	resp, err := k.RoundTripper.RoundTrip(req)
 	if err != nil {
 		return fmt.Errorf("error making HTTP request to %s: %s", url, err)
 	}
@@ -136,17 +215,51 @@ func (k *Kubernetes) gatherSummary(baseURL string, acc telegraf.Accumulator) err
 		return fmt.Errorf("%s returned HTTP status %s", url, resp.Status)
 	}
 
-	summaryMetrics := &SummaryMetrics{}
-	err = json.NewDecoder(resp.Body).Decode(summaryMetrics)
+	err = json.NewDecoder(resp.Body).Decode(v)
 	if err != nil {
 		return fmt.Errorf(`Error parsing response: %s`, err)
 	}
-	buildSystemContainerMetrics(summaryMetrics, acc)
-	buildNodeMetrics(summaryMetrics, acc)
-	buildPodMetrics(summaryMetrics, acc)
 	return nil
 }
 
+// podTagsEnabled returns true if the pods are tagged with their labels or
+// owner.
+func (k *Kubernetes) podTagsEnabled() bool {
+	return len(k.LabelInclude) > 0 || k.OwnerTags
+}
+
+// podTags returns the tags of a pod from its labels and owner.
+func (k *Kubernetes) podTags(pod Pod) map[string]string {
+	tags := make(map[string]string)
+	if len(k.LabelInclude) > 0 {
+		for name, value := range pod.Metadata.Labels {
+			if k.labelFilter.Match(name) {
+				tags[name] = value
+			}
+		}
+	}
+	if k.OwnerTags {
+		for _, owner := range pod.Metadata.OwnerReferences {
+			if owner.Controller != nil && *owner.Controller {
+				tags["owner_kind"] = owner.Kind
+				tags["owner_name"] = owner.Name
+				break
+			}
+		}
+	}
+	return tags
+}
+
+// addTags adds the extra tags to tags, without overriding them.
+func addTags(tags map[string]string, extra map[string]string) map[string]string {
+	for k, v := range extra {
+		if _, ok := tags[k]; !ok {
+			tags[k] = v
+		}
+	}
+	return tags
+}
+
 func buildSystemContainerMetrics(summaryMetrics *SummaryMetrics, acc telegraf.Accumulator) {
 	for _, container := range summaryMetrics.Node.SystemContainers {
 		tags := map[string]string{
@@ -195,8 +308,9 @@ func buildNodeMetrics(summaryMetrics *SummaryMetrics, acc telegraf.Accumulator)
 	acc.AddFields("kubernetes_node", fields, tags)
 }
 
-func buildPodMetrics(summaryMetrics *SummaryMetrics, acc telegraf.Accumulator) {
+func buildPodMetrics(summaryMetrics *SummaryMetrics, podTags map[string]map[string]string, acc telegraf.Accumulator) {
 	for _, pod := range summaryMetrics.Pods {
+		extraTags := podTags[pod.PodRef.Namespace+"/"+pod.PodRef.Name]
 		for _, container := range pod.Containers {
 			tags := map[string]string{
 				"node_name":      summaryMetrics.Node.NodeName,
@@ -204,6 +318,7 @@ func buildPodMetrics(summaryMetrics *SummaryMetrics, acc telegraf.Accumulator) {
 				"container_name": container.Name,
 				"pod_name":       pod.PodRef.Name,
 			}
+			addTags(tags, extraTags)
 			fields := make(map[string]interface{})
 			fields["cpu_usage_nanocores"] = container.CPU.UsageNanoCores
 			fields["cpu_usage_core_nanoseconds"] = container.CPU.UsageCoreNanoSeconds
@@ -228,6 +343,7 @@ func buildPodMetrics(summaryMetrics *SummaryMetrics, acc telegraf.Accumulator) {
 				"namespace":   pod.PodRef.Namespace,
 				"volume_name": volume.Name,
 			}
+			addTags(tags, extraTags)
 			fields := make(map[string]interface{})
 			fields["available_bytes"] = volume.AvailableBytes
 			fields["capacity_bytes"] = volume.CapacityBytes
@@ -240,6 +356,7 @@ func buildPodMetrics(summaryMetrics *SummaryMetrics, acc telegraf.Accumulator) {
 			"pod_name":  pod.PodRef.Name,
 			"namespace": pod.PodRef.Namespace,
 		}
+		addTags(tags, extraTags)
 		fields := make(map[string]interface{})
 		fields["rx_bytes"] = pod.Network.RXBytes
 		fields["rx_errors"] = pod.Network.RXErrors
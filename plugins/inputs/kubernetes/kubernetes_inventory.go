@@ -0,0 +1,312 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+)
+
+// phase types of the pods and persistent volume claims, in the order of
+// kube-state-metrics, the phases missing are reported as unknown
+var (
+	podPhaseTypes = map[string]int{
+		"Running":   0,
+		"Succeeded": 1,
+		"Pending":   2,
+		"Failed":    3,
+		"Unknown":   4,
+	}
+	pvcPhaseTypes = map[string]int{
+		"Bound":   0,
+		"Lost":    1,
+		"Pending": 2,
+		"Unknown": 3,
+	}
+)
+
+// gatherInventory gathers kube-state style metrics about the objects of the
+// cluster from the API server.
+func (k *Kubernetes) gatherInventory(baseURL string, acc telegraf.Accumulator) {
+	deployments := &DeploymentList{}
+	if err := k.getJSON(k.apiURL(baseURL, "/apis/apps/v1", "deployments", true), deployments); err != nil {
+		acc.AddError(err)
+	} else {
+		for _, d := range deployments.Items {
+			buildDeploymentMetrics(d, acc)
+		}
+	}
+
+	statefulSets := &StatefulSetList{}
+	if err := k.getJSON(k.apiURL(baseURL, "/apis/apps/v1", "statefulsets", true), statefulSets); err != nil {
+		acc.AddError(err)
+	} else {
+		for _, s := range statefulSets.Items {
+			buildStatefulSetMetrics(s, acc)
+		}
+	}
+
+	daemonSets := &DaemonSetList{}
+	if err := k.getJSON(k.apiURL(baseURL, "/apis/apps/v1", "daemonsets", true), daemonSets); err != nil {
+		acc.AddError(err)
+	} else {
+		for _, d := range daemonSets.Items {
+			buildDaemonSetMetrics(d, acc)
+		}
+	}
+
+	nodes := &NodeList{}
+	if err := k.getJSON(k.apiURL(baseURL, "/api/v1", "nodes", false), nodes); err != nil {
+		acc.AddError(err)
+	} else {
+		for _, n := range nodes.Items {
+			buildNodeInventoryMetrics(n, acc)
+		}
+	}
+
+	pvcs := &PersistentVolumeClaimList{}
+	if err := k.getJSON(k.apiURL(baseURL, "/api/v1", "persistentvolumeclaims", true), pvcs); err != nil {
+		acc.AddError(err)
+	} else {
+		for _, pvc := range pvcs.Items {
+			buildPersistentVolumeClaimMetrics(pvc, acc)
+		}
+	}
+
+	pods := &PodList{}
+	if err := k.getJSON(k.apiURL(baseURL, "/api/v1", "pods", true), pods); err != nil {
+		acc.AddError(err)
+	} else {
+		for _, p := range pods.Items {
+			k.buildPodStatusMetrics(p, acc)
+		}
+	}
+}
+
+// apiURL returns the URL listing the resources of an API group, in the
+// configured namespace for the namespaced resources.
+func (k *Kubernetes) apiURL(baseURL, group, resource string, namespaced bool) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if namespaced && k.Namespace != "" {
+		return fmt.Sprintf("%s%s/namespaces/%s/%s", baseURL, group, k.Namespace, resource)
+	}
+	return fmt.Sprintf("%s%s/%s", baseURL, group, resource)
+}
+
+func buildDeploymentMetrics(d Deployment, acc telegraf.Accumulator) {
+	tags := map[string]string{
+		"deployment_name": d.Metadata.Name,
+		"namespace":       d.Metadata.Namespace,
+	}
+	fields := map[string]interface{}{
+		"replicas":             d.Status.Replicas,
+		"replicas_updated":     d.Status.UpdatedReplicas,
+		"replicas_ready":       d.Status.ReadyReplicas,
+		"replicas_available":   d.Status.AvailableReplicas,
+		"replicas_unavailable": d.Status.UnavailableReplicas,
+		"generation":           d.Metadata.Generation,
+		"observed_generation":  d.Status.ObservedGeneration,
+		"created":              d.Metadata.CreationTimestamp.UnixNano(),
+	}
+	if d.Spec.Replicas != nil {
+		fields["spec_replicas"] = *d.Spec.Replicas
+	}
+	acc.AddFields("kubernetes_deployment", fields, tags)
+}
+
+func buildStatefulSetMetrics(s StatefulSet, acc telegraf.Accumulator) {
+	tags := map[string]string{
+		"statefulset_name": s.Metadata.Name,
+		"namespace":        s.Metadata.Namespace,
+	}
+	fields := map[string]interface{}{
+		"replicas":            s.Status.Replicas,
+		"replicas_current":    s.Status.CurrentReplicas,
+		"replicas_ready":      s.Status.ReadyReplicas,
+		"replicas_updated":    s.Status.UpdatedReplicas,
+		"generation":          s.Metadata.Generation,
+		"observed_generation": s.Status.ObservedGeneration,
+		"created":             s.Metadata.CreationTimestamp.UnixNano(),
+	}
+	if s.Spec.Replicas != nil {
+		fields["spec_replicas"] = *s.Spec.Replicas
+	}
+	acc.AddFields("kubernetes_statefulset", fields, tags)
+}
+
+func buildDaemonSetMetrics(d DaemonSet, acc telegraf.Accumulator) {
+	tags := map[string]string{
+		"daemonset_name": d.Metadata.Name,
+		"namespace":      d.Metadata.Namespace,
+	}
+	fields := map[string]interface{}{
+		"current_number_scheduled": d.Status.CurrentNumberScheduled,
+		"desired_number_scheduled": d.Status.DesiredNumberScheduled,
+		"number_available":         d.Status.NumberAvailable,
+		"number_misscheduled":      d.Status.NumberMisscheduled,
+		"number_ready":             d.Status.NumberReady,
+		"number_unavailable":       d.Status.NumberUnavailable,
+		"updated_number_scheduled": d.Status.UpdatedNumberScheduled,
+		"generation":               d.Metadata.Generation,
+		"observed_generation":      d.Status.ObservedGeneration,
+		"created":                  d.Metadata.CreationTimestamp.UnixNano(),
+	}
+	acc.AddFields("kubernetes_daemonset", fields, tags)
+}
+
+// buildNodeInventoryMetrics adds the resources and the conditions of a node,
+// in measurements apart from the kubernetes_node measurement of the kubelet.
+func buildNodeInventoryMetrics(n Node, acc telegraf.Accumulator) {
+	tags := map[string]string{
+		"node_name": n.Metadata.Name,
+	}
+	fields := map[string]interface{}{
+		"unschedulable": n.Spec.Unschedulable,
+		"created":       n.Metadata.CreationTimestamp.UnixNano(),
+	}
+	addResourceFields(fields, "capacity", n.Status.Capacity)
+	addResourceFields(fields, "allocatable", n.Status.Allocatable)
+	acc.AddFields("kubernetes_node_status", fields, tags)
+
+	for _, c := range n.Status.Conditions {
+		ctags := map[string]string{
+			"node_name": n.Metadata.Name,
+			"condition": c.Type,
+			"status":    c.Status,
+		}
+		value := 0
+		if c.Status == "True" {
+			value = 1
+		}
+		acc.AddFields("kubernetes_node_condition",
+			map[string]interface{}{"status_condition": value}, ctags)
+	}
+}
+
+// addResourceFields adds the cpu, memory and pods resources of a node as
+// fields, the cpu in millicores.
+func addResourceFields(fields map[string]interface{}, prefix string, resources map[string]string) {
+	if v, ok := resources["cpu"]; ok {
+		if cpu, err := parseQuantity(v); err == nil {
+			fields[prefix+"_millicpu_cores"] = int64(cpu*1000 + 0.5)
+		}
+	}
+	if v, ok := resources["memory"]; ok {
+		if memory, err := parseQuantity(v); err == nil {
+			fields[prefix+"_memory_bytes"] = int64(memory)
+		}
+	}
+	if v, ok := resources["pods"]; ok {
+		if pods, err := parseQuantity(v); err == nil {
+			fields[prefix+"_pods"] = int64(pods)
+		}
+	}
+}
+
+func buildPersistentVolumeClaimMetrics(pvc PersistentVolumeClaim, acc telegraf.Accumulator) {
+	tags := map[string]string{
+		"pvc_name":  pvc.Metadata.Name,
+		"namespace": pvc.Metadata.Namespace,
+		"phase":     pvc.Status.Phase,
+	}
+	if pvc.Spec.StorageClassName != nil {
+		tags["storageclass"] = *pvc.Spec.StorageClassName
+	}
+	if pvc.Spec.VolumeName != "" {
+		tags["volume_name"] = pvc.Spec.VolumeName
+	}
+
+	phaseType, ok := pvcPhaseTypes[pvc.Status.Phase]
+	if !ok {
+		phaseType = pvcPhaseTypes["Unknown"]
+	}
+	fields := map[string]interface{}{
+		"phase_type": phaseType,
+	}
+	if v, ok := pvc.Spec.Resources.Requests["storage"]; ok {
+		if storage, err := parseQuantity(v); err == nil {
+			fields["requested_bytes"] = int64(storage)
+		}
+	}
+	if v, ok := pvc.Status.Capacity["storage"]; ok {
+		if storage, err := parseQuantity(v); err == nil {
+			fields["capacity_bytes"] = int64(storage)
+		}
+	}
+	acc.AddFields("kubernetes_pvc", fields, tags)
+}
+
+func (k *Kubernetes) buildPodStatusMetrics(p Pod, acc telegraf.Accumulator) {
+	tags := map[string]string{
+		"pod_name":  p.Metadata.Name,
+		"namespace": p.Metadata.Namespace,
+		"phase":     p.Status.Phase,
+	}
+	if p.Spec.NodeName != "" {
+		tags["node_name"] = p.Spec.NodeName
+	}
+	addTags(tags, k.podTags(p))
+
+	phaseType, ok := podPhaseTypes[p.Status.Phase]
+	if !ok {
+		phaseType = podPhaseTypes["Unknown"]
+	}
+	var ready, restarts int64
+	for _, c := range p.Status.ContainerStatuses {
+		if c.Ready {
+			ready++
+		}
+		restarts += c.RestartCount
+	}
+	fields := map[string]interface{}{
+		"phase_type":       phaseType,
+		"containers":       int64(len(p.Spec.Containers)),
+		"containers_ready": ready,
+		"restarts_total":   restarts,
+		"created":          p.Metadata.CreationTimestamp.UnixNano(),
+	}
+	acc.AddFields("kubernetes_pod_status", fields, tags)
+}
+
+// the suffixes of the kubernetes resource quantities with their multipliers
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"Ti", 1 << 40},
+	{"Pi", 1 << 50},
+	{"Ei", 1 << 60},
+	{"n", 1e-9},
+	{"u", 1e-6},
+	{"m", 1e-3},
+	{"k", 1e3},
+	{"M", 1e6},
+	{"G", 1e9},
+	{"T", 1e12},
+	{"P", 1e15},
+	{"E", 1e18},
+}
+
+// parseQuantity parses a kubernetes resource quantity, ie, 3500m cpu or
+// 16Gi of memory.
+func parseQuantity(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	multiplier := 1.0
+	for _, q := range quantitySuffixes {
+		if strings.HasSuffix(s, q.suffix) {
+			s = strings.TrimSuffix(s, q.suffix)
+			multiplier = q.multiplier
+			break
+		}
+	}
+	// a decimal exponent, ie, 1e3, is parsed along with the number
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return v * multiplier, nil
+}
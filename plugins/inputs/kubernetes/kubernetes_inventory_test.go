@@ -0,0 +1,463 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAPIServer returns a fake API server serving the inventory responses,
+// recording the paths requested.
+func newAPIServer(paths *[]string) *httptest.Server {
+	responses := map[string]string{
+		"deployments":            deploymentsResponse,
+		"statefulsets":           statefulSetsResponse,
+		"daemonsets":             daemonSetsResponse,
+		"nodes":                  nodesResponse,
+		"persistentvolumeclaims": pvcsResponse,
+		"pods":                   apiPodsResponse,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		for resource, response := range responses {
+			if len(r.URL.Path) > len(resource) && r.URL.Path[len(r.URL.Path)-len(resource)-1:] == "/"+resource {
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, response)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func TestKubernetesInventory(t *testing.T) {
+	var paths []string
+	ts := newAPIServer(&paths)
+	defer ts.Close()
+
+	k := &Kubernetes{
+		APIURL:       ts.URL,
+		LabelInclude: []string{"app"},
+	}
+
+	var acc testutil.Accumulator
+	err := acc.GatherError(k.Gather)
+	require.NoError(t, err)
+
+	created := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC).UnixNano()
+
+	acc.AssertContainsTaggedFields(t, "kubernetes_deployment",
+		map[string]interface{}{
+			"replicas":             int64(3),
+			"spec_replicas":        int64(3),
+			"replicas_updated":     int64(3),
+			"replicas_ready":       int64(2),
+			"replicas_available":   int64(2),
+			"replicas_unavailable": int64(1),
+			"generation":           int64(4),
+			"observed_generation":  int64(4),
+			"created":              created,
+		},
+		map[string]string{
+			"deployment_name": "foo",
+			"namespace":       "foons",
+		})
+
+	acc.AssertContainsTaggedFields(t, "kubernetes_statefulset",
+		map[string]interface{}{
+			"replicas":            int64(2),
+			"spec_replicas":       int64(2),
+			"replicas_current":    int64(2),
+			"replicas_ready":      int64(2),
+			"replicas_updated":    int64(2),
+			"generation":          int64(1),
+			"observed_generation": int64(1),
+			"created":             created,
+		},
+		map[string]string{
+			"statefulset_name": "db",
+			"namespace":        "foons",
+		})
+
+	acc.AssertContainsTaggedFields(t, "kubernetes_daemonset",
+		map[string]interface{}{
+			"current_number_scheduled": int64(2),
+			"desired_number_scheduled": int64(2),
+			"number_available":         int64(1),
+			"number_misscheduled":      int64(0),
+			"number_ready":             int64(1),
+			"number_unavailable":       int64(1),
+			"updated_number_scheduled": int64(2),
+			"generation":               int64(2),
+			"observed_generation":      int64(2),
+			"created":                  created,
+		},
+		map[string]string{
+			"daemonset_name": "telegraf",
+			"namespace":      "kube-system",
+		})
+
+	acc.AssertContainsTaggedFields(t, "kubernetes_node_status",
+		map[string]interface{}{
+			"unschedulable":              false,
+			"created":                    created,
+			"capacity_millicpu_cores":    int64(4000),
+			"capacity_memory_bytes":      int64(16393144 * 1024),
+			"capacity_pods":              int64(110),
+			"allocatable_millicpu_cores": int64(3500),
+			"allocatable_memory_bytes":   int64(15 * 1024 * 1024 * 1024),
+			"allocatable_pods":           int64(110),
+		},
+		map[string]string{
+			"node_name": "node1",
+		})
+	acc.AssertContainsTaggedFields(t, "kubernetes_node_condition",
+		map[string]interface{}{"status_condition": 1},
+		map[string]string{
+			"node_name": "node1",
+			"condition": "Ready",
+			"status":    "True",
+		})
+	acc.AssertContainsTaggedFields(t, "kubernetes_node_condition",
+		map[string]interface{}{"status_condition": 0},
+		map[string]string{
+			"node_name": "node1",
+			"condition": "MemoryPressure",
+			"status":    "False",
+		})
+
+	acc.AssertContainsTaggedFields(t, "kubernetes_pvc",
+		map[string]interface{}{
+			"phase_type":      0,
+			"requested_bytes": int64(10 * 1024 * 1024 * 1024),
+			"capacity_bytes":  int64(10 * 1024 * 1024 * 1024),
+		},
+		map[string]string{
+			"pvc_name":     "data-db-0",
+			"namespace":    "foons",
+			"phase":        "Bound",
+			"storageclass": "standard",
+			"volume_name":  "pvc-0a1b2c",
+		})
+	acc.AssertContainsTaggedFields(t, "kubernetes_pvc",
+		map[string]interface{}{
+			"phase_type":      2,
+			"requested_bytes": int64(1000000000),
+		},
+		map[string]string{
+			"pvc_name":  "scratch",
+			"namespace": "foons",
+			"phase":     "Pending",
+		})
+
+	acc.AssertContainsTaggedFields(t, "kubernetes_pod_status",
+		map[string]interface{}{
+			"phase_type":       0,
+			"containers":       int64(2),
+			"containers_ready": int64(1),
+			"restarts_total":   int64(5),
+			"created":          created,
+		},
+		map[string]string{
+			"pod_name":  "foo-5d8f7c9b4-x2x7z",
+			"namespace": "foons",
+			"node_name": "node1",
+			"phase":     "Running",
+			"app":       "foo",
+		})
+	acc.AssertContainsTaggedFields(t, "kubernetes_pod_status",
+		map[string]interface{}{
+			"phase_type":       2,
+			"containers":       int64(1),
+			"containers_ready": int64(0),
+			"restarts_total":   int64(0),
+			"created":          created,
+		},
+		map[string]string{
+			"pod_name":  "pending",
+			"namespace": "foons",
+			"phase":     "Pending",
+		})
+
+	sort.Strings(paths)
+	assert.Equal(t, []string{
+		"/api/v1/nodes",
+		"/api/v1/persistentvolumeclaims",
+		"/api/v1/pods",
+		"/apis/apps/v1/daemonsets",
+		"/apis/apps/v1/deployments",
+		"/apis/apps/v1/statefulsets",
+	}, paths)
+}
+
+func TestKubernetesInventoryNamespace(t *testing.T) {
+	var paths []string
+	ts := newAPIServer(&paths)
+	defer ts.Close()
+
+	k := &Kubernetes{
+		APIURL:    ts.URL + "/",
+		Namespace: "foons",
+	}
+
+	var acc testutil.Accumulator
+	err := acc.GatherError(k.Gather)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/apis/apps/v1/namespaces/foons/deployments",
+		"/apis/apps/v1/namespaces/foons/statefulsets",
+		"/apis/apps/v1/namespaces/foons/daemonsets",
+		"/api/v1/nodes",
+		"/api/v1/namespaces/foons/persistentvolumeclaims",
+		"/api/v1/namespaces/foons/pods",
+	}, paths)
+}
+
+func TestKubernetesInventoryErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/nodes" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintln(w, nodesResponse)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	k := &Kubernetes{
+		APIURL: ts.URL,
+	}
+
+	var acc testutil.Accumulator
+	require.NoError(t, k.Gather(&acc))
+
+	// the resources which cannot be listed do not prevent the others
+	assert.Len(t, acc.Errors, 5)
+	assert.True(t, acc.HasMeasurement("kubernetes_node_status"))
+}
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		quantity string
+		value    float64
+	}{
+		{"4", 4},
+		{"3500m", 3.5},
+		{"16Gi", 16 * 1024 * 1024 * 1024},
+		{"16393144Ki", 16393144 * 1024},
+		{"1G", 1e9},
+		{"1e3", 1000},
+		{"0.5", 0.5},
+	}
+	for _, tt := range tests {
+		v, err := parseQuantity(tt.quantity)
+		require.NoError(t, err, tt.quantity)
+		assert.InDelta(t, tt.value, v, 1e-9, tt.quantity)
+	}
+
+	_, err := parseQuantity("lots")
+	assert.Error(t, err)
+}
+
+var deploymentsResponse = `
+{
+  "kind": "DeploymentList",
+  "apiVersion": "apps/v1",
+  "items": [
+    {
+      "metadata": {
+        "name": "foo",
+        "namespace": "foons",
+        "generation": 4,
+        "creationTimestamp": "2018-06-01T10:00:00Z"
+      },
+      "spec": {
+        "replicas": 3
+      },
+      "status": {
+        "observedGeneration": 4,
+        "replicas": 3,
+        "updatedReplicas": 3,
+        "readyReplicas": 2,
+        "availableReplicas": 2,
+        "unavailableReplicas": 1
+      }
+    }
+  ]
+}`
+
+var statefulSetsResponse = `
+{
+  "kind": "StatefulSetList",
+  "apiVersion": "apps/v1",
+  "items": [
+    {
+      "metadata": {
+        "name": "db",
+        "namespace": "foons",
+        "generation": 1,
+        "creationTimestamp": "2018-06-01T10:00:00Z"
+      },
+      "spec": {
+        "replicas": 2
+      },
+      "status": {
+        "observedGeneration": 1,
+        "replicas": 2,
+        "currentReplicas": 2,
+        "readyReplicas": 2,
+        "updatedReplicas": 2
+      }
+    }
+  ]
+}`
+
+var daemonSetsResponse = `
+{
+  "kind": "DaemonSetList",
+  "apiVersion": "apps/v1",
+  "items": [
+    {
+      "metadata": {
+        "name": "telegraf",
+        "namespace": "kube-system",
+        "generation": 2,
+        "creationTimestamp": "2018-06-01T10:00:00Z"
+      },
+      "status": {
+        "currentNumberScheduled": 2,
+        "numberMisscheduled": 0,
+        "desiredNumberScheduled": 2,
+        "numberReady": 1,
+        "observedGeneration": 2,
+        "updatedNumberScheduled": 2,
+        "numberAvailable": 1,
+        "numberUnavailable": 1
+      }
+    }
+  ]
+}`
+
+var nodesResponse = `
+{
+  "kind": "NodeList",
+  "apiVersion": "v1",
+  "items": [
+    {
+      "metadata": {
+        "name": "node1",
+        "creationTimestamp": "2018-06-01T10:00:00Z"
+      },
+      "spec": {},
+      "status": {
+        "capacity": {
+          "cpu": "4",
+          "memory": "16393144Ki",
+          "pods": "110"
+        },
+        "allocatable": {
+          "cpu": "3500m",
+          "memory": "15Gi",
+          "pods": "110"
+        },
+        "conditions": [
+          {"type": "MemoryPressure", "status": "False"},
+          {"type": "DiskPressure", "status": "False"},
+          {"type": "Ready", "status": "True"}
+        ]
+      }
+    }
+  ]
+}`
+
+var pvcsResponse = `
+{
+  "kind": "PersistentVolumeClaimList",
+  "apiVersion": "v1",
+  "items": [
+    {
+      "metadata": {
+        "name": "data-db-0",
+        "namespace": "foons",
+        "creationTimestamp": "2018-06-01T10:00:00Z"
+      },
+      "spec": {
+        "storageClassName": "standard",
+        "volumeName": "pvc-0a1b2c",
+        "resources": {
+          "requests": {"storage": "10Gi"}
+        }
+      },
+      "status": {
+        "phase": "Bound",
+        "capacity": {"storage": "10Gi"}
+      }
+    },
+    {
+      "metadata": {
+        "name": "scratch",
+        "namespace": "foons",
+        "creationTimestamp": "2018-06-01T10:00:00Z"
+      },
+      "spec": {
+        "resources": {
+          "requests": {"storage": "1G"}
+        }
+      },
+      "status": {
+        "phase": "Pending"
+      }
+    }
+  ]
+}`
+
+var apiPodsResponse = `
+{
+  "kind": "PodList",
+  "apiVersion": "v1",
+  "items": [
+    {
+      "metadata": {
+        "name": "foo-5d8f7c9b4-x2x7z",
+        "namespace": "foons",
+        "labels": {
+          "app": "foo",
+          "pod-template-hash": "5d8f7c9b4"
+        },
+        "creationTimestamp": "2018-06-01T10:00:00Z"
+      },
+      "spec": {
+        "nodeName": "node1",
+        "containers": [{"name": "foo"}, {"name": "sidecar"}]
+      },
+      "status": {
+        "phase": "Running",
+        "containerStatuses": [
+          {"name": "foo", "ready": true, "restartCount": 1},
+          {"name": "sidecar", "ready": false, "restartCount": 4}
+        ]
+      }
+    },
+    {
+      "metadata": {
+        "name": "pending",
+        "namespace": "foons",
+        "creationTimestamp": "2018-06-01T10:00:00Z"
+      },
+      "spec": {
+        "containers": [{"name": "pending"}]
+      },
+      "status": {
+        "phase": "Pending"
+      }
+    }
+  ]
+}`
@@ -0,0 +1,155 @@
+package kubernetes
+
+import "time"
+
+// ObjectMeta is the metadata common to the kubernetes objects
+type ObjectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	Labels            map[string]string `json:"labels"`
+	OwnerReferences   []OwnerReference  `json:"ownerReferences"`
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+	Generation        int64             `json:"generation"`
+}
+
+// OwnerReference identifies the object owning another one, ie, the replica
+// set of a pod
+type OwnerReference struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller *bool  `json:"controller"`
+}
+
+// PodList is the list of pods returned by the kubelet and the API server
+type PodList struct {
+	Items []Pod `json:"items"`
+}
+
+// Pod is a pod with the parts of its spec and status reported
+type Pod struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		NodeName   string `json:"nodeName"`
+		Containers []struct {
+			Name string `json:"name"`
+		} `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase             string            `json:"phase"`
+		ContainerStatuses []ContainerStatus `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// ContainerStatus is the status of a container of a pod
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	RestartCount int64  `json:"restartCount"`
+}
+
+// DeploymentList is a list of deployments
+type DeploymentList struct {
+	Items []Deployment `json:"items"`
+}
+
+// Deployment is a deployment with its replica counts
+type Deployment struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int64 `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		Replicas            int64 `json:"replicas"`
+		UpdatedReplicas     int64 `json:"updatedReplicas"`
+		ReadyReplicas       int64 `json:"readyReplicas"`
+		AvailableReplicas   int64 `json:"availableReplicas"`
+		UnavailableReplicas int64 `json:"unavailableReplicas"`
+		ObservedGeneration  int64 `json:"observedGeneration"`
+	} `json:"status"`
+}
+
+// StatefulSetList is a list of stateful sets
+type StatefulSetList struct {
+	Items []StatefulSet `json:"items"`
+}
+
+// StatefulSet is a stateful set with its replica counts
+type StatefulSet struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int64 `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		Replicas           int64 `json:"replicas"`
+		CurrentReplicas    int64 `json:"currentReplicas"`
+		ReadyReplicas      int64 `json:"readyReplicas"`
+		UpdatedReplicas    int64 `json:"updatedReplicas"`
+		ObservedGeneration int64 `json:"observedGeneration"`
+	} `json:"status"`
+}
+
+// DaemonSetList is a list of daemon sets
+type DaemonSetList struct {
+	Items []DaemonSet `json:"items"`
+}
+
+// DaemonSet is a daemon set with its scheduling counts
+type DaemonSet struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Status   struct {
+		CurrentNumberScheduled int64 `json:"currentNumberScheduled"`
+		DesiredNumberScheduled int64 `json:"desiredNumberScheduled"`
+		NumberAvailable        int64 `json:"numberAvailable"`
+		NumberMisscheduled     int64 `json:"numberMisscheduled"`
+		NumberReady            int64 `json:"numberReady"`
+		NumberUnavailable      int64 `json:"numberUnavailable"`
+		UpdatedNumberScheduled int64 `json:"updatedNumberScheduled"`
+		ObservedGeneration     int64 `json:"observedGeneration"`
+	} `json:"status"`
+}
+
+// NodeList is a list of nodes
+type NodeList struct {
+	Items []Node `json:"items"`
+}
+
+// Node is a node with its resources and conditions
+type Node struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Unschedulable bool `json:"unschedulable"`
+	} `json:"spec"`
+	Status struct {
+		Capacity    map[string]string `json:"capacity"`
+		Allocatable map[string]string `json:"allocatable"`
+		Conditions  []NodeCondition   `json:"conditions"`
+	} `json:"status"`
+}
+
+// NodeCondition is a condition of a node, ie, Ready or MemoryPressure, with a
+// status of True, False or Unknown
+type NodeCondition struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+// PersistentVolumeClaimList is a list of persistent volume claims
+type PersistentVolumeClaimList struct {
+	Items []PersistentVolumeClaim `json:"items"`
+}
+
+// PersistentVolumeClaim is a persistent volume claim with its phase
+type PersistentVolumeClaim struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		StorageClassName *string `json:"storageClassName"`
+		VolumeName       string  `json:"volumeName"`
+		Resources        struct {
+			Requests map[string]string `json:"requests"`
+		} `json:"resources"`
+	} `json:"spec"`
+	Status struct {
+		Phase    string            `json:"phase"`
+		Capacity map[string]string `json:"capacity"`
+	} `json:"status"`
+}
@@ -143,6 +143,86 @@ func TestKubernetesStats(t *testing.T) {
 
 }
 
+func TestKubernetesPodLabelsAndOwner(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/stats/summary":
+			fmt.Fprintln(w, response)
+		case "/pods":
+			fmt.Fprintln(w, podsResponse)
+		}
+	}))
+	defer ts.Close()
+
+	k := &Kubernetes{
+		URL:          ts.URL,
+		LabelInclude: []string{"app", "tier*"},
+		LabelExclude: []string{"tier-internal"},
+		OwnerTags:    true,
+	}
+
+	var acc testutil.Accumulator
+	err := acc.GatherError(k.Gather)
+	require.NoError(t, err)
+
+	tags := map[string]string{
+		"node_name":  "node1",
+		"namespace":  "foons",
+		"pod_name":   "foopod",
+		"app":        "foo",
+		"tier":       "backend",
+		"owner_kind": "ReplicaSet",
+		"owner_name": "foo-5d8f7c9b4",
+	}
+	require.True(t, acc.HasPoint("kubernetes_pod_network", tags, "rx_bytes", int64(70749124)))
+
+	tags["volume_name"] = "volume1"
+	require.True(t, acc.HasPoint("kubernetes_pod_volume", tags, "used_bytes", int64(12288)))
+	delete(tags, "volume_name")
+
+	tags["container_name"] = "foocontainer"
+	require.True(t, acc.HasPoint("kubernetes_pod_container", tags, "memory_rss_bytes", int64(30695424)))
+
+	// pods unknown to the kubelet have no extra tags
+	require.True(t, acc.HasPoint("kubernetes_pod_container", map[string]string{
+		"node_name":      "node1",
+		"container_name": "stopped-container",
+		"namespace":      "foons",
+		"pod_name":       "stopped-pod",
+	}, "memory_rss_bytes", int64(0)))
+}
+
+func TestKubernetesPodTagsUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stats/summary":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintln(w, response)
+		case "/pods":
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	k := &Kubernetes{
+		URL:       ts.URL,
+		OwnerTags: true,
+	}
+
+	var acc testutil.Accumulator
+	require.NoError(t, k.Gather(&acc))
+	require.Len(t, acc.Errors, 1)
+
+	// the pod metrics are reported without the pod tags
+	require.True(t, acc.HasPoint("kubernetes_pod_network", map[string]string{
+		"node_name": "node1",
+		"namespace": "foons",
+		"pod_name":  "foopod",
+	}, "rx_bytes", int64(70749124)))
+	require.True(t, acc.HasMeasurement("kubernetes_node"))
+}
+
 var response = `
 {
   "node": {
@@ -329,3 +409,38 @@ var response = `
    }
   ]
  }`
+
+var podsResponse = `
+{
+  "kind": "PodList",
+  "apiVersion": "v1",
+  "items": [
+    {
+      "metadata": {
+        "name": "foopod",
+        "namespace": "foons",
+        "labels": {
+          "app": "foo",
+          "tier": "backend",
+          "tier-internal": "yes",
+          "pod-template-hash": "5d8f7c9b4"
+        },
+        "ownerReferences": [
+          {
+            "apiVersion": "apps/v1",
+            "kind": "ReplicaSet",
+            "name": "foo-5d8f7c9b4",
+            "controller": true
+          }
+        ]
+      },
+      "spec": {
+        "nodeName": "node1",
+        "containers": [{"name": "foocontainer"}]
+      },
+      "status": {
+        "phase": "Running"
+      }
+    }
+  ]
+}`
@@ -3,7 +3,6 @@ package grok
 import (
 	"bufio"
 	"fmt"
-	"log"
 	"os"
 	"regexp"
 	"strconv"
@@ -81,6 +80,8 @@ type Parser struct {
 	Timezone string
 	loc      *time.Location
 
+	Log telegraf.Logger `toml:"-"`
+
 	// typeMap is a map of patterns -> capture name -> modifier,
 	//   ie, {
 	//          "%{TESTLOG}":
@@ -169,7 +170,7 @@ func (p *Parser) Compile() error {
 
 	p.loc, err = time.LoadLocation(p.Timezone)
 	if err != nil {
-		log.Printf("W! improper timezone supplied (%s), setting loc to UTC", p.Timezone)
+		p.Log.Warnf("improper timezone supplied (%s), setting loc to UTC", p.Timezone)
 		p.loc, _ = time.LoadLocation("UTC")
 	}
 
@@ -194,7 +195,7 @@ func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
 	}
 
 	if len(values) == 0 {
-		log.Printf("D! Grok no match found for: %q", line)
+		p.Log.Debugf("Grok no match found for: %q", line)
 		return nil, nil
 	}
 
@@ -230,21 +231,21 @@ func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
 		case INT:
 			iv, err := strconv.ParseInt(v, 10, 64)
 			if err != nil {
-				log.Printf("E! Error parsing %s to int: %s", v, err)
+				p.Log.Errorf("Error parsing %s to int: %s", v, err)
 			} else {
 				fields[k] = iv
 			}
 		case FLOAT:
 			fv, err := strconv.ParseFloat(v, 64)
 			if err != nil {
-				log.Printf("E! Error parsing %s to float: %s", v, err)
+				p.Log.Errorf("Error parsing %s to float: %s", v, err)
 			} else {
 				fields[k] = fv
 			}
 		case DURATION:
 			d, err := time.ParseDuration(v)
 			if err != nil {
-				log.Printf("E! Error parsing %s to duration: %s", v, err)
+				p.Log.Errorf("Error parsing %s to duration: %s", v, err)
 			} else {
 				fields[k] = int64(d)
 			}
@@ -255,13 +256,13 @@ func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
 		case EPOCH:
 			parts := strings.SplitN(v, ".", 2)
 			if len(parts) == 0 {
-				log.Printf("E! Error parsing %s to timestamp: %s", v, err)
+				p.Log.Errorf("Error parsing %s to timestamp: %s", v, err)
 				break
 			}
 
 			sec, err := strconv.ParseInt(parts[0], 10, 64)
 			if err != nil {
-				log.Printf("E! Error parsing %s to timestamp: %s", v, err)
+				p.Log.Errorf("Error parsing %s to timestamp: %s", v, err)
 				break
 			}
 			ts := time.Unix(sec, 0)
@@ -271,7 +272,7 @@ func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
 				nsString := strings.Replace(padded[:9], " ", "0", -1)
 				nanosec, err := strconv.ParseInt(nsString, 10, 64)
 				if err != nil {
-					log.Printf("E! Error parsing %s to timestamp: %s", v, err)
+					p.Log.Errorf("Error parsing %s to timestamp: %s", v, err)
 					break
 				}
 				ts = ts.Add(time.Duration(nanosec) * time.Nanosecond)
@@ -280,7 +281,7 @@ func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
 		case EPOCH_NANO:
 			iv, err := strconv.ParseInt(v, 10, 64)
 			if err != nil {
-				log.Printf("E! Error parsing %s to int: %s", v, err)
+				p.Log.Errorf("Error parsing %s to int: %s", v, err)
 			} else {
 				timestamp = time.Unix(0, iv)
 			}
@@ -311,7 +312,7 @@ func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
 			// if we still haven't found a timestamp layout, log it and we will
 			// just use time.Now()
 			if !foundTs {
-				log.Printf("E! Error parsing timestamp [%s], could not find any "+
+				p.Log.Errorf("Error parsing timestamp [%s], could not find any "+
 					"suitable time layouts.", v)
 			}
 		case DROP:
@@ -321,7 +322,7 @@ func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
 			if err == nil {
 				timestamp = ts
 			} else {
-				log.Printf("E! Error parsing %s to time layout [%s]: %s", v, t, err)
+				p.Log.Errorf("Error parsing %s to time layout [%s]: %s", v, t, err)
 			}
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,6 +15,7 @@ var benchM telegraf.Metric
 
 func Benchmark_ParseLine_CommonLogFormat(b *testing.B) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{COMMON_LOG_FORMAT}"},
 	}
 	_ = p.Compile()
@@ -27,6 +29,7 @@ func Benchmark_ParseLine_CommonLogFormat(b *testing.B) {
 
 func Benchmark_ParseLine_CombinedLogFormat(b *testing.B) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{COMBINED_LOG_FORMAT}"},
 	}
 	_ = p.Compile()
@@ -40,6 +43,7 @@ func Benchmark_ParseLine_CombinedLogFormat(b *testing.B) {
 
 func Benchmark_ParseLine_CustomPattern(b *testing.B) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatterns: `
 			DURATION %{NUMBER}[nuµm]?s
@@ -60,6 +64,7 @@ func Benchmark_ParseLine_CustomPattern(b *testing.B) {
 // Test a very simple parse pattern.
 func TestSimpleParse(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TESTLOG}"},
 		CustomPatterns: `
 			TESTLOG %{NUMBER:num:int} %{WORD:client}
@@ -82,6 +87,7 @@ func TestSimpleParse(t *testing.T) {
 // Verify that patterns with a regex lookahead fail at compile time.
 func TestParsePatternsWithLookahead(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{MYLOG}"},
 		CustomPatterns: `
 			NOBOT ((?!bot|crawl).)*
@@ -96,6 +102,7 @@ func TestParsePatternsWithLookahead(t *testing.T) {
 
 func TestMeasurementName(t *testing.T) {
 	p := &Parser{
+		Log:         testutil.Logger{},
 		Measurement: "my_web_log",
 		Patterns:    []string{"%{COMMON_LOG_FORMAT}"},
 	}
@@ -121,6 +128,7 @@ func TestMeasurementName(t *testing.T) {
 
 func TestCLF_IPv6(t *testing.T) {
 	p := &Parser{
+		Log:         testutil.Logger{},
 		Measurement: "my_web_log",
 		Patterns:    []string{"%{COMMON_LOG_FORMAT}"},
 	}
@@ -161,6 +169,7 @@ func TestCLF_IPv6(t *testing.T) {
 
 func TestCustomInfluxdbHttpd(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{`\[httpd\] %{COMBINED_LOG_FORMAT} %{UUID:uuid:drop} %{NUMBER:response_time_us:int}`},
 	}
 	assert.NoError(t, p.Compile())
@@ -208,6 +217,7 @@ func TestCustomInfluxdbHttpd(t *testing.T) {
 // 127.0.0.1 user-identifier frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326
 func TestBuiltinCommonLogFormat(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{COMMON_LOG_FORMAT}"},
 	}
 	assert.NoError(t, p.Compile())
@@ -233,6 +243,7 @@ func TestBuiltinCommonLogFormat(t *testing.T) {
 // 127.0.0.1 user1234 frank1234 [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326
 func TestBuiltinCommonLogFormatWithNumbers(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{COMMON_LOG_FORMAT}"},
 	}
 	assert.NoError(t, p.Compile())
@@ -258,6 +269,7 @@ func TestBuiltinCommonLogFormatWithNumbers(t *testing.T) {
 // 127.0.0.1 user-identifier frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "-" "Mozilla"
 func TestBuiltinCombinedLogFormat(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{COMBINED_LOG_FORMAT}"},
 	}
 	assert.NoError(t, p.Compile())
@@ -283,6 +295,7 @@ func TestBuiltinCombinedLogFormat(t *testing.T) {
 
 func TestCompileStringAndParse(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_A}"},
 		CustomPatterns: `
 			DURATION %{NUMBER}[nuµm]?s
@@ -308,6 +321,7 @@ func TestCompileStringAndParse(t *testing.T) {
 
 func TestCompileErrorsOnInvalidPattern(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatterns: `
 			DURATION %{NUMBER}[nuµm]?s
@@ -324,6 +338,7 @@ func TestCompileErrorsOnInvalidPattern(t *testing.T) {
 
 func TestParsePatternsWithoutCustom(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{POSINT:ts:ts-epochnano} response_time=%{POSINT:response_time:int} mymetric=%{NUMBER:metric:float}"},
 	}
 	assert.NoError(t, p.Compile())
@@ -343,6 +358,7 @@ func TestParsePatternsWithoutCustom(t *testing.T) {
 
 func TestParseEpochNano(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{MYAPP}"},
 		CustomPatterns: `
 			MYAPP %{POSINT:ts:ts-epochnano} response_time=%{POSINT:response_time:int} mymetric=%{NUMBER:metric:float}
@@ -365,6 +381,7 @@ func TestParseEpochNano(t *testing.T) {
 
 func TestParseEpoch(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{MYAPP}"},
 		CustomPatterns: `
 			MYAPP %{POSINT:ts:ts-epoch} response_time=%{POSINT:response_time:int} mymetric=%{NUMBER:metric:float}
@@ -435,6 +452,7 @@ func TestParseEpochDecimal(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			parser := &Parser{
+				Log:      testutil.Logger{},
 				Patterns: []string{"%{NUMBER:ts:ts-epoch} value=%{NUMBER:value:int}"},
 			}
 			assert.NoError(t, parser.Compile())
@@ -458,6 +476,7 @@ func TestParseEpochDecimal(t *testing.T) {
 
 func TestParseEpochErrors(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{MYAPP}"},
 		CustomPatterns: `
 			MYAPP %{WORD:ts:ts-epoch} response_time=%{POSINT:response_time:int} mymetric=%{NUMBER:metric:float}
@@ -469,6 +488,7 @@ func TestParseEpochErrors(t *testing.T) {
 	assert.NoError(t, err)
 
 	p = &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{MYAPP}"},
 		CustomPatterns: `
 			MYAPP %{WORD:ts:ts-epochnano} response_time=%{POSINT:response_time:int} mymetric=%{NUMBER:metric:float}
@@ -482,6 +502,7 @@ func TestParseEpochErrors(t *testing.T) {
 
 func TestParseGenericTimestamp(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{`\[%{HTTPDATE:ts:ts}\] response_time=%{POSINT:response_time:int} mymetric=%{NUMBER:metric:float}`},
 	}
 	assert.NoError(t, p.Compile())
@@ -513,6 +534,7 @@ func TestParseGenericTimestamp(t *testing.T) {
 
 func TestParseGenericTimestampNotFound(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{`\[%{NOTSPACE:ts:ts}\] response_time=%{POSINT:response_time:int} mymetric=%{NUMBER:metric:float}`},
 	}
 	assert.NoError(t, p.Compile())
@@ -531,6 +553,7 @@ func TestParseGenericTimestampNotFound(t *testing.T) {
 
 func TestCompileFileAndParse(t *testing.T) {
 	p := &Parser{
+		Log:                testutil.Logger{},
 		Patterns:           []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatternFiles: []string{"./testdata/test-patterns"},
 	}
@@ -570,6 +593,7 @@ func TestCompileFileAndParse(t *testing.T) {
 
 func TestCompileNoModifiersAndParse(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_C}"},
 		CustomPatterns: `
 			DURATION %{NUMBER}[nuµm]?s
@@ -593,6 +617,7 @@ func TestCompileNoModifiersAndParse(t *testing.T) {
 
 func TestCompileNoNamesAndParse(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_C}"},
 		CustomPatterns: `
 			DURATION %{NUMBER}[nuµm]?s
@@ -608,6 +633,7 @@ func TestCompileNoNamesAndParse(t *testing.T) {
 
 func TestParseNoMatch(t *testing.T) {
 	p := &Parser{
+		Log:                testutil.Logger{},
 		Patterns:           []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatternFiles: []string{"./testdata/test-patterns"},
 	}
@@ -621,6 +647,7 @@ func TestParseNoMatch(t *testing.T) {
 func TestCompileErrors(t *testing.T) {
 	// Compile fails because there are multiple timestamps:
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatterns: `
 			TEST_LOG_A %{HTTPDATE:ts1:ts-httpd} %{HTTPDATE:ts2:ts-httpd} %{NUMBER:mynum:int}
@@ -630,6 +657,7 @@ func TestCompileErrors(t *testing.T) {
 
 	// Compile fails because file doesn't exist:
 	p = &Parser{
+		Log:                testutil.Logger{},
 		Patterns:           []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatternFiles: []string{"/tmp/foo/bar/baz"},
 	}
@@ -639,6 +667,7 @@ func TestCompileErrors(t *testing.T) {
 func TestParseErrors(t *testing.T) {
 	// Parse fails because the pattern doesn't exist
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_B}"},
 		CustomPatterns: `
 			TEST_LOG_A %{HTTPDATE:ts:ts-httpd} %{WORD:myword:int} %{}
@@ -650,6 +679,7 @@ func TestParseErrors(t *testing.T) {
 
 	// Parse fails because myword is not an int
 	p = &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_A}"},
 		CustomPatterns: `
 			TEST_LOG_A %{HTTPDATE:ts:ts-httpd} %{WORD:myword:int}
@@ -661,6 +691,7 @@ func TestParseErrors(t *testing.T) {
 
 	// Parse fails because myword is not a float
 	p = &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_A}"},
 		CustomPatterns: `
 			TEST_LOG_A %{HTTPDATE:ts:ts-httpd} %{WORD:myword:float}
@@ -672,6 +703,7 @@ func TestParseErrors(t *testing.T) {
 
 	// Parse fails because myword is not a duration
 	p = &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_A}"},
 		CustomPatterns: `
 			TEST_LOG_A %{HTTPDATE:ts:ts-httpd} %{WORD:myword:duration}
@@ -683,6 +715,7 @@ func TestParseErrors(t *testing.T) {
 
 	// Parse fails because the time layout is wrong.
 	p = &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TEST_LOG_A}"},
 		CustomPatterns: `
 			TEST_LOG_A %{HTTPDATE:ts:ts-unix} %{WORD:myword:duration}
@@ -761,6 +794,7 @@ func TestTsModder_Rollover(t *testing.T) {
 
 func TestShortPatternRegression(t *testing.T) {
 	p := &Parser{
+		Log:      testutil.Logger{},
 		Patterns: []string{"%{TS_UNIX:timestamp:ts-unix} %{NUMBER:value:int}"},
 		CustomPatterns: `
 		  TS_UNIX %{DAY} %{MONTH} %{MONTHDAY} %{HOUR}:%{MINUTE}:%{SECOND} %{TZ} %{YEAR}
@@ -781,6 +815,7 @@ func TestShortPatternRegression(t *testing.T) {
 
 func TestTimezoneEmptyCompileFileAndParse(t *testing.T) {
 	p := &Parser{
+		Log:                testutil.Logger{},
 		Patterns:           []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatternFiles: []string{"./testdata/test-patterns"},
 		Timezone:           "",
@@ -817,6 +852,7 @@ func TestTimezoneEmptyCompileFileAndParse(t *testing.T) {
 
 func TestTimezoneMalformedCompileFileAndParse(t *testing.T) {
 	p := &Parser{
+		Log:                testutil.Logger{},
 		Patterns:           []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatternFiles: []string{"./testdata/test-patterns"},
 		Timezone:           "Something/Weird",
@@ -853,6 +889,7 @@ func TestTimezoneMalformedCompileFileAndParse(t *testing.T) {
 
 func TestTimezoneEuropeCompileFileAndParse(t *testing.T) {
 	p := &Parser{
+		Log:                testutil.Logger{},
 		Patterns:           []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatternFiles: []string{"./testdata/test-patterns"},
 		Timezone:           "Europe/Berlin",
@@ -889,6 +926,7 @@ func TestTimezoneEuropeCompileFileAndParse(t *testing.T) {
 
 func TestTimezoneAmericasCompileFileAndParse(t *testing.T) {
 	p := &Parser{
+		Log:                testutil.Logger{},
 		Patterns:           []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatternFiles: []string{"./testdata/test-patterns"},
 		Timezone:           "Canada/Eastern",
@@ -925,6 +963,7 @@ func TestTimezoneAmericasCompileFileAndParse(t *testing.T) {
 
 func TestTimezoneLocalCompileFileAndParse(t *testing.T) {
 	p := &Parser{
+		Log:                testutil.Logger{},
 		Patterns:           []string{"%{TEST_LOG_A}", "%{TEST_LOG_B}"},
 		CustomPatternFiles: []string{"./testdata/test-patterns"},
 		Timezone:           "Local",
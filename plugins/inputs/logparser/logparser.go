@@ -4,7 +4,6 @@ package logparser
 
 import (
 	"fmt"
-	"log"
 	"reflect"
 	"strings"
 	"sync"
@@ -50,6 +49,8 @@ type LogParserPlugin struct {
 	sync.Mutex
 
 	GrokParser *grok.Parser `toml:"grok"`
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 const sampleConfig = `
@@ -131,6 +132,10 @@ func (l *LogParserPlugin) Start(acc telegraf.Accumulator) error {
 	l.done = make(chan struct{})
 	l.tailers = make(map[string]*tail.Tail)
 
+	if l.GrokParser != nil {
+		l.GrokParser.Log = l.Log
+	}
+
 	// Looks for fields which implement LogParser interface
 	l.parsers = []LogParser{}
 	s := reflect.ValueOf(l).Elem()
@@ -184,7 +189,7 @@ func (l *LogParserPlugin) tailNewfiles(fromBeginning bool) error {
 	for _, filepath := range l.Files {
 		g, err := globpath.Compile(filepath)
 		if err != nil {
-			log.Printf("E! Error Glob %s failed to compile, %s", filepath, err)
+			l.Log.Errorf("Error Glob %s failed to compile, %s", filepath, err)
 			continue
 		}
 		files := g.Match()
@@ -228,7 +233,7 @@ func (l *LogParserPlugin) receiver(tailer *tail.Tail) {
 	for line = range tailer.Lines {
 
 		if line.Err != nil {
-			log.Printf("E! Error tailing file %s, Error: %s\n",
+			l.Log.Errorf("Error tailing file %s, Error: %s",
 				tailer.Filename, line.Err)
 			continue
 		}
@@ -275,7 +280,7 @@ func (l *LogParserPlugin) parser() {
 					l.acc.AddFields(m.Name(), m.Fields(), tags, m.Time())
 				}
 			} else {
-				log.Println("E! Error parsing log line: " + err.Error())
+				l.Log.Error("Error parsing log line: " + err.Error())
 			}
 		}
 	}
@@ -289,7 +294,7 @@ func (l *LogParserPlugin) Stop() {
 	for _, t := range l.tailers {
 		err := t.Stop()
 		if err != nil {
-			log.Printf("E! Error stopping tail on file %s\n", t.Filename)
+			l.Log.Errorf("Error stopping tail on file %s", t.Filename)
 		}
 		t.Cleanup()
 	}
@@ -16,6 +16,7 @@ import (
 
 func TestStartNoParsers(t *testing.T) {
 	logparser := &LogParserPlugin{
+		Log:           testutil.Logger{},
 		FromBeginning: true,
 		Files:         []string{"grok/testdata/*.log"},
 	}
@@ -32,6 +33,7 @@ func TestGrokParseLogFilesNonExistPattern(t *testing.T) {
 	}
 
 	logparser := &LogParserPlugin{
+		Log:           testutil.Logger{},
 		FromBeginning: true,
 		Files:         []string{thisdir + "grok/testdata/*.log"},
 		GrokParser:    p,
@@ -50,6 +52,7 @@ func TestGrokParseLogFiles(t *testing.T) {
 	}
 
 	logparser := &LogParserPlugin{
+		Log:           testutil.Logger{},
 		FromBeginning: true,
 		Files:         []string{thisdir + "grok/testdata/*.log"},
 		GrokParser:    p,
@@ -97,6 +100,7 @@ func TestGrokParseLogFilesAppearLater(t *testing.T) {
 	}
 
 	logparser := &LogParserPlugin{
+		Log:           testutil.Logger{},
 		FromBeginning: true,
 		Files:         []string{emptydir + "/*.log"},
 		GrokParser:    p,
@@ -137,6 +141,7 @@ func TestGrokParseLogFilesOneBad(t *testing.T) {
 	assert.NoError(t, p.Compile())
 
 	logparser := &LogParserPlugin{
+		Log:           testutil.Logger{},
 		FromBeginning: true,
 		Files:         []string{thisdir + "grok/testdata/test_a.log"},
 		GrokParser:    p,
@@ -5,12 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"regexp"
 	"sync"
 	"time"
+
+	"github.com/influxdata/telegraf"
 )
 
 const (
@@ -23,6 +24,7 @@ var mailchimp_datacenter = regexp.MustCompile("[a-z]+[0-9]+$")
 type ChimpAPI struct {
 	Transport http.RoundTripper
 	Debug     bool
+	Log       telegraf.Logger
 
 	sync.Mutex
 
@@ -53,12 +55,12 @@ func (p *ReportsParams) String() string {
 	return v.Encode()
 }
 
-func NewChimpAPI(apiKey string) *ChimpAPI {
+func NewChimpAPI(apiKey string, log telegraf.Logger) *ChimpAPI {
 	u := &url.URL{}
 	u.Scheme = "https"
 	u.Host = fmt.Sprintf("%s.api.mailchimp.com", mailchimp_datacenter.FindString(apiKey))
 	u.User = url.UserPassword("", apiKey)
-	return &ChimpAPI{url: u}
+	return &ChimpAPI{url: u, Log: log}
 }
 
 type APIError struct {
@@ -134,7 +136,7 @@ func runChimp(api *ChimpAPI, params ReportsParams) ([]byte, error) {
 	req.URL.RawQuery = params.String()
 	req.Header.Set("User-Agent", "Telegraf-MailChimp-Plugin")
 	if api.Debug {
-		log.Printf("D! Request URL: %s", req.URL.String())
+		api.Log.Debugf("Request URL: %s", req.URL.String())
 	}
 
 	resp, err := client.Do(req)
@@ -148,7 +150,7 @@ func runChimp(api *ChimpAPI, params ReportsParams) ([]byte, error) {
 		return nil, err
 	}
 	if api.Debug {
-		log.Printf("D! Response Body:%s", string(body))
+		api.Log.Debugf("Response Body:%s", string(body))
 	}
 
 	if err = chimpErrorCheck(body); err != nil {
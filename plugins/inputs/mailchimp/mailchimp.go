@@ -14,6 +14,8 @@ type MailChimp struct {
 	ApiKey     string
 	DaysOld    int
 	CampaignId string
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 var sampleConfig = `
@@ -37,7 +39,7 @@ func (m *MailChimp) Description() string {
 
 func (m *MailChimp) Gather(acc telegraf.Accumulator) error {
 	if m.api == nil {
-		m.api = NewChimpAPI(m.ApiKey)
+		m.api = NewChimpAPI(m.ApiKey, m.Log)
 	}
 	m.api.Debug = false
 
@@ -28,8 +28,10 @@ func TestMailChimpGatherReports(t *testing.T) {
 	api := &ChimpAPI{
 		url:   u,
 		Debug: true,
+		Log:   testutil.Logger{},
 	}
 	m := MailChimp{
+		Log: testutil.Logger{},
 		api: api,
 	}
 
@@ -91,8 +93,10 @@ func TestMailChimpGatherReport(t *testing.T) {
 	api := &ChimpAPI{
 		url:   u,
 		Debug: true,
+		Log:   testutil.Logger{},
 	}
 	m := MailChimp{
+		Log:        testutil.Logger{},
 		api:        api,
 		CampaignId: "test",
 	}
@@ -156,8 +160,10 @@ func TestMailChimpGatherErroror(t *testing.T) {
 	api := &ChimpAPI{
 		url:   u,
 		Debug: true,
+		Log:   testutil.Logger{},
 	}
 	m := MailChimp{
+		Log:        testutil.Logger{},
 		api:        api,
 		CampaignId: "test",
 	}
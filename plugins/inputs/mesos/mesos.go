@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
-	"log"
 	"net"
 	"net/http"
 	"net/url"
@@ -43,6 +42,8 @@ type Mesos struct {
 	// Use SSL but skip chain & host verification
 	InsecureSkipVerify bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	initialized bool
 	client      *http.Client
 	masterURLs  []*url.URL
@@ -101,7 +102,7 @@ func (m *Mesos) Description() string {
 	return "Telegraf plugin for gathering metrics from N Mesos masters"
 }
 
-func parseURL(s string, role Role) (*url.URL, error) {
+func (m *Mesos) parseURL(s string, role Role) (*url.URL, error) {
 	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
 		host, port, err := net.SplitHostPort(s)
 		// no port specified
@@ -116,7 +117,7 @@ func parseURL(s string, role Role) (*url.URL, error) {
 		}
 
 		s = "http://" + host + ":" + port
-		log.Printf("W! [inputs.mesos] Using %q as connection URL; please update your configuration to use an URL", s)
+		m.Log.Warnf("Using %q as connection URL; please update your configuration to use an URL", s)
 	}
 
 	return url.Parse(s)
@@ -132,7 +133,7 @@ func (m *Mesos) initialize() error {
 	}
 
 	if m.Timeout == 0 {
-		log.Println("I! [inputs.mesos] Missing timeout value, setting default value (100ms)")
+		m.Log.Info("Missing timeout value, setting default value (100ms)")
 		m.Timeout = 100
 	}
 
@@ -140,7 +141,7 @@ func (m *Mesos) initialize() error {
 
 	m.masterURLs = make([]*url.URL, 0, len(m.Masters))
 	for _, master := range m.Masters {
-		u, err := parseURL(master, MASTER)
+		u, err := m.parseURL(master, MASTER)
 		if err != nil {
 			return err
 		}
@@ -151,7 +152,7 @@ func (m *Mesos) initialize() error {
 
 	m.slaveURLs = make([]*url.URL, 0, len(m.Slaves))
 	for _, slave := range m.Slaves {
-		u, err := parseURL(slave, SLAVE)
+		u, err := m.parseURL(slave, SLAVE)
 		if err != nil {
 			return err
 		}
@@ -256,13 +257,13 @@ func metricsDiff(role Role, w []string) []string {
 }
 
 // masterBlocks serves as kind of metrics registry groupping them in sets
-func getMetrics(role Role, group string) []string {
-	var m map[string][]string
+func (m *Mesos) getMetrics(role Role, group string) []string {
+	var metrics map[string][]string
 
-	m = make(map[string][]string)
+	metrics = make(map[string][]string)
 
 	if role == MASTER {
-		m["resources"] = []string{
+		metrics["resources"] = []string{
 			"master/cpus_percent",
 			"master/cpus_used",
 			"master/cpus_total",
@@ -289,12 +290,12 @@ func getMetrics(role Role, group string) []string {
 			"master/mem_revocable_used",
 		}
 
-		m["master"] = []string{
+		metrics["master"] = []string{
 			"master/elected",
 			"master/uptime_secs",
 		}
 
-		m["system"] = []string{
+		metrics["system"] = []string{
 			"system/cpus_total",
 			"system/load_15min",
 			"system/load_5min",
@@ -303,7 +304,7 @@ func getMetrics(role Role, group string) []string {
 			"system/mem_total_bytes",
 		}
 
-		m["agents"] = []string{
+		metrics["agents"] = []string{
 			"master/slave_registrations",
 			"master/slave_removals",
 			"master/slave_reregistrations",
@@ -316,7 +317,7 @@ func getMetrics(role Role, group string) []string {
 			"master/slaves_inactive",
 		}
 
-		m["frameworks"] = []string{
+		metrics["frameworks"] = []string{
 			"master/frameworks_active",
 			"master/frameworks_connected",
 			"master/frameworks_disconnected",
@@ -324,7 +325,7 @@ func getMetrics(role Role, group string) []string {
 			"master/outstanding_offers",
 		}
 
-		m["tasks"] = []string{
+		metrics["tasks"] = []string{
 			"master/tasks_error",
 			"master/tasks_failed",
 			"master/tasks_finished",
@@ -335,7 +336,7 @@ func getMetrics(role Role, group string) []string {
 			"master/tasks_starting",
 		}
 
-		m["messages"] = []string{
+		metrics["messages"] = []string{
 			"master/invalid_executor_to_framework_messages",
 			"master/invalid_framework_to_executor_messages",
 			"master/invalid_status_update_acknowledgements",
@@ -374,13 +375,13 @@ func getMetrics(role Role, group string) []string {
 			"master/valid_executor_to_framework_messages",
 		}
 
-		m["evqueue"] = []string{
+		metrics["evqueue"] = []string{
 			"master/event_queue_dispatches",
 			"master/event_queue_http_requests",
 			"master/event_queue_messages",
 		}
 
-		m["registrar"] = []string{
+		metrics["registrar"] = []string{
 			"registrar/state_fetch_ms",
 			"registrar/state_store_ms",
 			"registrar/state_store_ms/max",
@@ -393,7 +394,7 @@ func getMetrics(role Role, group string) []string {
 			"registrar/state_store_ms/p9999",
 		}
 	} else if role == SLAVE {
-		m["resources"] = []string{
+		metrics["resources"] = []string{
 			"slave/cpus_percent",
 			"slave/cpus_used",
 			"slave/cpus_total",
@@ -420,12 +421,12 @@ func getMetrics(role Role, group string) []string {
 			"slave/mem_revocable_used",
 		}
 
-		m["agent"] = []string{
+		metrics["agent"] = []string{
 			"slave/registered",
 			"slave/uptime_secs",
 		}
 
-		m["system"] = []string{
+		metrics["system"] = []string{
 			"system/cpus_total",
 			"system/load_15min",
 			"system/load_5min",
@@ -434,7 +435,7 @@ func getMetrics(role Role, group string) []string {
 			"system/mem_total_bytes",
 		}
 
-		m["executors"] = []string{
+		metrics["executors"] = []string{
 			"containerizer/mesos/container_destroy_errors",
 			"slave/container_launch_errors",
 			"slave/executors_preempted",
@@ -447,7 +448,7 @@ func getMetrics(role Role, group string) []string {
 			"slave/recovery_errors",
 		}
 
-		m["tasks"] = []string{
+		metrics["tasks"] = []string{
 			"slave/tasks_failed",
 			"slave/tasks_finished",
 			"slave/tasks_killed",
@@ -457,7 +458,7 @@ func getMetrics(role Role, group string) []string {
 			"slave/tasks_starting",
 		}
 
-		m["messages"] = []string{
+		metrics["messages"] = []string{
 			"slave/invalid_framework_messages",
 			"slave/invalid_status_updates",
 			"slave/valid_framework_messages",
@@ -465,10 +466,10 @@ func getMetrics(role Role, group string) []string {
 		}
 	}
 
-	ret, ok := m[group]
+	ret, ok := metrics[group]
 
 	if !ok {
-		log.Printf("I! [mesos] Unknown %s metrics group: %s\n", role, group)
+		m.Log.Infof("Unknown %s metrics group: %s", role, group)
 		return []string{}
 	}
 
@@ -486,7 +487,7 @@ func (m *Mesos) filterMetrics(role Role, metrics *map[string]interface{}) {
 	}
 
 	for _, k := range metricsDiff(role, selectedMetrics) {
-		for _, v := range getMetrics(role, k) {
+		for _, v := range m.getMetrics(role, k) {
 			if _, ok = (*metrics)[v]; ok {
 				delete((*metrics), v)
 			}
@@ -280,6 +280,7 @@ func TestMesosMaster(t *testing.T) {
 	var acc testutil.Accumulator
 
 	m := Mesos{
+		Log:     testutil.Logger{},
 		Masters: []string{masterTestServer.Listener.Addr().String()},
 		Timeout: 10,
 	}
@@ -295,6 +296,7 @@ func TestMesosMaster(t *testing.T) {
 
 func TestMasterFilter(t *testing.T) {
 	m := Mesos{
+		Log: testutil.Logger{},
 		MasterCols: []string{
 			"resources", "master", "registrar",
 		},
@@ -307,14 +309,14 @@ func TestMasterFilter(t *testing.T) {
 	m.filterMetrics(MASTER, &masterMetrics)
 
 	for _, v := range b {
-		for _, x := range getMetrics(MASTER, v) {
+		for _, x := range m.getMetrics(MASTER, v) {
 			if _, ok := masterMetrics[x]; ok {
 				t.Errorf("Found key %s, it should be gone.", x)
 			}
 		}
 	}
 	for _, v := range m.MasterCols {
-		for _, x := range getMetrics(MASTER, v) {
+		for _, x := range m.getMetrics(MASTER, v) {
 			if _, ok := masterMetrics[x]; !ok {
 				t.Errorf("Didn't find key %s, it should present.", x)
 			}
@@ -326,6 +328,7 @@ func TestMesosSlave(t *testing.T) {
 	var acc testutil.Accumulator
 
 	m := Mesos{
+		Log:     testutil.Logger{},
 		Masters: []string{},
 		Slaves:  []string{slaveTestServer.Listener.Addr().String()},
 		// SlaveTasks: true,
@@ -355,6 +358,7 @@ func TestMesosSlave(t *testing.T) {
 
 func TestSlaveFilter(t *testing.T) {
 	m := Mesos{
+		Log: testutil.Logger{},
 		SlaveCols: []string{
 			"resources", "agent", "tasks",
 		},
@@ -366,14 +370,14 @@ func TestSlaveFilter(t *testing.T) {
 	m.filterMetrics(SLAVE, &slaveMetrics)
 
 	for _, v := range b {
-		for _, x := range getMetrics(SLAVE, v) {
+		for _, x := range m.getMetrics(SLAVE, v) {
 			if _, ok := slaveMetrics[x]; ok {
 				t.Errorf("Found key %s, it should be gone.", x)
 			}
 		}
 	}
 	for _, v := range m.MasterCols {
-		for _, x := range getMetrics(SLAVE, v) {
+		for _, x := range m.getMetrics(SLAVE, v) {
 			if _, ok := slaveMetrics[x]; !ok {
 				t.Errorf("Didn't find key %s, it should present.", x)
 			}
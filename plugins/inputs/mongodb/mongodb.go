@@ -4,7 +4,6 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
-	"log"
 	"net"
 	"net/url"
 	"strings"
@@ -31,6 +30,8 @@ type MongoDB struct {
 	SSLKey string `toml:"ssl_key"`
 	// Use SSL but skip chain & host verification
 	InsecureSkipVerify bool
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 type Ssl struct {
@@ -79,7 +80,7 @@ func (m *MongoDB) Gather(acc telegraf.Accumulator) error {
 			// Preserve backwards compatibility for hostnames without a
 			// scheme, broken in go 1.8. Remove in Telegraf 2.0
 			serv = "mongodb://" + serv
-			log.Printf("W! [inputs.mongodb] Using %q as connection URL; please update your configuration to use an URL", serv)
+			m.Log.Warnf("Using %q as connection URL; please update your configuration to use an URL", serv)
 			m.Servers[i] = serv
 		}
 
@@ -108,6 +109,7 @@ func (m *MongoDB) getMongoServer(url *url.URL) *Server {
 	if _, ok := m.mongos[url.Host]; !ok {
 		m.mongos[url.Host] = &Server{
 			Url: url,
+			Log: m.Log,
 		}
 	}
 	return m.mongos[url.Host]
@@ -1,7 +1,6 @@
 package mongodb
 
 import (
-	"log"
 	"net/url"
 	"time"
 
@@ -13,6 +12,7 @@ import (
 type Server struct {
 	Url        *url.URL
 	Session    *mgo.Session
+	Log        telegraf.Logger
 	lastResult *MongoStatus
 }
 
@@ -61,7 +61,7 @@ func (s *Server) gatherData(acc telegraf.Accumulator, gatherDbStats bool) error
 		names := []string{}
 		names, err = s.Session.DatabaseNames()
 		if err != nil {
-			log.Println("E! Error getting database names (" + err.Error() + ")")
+			s.Log.Error("Error getting database names (" + err.Error() + ")")
 		}
 		for _, db_name := range names {
 			db_stat_line := &DbStatsData{}
@@ -72,7 +72,7 @@ func (s *Server) gatherData(acc telegraf.Accumulator, gatherDbStats bool) error
 				},
 			}, db_stat_line)
 			if err != nil {
-				log.Println("E! Error getting db stats from " + db_name + "(" + err.Error() + ")")
+				s.Log.Error("Error getting db stats from " + db_name + "(" + err.Error() + ")")
 			}
 			db := &Db{
 				Name:        db_name,
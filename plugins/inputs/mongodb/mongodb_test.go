@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"gopkg.in/mgo.v2"
+
+	"github.com/influxdata/telegraf/testutil"
 )
 
 var connect_url string
@@ -20,13 +22,13 @@ func init() {
 	connect_url = os.Getenv("MONGODB_URL")
 	if connect_url == "" {
 		connect_url = "127.0.0.1:27017"
-		server = &Server{Url: &url.URL{Host: connect_url}}
+		server = &Server{Url: &url.URL{Host: connect_url}, Log: testutil.Logger{}}
 	} else {
 		full_url, err := url.Parse(connect_url)
 		if err != nil {
 			log.Fatalf("Unable to parse URL (%s), %s\n", full_url, err.Error())
 		}
-		server = &Server{Url: full_url}
+		server = &Server{Url: full_url, Log: testutil.Logger{}}
 	}
 }
 
@@ -2,7 +2,6 @@ package mqtt_consumer
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 	"time"
@@ -43,6 +42,8 @@ type MQTTConsumer struct {
 	// Use SSL but skip chain & host verification
 	InsecureSkipVerify bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	sync.Mutex
 	client mqtt.Client
 	// channel of all incoming raw mqtt messages
@@ -145,7 +146,7 @@ func (m *MQTTConsumer) Start(acc telegraf.Accumulator) error {
 func (m *MQTTConsumer) connect() error {
 	if token := m.client.Connect(); token.Wait() && token.Error() != nil {
 		err := token.Error()
-		log.Printf("D! MQTT Consumer, connection error - %v", err)
+		m.Log.Debugf("MQTT Consumer, connection error - %v", err)
 
 		return err
 	}
@@ -156,7 +157,7 @@ func (m *MQTTConsumer) connect() error {
 }
 
 func (m *MQTTConsumer) onConnect(c mqtt.Client) {
-	log.Printf("I! MQTT Client Connected")
+	m.Log.Infof("MQTT Client Connected")
 	if !m.PersistentSession || !m.connected {
 		topics := make(map[string]byte)
 		for _, topic := range m.Topics {
@@ -262,7 +263,7 @@ func (m *MQTTConsumer) createOpts() (*mqtt.ClientOptions, error) {
 	for _, server := range m.Servers {
 		// Preserve support for host:port style servers; deprecated in Telegraf 1.4.4
 		if !strings.Contains(server, "://") {
-			log.Printf("W! mqtt_consumer server %q should be updated to use `scheme://host:port` format", server)
+			m.Log.Warnf("mqtt_consumer server %q should be updated to use `scheme://host:port` format", server)
 			if tlsCfg == nil {
 				server = "tcp://" + server
 			} else {
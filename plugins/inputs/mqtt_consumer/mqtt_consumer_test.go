@@ -22,6 +22,7 @@ const (
 func newTestMQTTConsumer() (*MQTTConsumer, chan mqtt.Message) {
 	in := make(chan mqtt.Message, 100)
 	n := &MQTTConsumer{
+		Log:       testutil.Logger{},
 		Topics:    []string{"telegraf"},
 		Servers:   []string{"localhost:1883"},
 		in:        in,
@@ -35,11 +36,13 @@ func newTestMQTTConsumer() (*MQTTConsumer, chan mqtt.Message) {
 // Test that default client has random ID
 func TestRandomClientID(t *testing.T) {
 	m1 := &MQTTConsumer{
+		Log:     testutil.Logger{},
 		Servers: []string{"localhost:1883"}}
 	opts, err := m1.createOpts()
 	assert.NoError(t, err)
 
 	m2 := &MQTTConsumer{
+		Log:     testutil.Logger{},
 		Servers: []string{"localhost:1883"}}
 	opts2, err2 := m2.createOpts()
 	assert.NoError(t, err2)
@@ -50,6 +53,7 @@ func TestRandomClientID(t *testing.T) {
 // Test that default client has random ID
 func TestClientID(t *testing.T) {
 	m1 := &MQTTConsumer{
+		Log:      testutil.Logger{},
 		Servers:  []string{"localhost:1883"},
 		ClientID: "telegraf-test",
 	}
@@ -57,6 +61,7 @@ func TestClientID(t *testing.T) {
 	assert.NoError(t, err)
 
 	m2 := &MQTTConsumer{
+		Log:      testutil.Logger{},
 		Servers:  []string{"localhost:1883"},
 		ClientID: "telegraf-test",
 	}
@@ -70,6 +75,7 @@ func TestClientID(t *testing.T) {
 // Test that Start() fails if client ID is not set but persistent is
 func TestPersistentClientIDFail(t *testing.T) {
 	m1 := &MQTTConsumer{
+		Log:               testutil.Logger{},
 		Servers:           []string{"localhost:1883"},
 		PersistentSession: true,
 	}
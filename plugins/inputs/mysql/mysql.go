@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"database/sql"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"sync"
@@ -40,6 +39,8 @@ type Mysql struct {
 	SSLCA                               string   `toml:"ssl_ca"`
 	SSLCert                             string   `toml:"ssl_cert"`
 	SSLKey                              string   `toml:"ssl_key"`
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 var sampleConfig = `
@@ -682,7 +683,7 @@ func (m *Mysql) gatherGlobalStatuses(db *sql.DB, serv string, acc telegraf.Accum
 	if m.GatherProcessList {
 		conn_rows, err := db.Query("SELECT user, sum(1) FROM INFORMATION_SCHEMA.PROCESSLIST GROUP BY user")
 		if err != nil {
-			log.Printf("E! MySQL Error gathering process list: %s", err)
+			m.Log.Errorf("Error gathering process list: %s", err)
 		} else {
 			for conn_rows.Next() {
 				var user string
@@ -709,7 +710,7 @@ func (m *Mysql) gatherGlobalStatuses(db *sql.DB, serv string, acc telegraf.Accum
 	if m.GatherUserStatistics {
 		conn_rows, err := db.Query("select user, total_connections, concurrent_connections, connected_time, busy_time, cpu_time, bytes_received, bytes_sent, binlog_bytes_written, rows_fetched, rows_updated, table_rows_read, select_commands, update_commands, other_commands, commit_transactions, rollback_transactions, denied_connections, lost_connections, access_denied, empty_queries, total_ssl_connections FROM INFORMATION_SCHEMA.USER_STATISTICS GROUP BY user")
 		if err != nil {
-			log.Printf("E! MySQL Error gathering user stats: %s", err)
+			m.Log.Errorf("Error gathering user stats: %s", err)
 		} else {
 			for conn_rows.Next() {
 				var user string
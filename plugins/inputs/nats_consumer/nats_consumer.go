@@ -2,7 +2,6 @@ package natsconsumer
 
 import (
 	"fmt"
-	"log"
 	"sync"
 
 	"github.com/influxdata/telegraf"
@@ -42,6 +41,8 @@ type natsConsumer struct {
 	Conn *nats.Conn
 	Subs []*nats.Subscription
 
+	Log telegraf.Logger `toml:"-"`
+
 	// channel for all incoming NATS messages
 	in chan *nats.Msg
 	// channel for all NATS read errors
@@ -147,7 +148,7 @@ func (n *natsConsumer) Start(acc telegraf.Accumulator) error {
 	// Start the message reader
 	n.wg.Add(1)
 	go n.receiver()
-	log.Printf("I! Started the NATS consumer service, nats: %v, subjects: %v, queue: %v\n",
+	n.Log.Infof("Started the NATS consumer service, nats: %v, subjects: %v, queue: %v",
 		n.Conn.ConnectedUrl(), n.Subjects, n.QueueGroup)
 
 	return nil
@@ -20,6 +20,7 @@ const (
 func newTestNatsConsumer() (*natsConsumer, chan *nats.Msg) {
 	in := make(chan *nats.Msg, metricBuffer)
 	n := &natsConsumer{
+		Log:        testutil.Logger{},
 		QueueGroup: "test",
 		Subjects:   []string{"telegraf"},
 		Servers:    []string{"nats://localhost:4222"},
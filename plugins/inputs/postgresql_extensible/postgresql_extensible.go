@@ -3,7 +3,6 @@ package postgresql_extensible
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"strings"
 
 	// register in driver.
@@ -27,6 +26,8 @@ type Postgresql struct {
 		Measurement string
 	}
 	Debug bool
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 type query []struct {
@@ -241,7 +242,7 @@ func (p *Postgresql) accRow(meas_name string, row scanner, acc telegraf.Accumula
 	fields := make(map[string]interface{})
 COLUMN:
 	for col, val := range columnMap {
-		log.Printf("D! postgresql_extensible: column: %s = %T: %v\n", col, *val, *val)
+		p.Log.Debugf("column: %s = %T: %v", col, *val, *val)
 		_, ignore := ignoredColumns[col]
 		if ignore || *val == nil {
 			continue
@@ -259,7 +260,7 @@ COLUMN:
 			case int64, int32, int:
 				tags[col] = fmt.Sprintf("%d", v)
 			default:
-				log.Println("failed to add additional tag", col)
+				p.Log.Warnf("Failed to add additional tag %s", col)
 			}
 			continue COLUMN
 		}
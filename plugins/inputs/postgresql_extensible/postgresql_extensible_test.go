@@ -12,6 +12,7 @@ import (
 
 func queryRunner(t *testing.T, q query) *testutil.Accumulator {
 	p := &Postgresql{
+		Log: testutil.Logger{},
 		Service: postgresql.Service{
 			Address: fmt.Sprintf(
 				"host=%s user=postgres sslmode=disable",
@@ -205,6 +206,7 @@ func TestPostgresqlIgnoresUnwantedColumns(t *testing.T) {
 	}
 
 	p := &Postgresql{
+		Log: testutil.Logger{},
 		Service: postgresql.Service{
 			Address: fmt.Sprintf(
 				"host=%s user=postgres sslmode=disable",
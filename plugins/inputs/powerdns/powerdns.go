@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"strconv"
 	"strings"
@@ -16,6 +15,8 @@ import (
 
 type Powerdns struct {
 	UnixSockets []string
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 var sampleConfig = `
@@ -87,7 +88,7 @@ func (p *Powerdns) gatherServer(address string, acc telegraf.Accumulator) error
 	metrics := string(buf)
 
 	// Process data
-	fields := parseResponse(metrics)
+	fields := p.parseResponse(metrics)
 
 	// Add server socket as a tag
 	tags := map[string]string{"server": address}
@@ -97,7 +98,7 @@ func (p *Powerdns) gatherServer(address string, acc telegraf.Accumulator) error
 	return nil
 }
 
-func parseResponse(metrics string) map[string]interface{} {
+func (p *Powerdns) parseResponse(metrics string) map[string]interface{} {
 	values := make(map[string]interface{})
 
 	s := strings.Split(metrics, ",")
@@ -110,7 +111,7 @@ func parseResponse(metrics string) map[string]interface{} {
 
 		i, err := strconv.ParseInt(m[1], 10, 64)
 		if err != nil {
-			log.Printf("E! powerdns: Error parsing integer for metric [%s]: %s",
+			p.Log.Errorf("Error parsing integer for metric [%s]: %s",
 				metric, err)
 			continue
 		}
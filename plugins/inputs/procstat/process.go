@@ -1,10 +1,16 @@
 package procstat
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/net"
 	"github.com/shirou/gopsutil/process"
 )
 
@@ -21,6 +27,8 @@ type Process interface {
 	Percent(interval time.Duration) (float64, error)
 	Times() (*cpu.TimesStat, error)
 	RlimitUsage(bool) ([]process.RlimitStat, error)
+	Connections() ([]net.ConnectionStat, error)
+	Cgroup() (string, error)
 }
 
 type PIDFinder interface {
@@ -66,3 +74,46 @@ func (p *Proc) Percent(interval time.Duration) (float64, error) {
 	}
 	return cpu_perc, err
 }
+
+// Cgroup returns the cgroup of the process, from /proc/<pid>/cgroup.
+func (p *Proc) Cgroup() (string, error) {
+	procPath := os.Getenv("HOST_PROC")
+	if procPath == "" {
+		procPath = "/proc"
+	}
+	data, err := ioutil.ReadFile(filepath.Join(procPath, strconv.Itoa(int(p.Pid)), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	return parseCgroup(data)
+}
+
+// parseCgroup returns the cgroup of the unified hierarchy, or with cgroups v1
+// the cgroup of the systemd hierarchy, falling back to the first hierarchy.
+func parseCgroup(data []byte) (string, error) {
+	var first, systemd string
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := bytes.SplitN(line, []byte{':'}, 3)
+		if len(parts) != 3 {
+			continue
+		}
+		path := string(parts[2])
+		if string(parts[0]) == "0" && len(parts[1]) == 0 {
+			return path, nil
+		}
+		if string(parts[1]) == "name=systemd" {
+			systemd = path
+		}
+		if first == "" {
+			first = path
+		}
+	}
+	if systemd != "" {
+		return systemd, nil
+	}
+	if first != "" {
+		return first, nil
+	}
+	return "", fmt.Errorf("no cgroup found")
+}
@@ -6,11 +6,15 @@ import (
 	"io/ioutil"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/shirou/gopsutil/net"
 	"github.com/shirou/gopsutil/process"
 )
 
@@ -33,6 +37,13 @@ type Procstat struct {
 	CGroup      string `toml:"cgroup"`
 	PidTag      bool
 
+	// Gather the listening ports and the connections of the processes
+	GatherSockets bool `toml:"gather_sockets"`
+	// Tag the processes with their cgroup
+	CgroupTag bool `toml:"cgroup_tag"`
+	// Sum the fields of all the processes matched into a single metric
+	Aggregate bool `toml:"aggregate"`
+
 	finder PIDFinder
 
 	createPIDFinder func() (PIDFinder, error)
@@ -66,6 +77,20 @@ var sampleConfig = `
   ## of series, use judiciously.
   # pid_tag = false
 
+  ## Gather the listening TCP and UDP ports and the number of connections of
+  ## the processes.  The sockets of the processes of other users are only
+  ## visible to root.
+  # gather_sockets = false
+
+  ## Add the cgroup of the processes as the process_cgroup tag.
+  # cgroup_tag = false
+
+  ## Sum the fields of all the processes matched into a single metric, with
+  ## the number of processes in the pid_count field, rather than reporting
+  ## each process.  The resource limits, which are per process, are not
+  ## reported.
+  # aggregate = false
+
   ## Method to use when finding process IDs.  Can be one of 'pgrep', or
   ## 'native'.  The pgrep finder calls the pgrep executable in the PATH while
   ## the native finder performs the search directly in a manor dependent on the
@@ -97,13 +122,20 @@ func (p *Procstat) Gather(acc telegraf.Accumulator) error {
 		p.createProcess = defaultProcess
 	}
 
-	procs, err := p.updateProcesses(p.procs)
+	procs, tags, err := p.updateProcesses(p.procs)
 	if err != nil {
 		acc.AddError(fmt.Errorf("E! Error: procstat getting process, exe: [%s] pidfile: [%s] pattern: [%s] user: [%s] %s",
 			p.Exe, p.PidFile, p.Pattern, p.User, err.Error()))
 	}
 	p.procs = procs
 
+	if p.Aggregate {
+		if err == nil {
+			p.addAggregateMetrics(p.procs, tags, acc)
+		}
+		return nil
+	}
+
 	for _, proc := range p.procs {
 		p.addMetrics(proc, acc)
 	}
@@ -113,6 +145,110 @@ func (p *Procstat) Gather(acc telegraf.Accumulator) error {
 
 // Add metrics a single Process
 func (p *Procstat) addMetrics(proc Process, acc telegraf.Accumulator) {
+	acc.AddFields("procstat", p.processFields(proc), proc.Tags())
+}
+
+// addAggregateMetrics adds a single metric summing the fields of the
+// processes, tagged with the tags common to all of them.
+func (p *Procstat) addAggregateMetrics(procs map[PID]Process, tags map[string]string, acc telegraf.Accumulator) {
+	var prefix string
+	if p.Prefix != "" {
+		prefix = p.Prefix + "_"
+	}
+
+	fields := map[string]interface{}{}
+	var common map[string]string
+	for _, proc := range procs {
+		for k, v := range p.processFields(proc) {
+			// the pid and the limits are per process
+			if k == "pid" || strings.HasPrefix(k, prefix+"rlimit_") {
+				continue
+			}
+			addField(fields, k, v)
+		}
+
+		if common == nil {
+			common = make(map[string]string, len(proc.Tags()))
+			for k, v := range proc.Tags() {
+				common[k] = v
+			}
+			continue
+		}
+		for k, v := range common {
+			if proc.Tags()[k] != v {
+				delete(common, k)
+			}
+		}
+	}
+	fields["pid_count"] = len(procs)
+
+	aggTags := make(map[string]string, len(tags)+len(common))
+	for k, v := range common {
+		aggTags[k] = v
+	}
+	for k, v := range tags {
+		aggTags[k] = v
+	}
+	acc.AddFields("procstat", fields, aggTags)
+}
+
+// addField adds the value of a field to the sum of the field, the ports are
+// merged. The integers are summed as int64, or as uint64 while all of them
+// are unsigned, as a field may have a different type for each process, ie,
+// num_fds is an int32 unless it is read with the limits.
+func addField(fields map[string]interface{}, key string, value interface{}) {
+	value = normalizeField(value)
+	sum, ok := fields[key]
+	if !ok {
+		fields[key] = value
+		return
+	}
+
+	switch v := value.(type) {
+	case int64:
+		switch s := sum.(type) {
+		case int64:
+			fields[key] = s + v
+		case uint64:
+			fields[key] = int64(s) + v
+		}
+	case uint64:
+		switch s := sum.(type) {
+		case int64:
+			fields[key] = s + int64(v)
+		case uint64:
+			fields[key] = s + v
+		}
+	case float64:
+		if s, ok := sum.(float64); ok {
+			fields[key] = s + v
+		}
+	case string:
+		if s, ok := sum.(string); ok {
+			fields[key] = mergePorts(s, v)
+		}
+	}
+}
+
+// normalizeField converts the numbers to int64, uint64 or float64.
+func normalizeField(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+// Fields of a single Process
+func (p *Procstat) processFields(proc Process) map[string]interface{} {
 	var prefix string
 	if p.Prefix != "" {
 		prefix = p.Prefix + "_"
@@ -128,6 +264,13 @@ func (p *Procstat) addMetrics(proc Process, acc telegraf.Accumulator) {
 		}
 	}
 
+	if _, cgroupInTags := proc.Tags()["process_cgroup"]; p.CgroupTag && !cgroupInTags {
+		cgroup, err := proc.Cgroup()
+		if err == nil {
+			proc.Tags()["process_cgroup"] = cgroup
+		}
+	}
+
 	//If pid is not present as a tag, include it as a field.
 	if _, pidInTags := proc.Tags()["pid"]; !pidInTags {
 		fields["pid"] = int32(proc.PID())
@@ -226,14 +369,85 @@ func (p *Procstat) addMetrics(proc Process, acc telegraf.Accumulator) {
 		}
 	}
 
-	acc.AddFields("procstat", fields, proc.Tags())
+	if p.GatherSockets {
+		conns, err := proc.Connections()
+		if err == nil {
+			for k, v := range socketFields(conns) {
+				fields[prefix+k] = v
+			}
+		}
+	}
+
+	return fields
 }
 
-// Update monitored Processes
-func (p *Procstat) updateProcesses(prevInfo map[PID]Process) (map[PID]Process, error) {
+// socketFields returns the listening ports and the number of sockets of a
+// process.  UDP sockets without a remote address are listening.
+func socketFields(conns []net.ConnectionStat) map[string]interface{} {
+	var tcpListen, tcpConnections, udpSockets int64
+	var tcpPorts, udpPorts []uint32
+	for _, conn := range conns {
+		// unix sockets have the same types
+		if conn.Family != syscall.AF_INET && conn.Family != syscall.AF_INET6 {
+			continue
+		}
+		switch conn.Type {
+		case syscall.SOCK_STREAM:
+			if conn.Status == "LISTEN" {
+				tcpListen++
+				tcpPorts = append(tcpPorts, conn.Laddr.Port)
+			} else {
+				tcpConnections++
+			}
+		case syscall.SOCK_DGRAM:
+			udpSockets++
+			if conn.Raddr.Port == 0 && conn.Laddr.Port != 0 {
+				udpPorts = append(udpPorts, conn.Laddr.Port)
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"num_tcp_listen":      tcpListen,
+		"num_tcp_connections": tcpConnections,
+		"num_udp_sockets":     udpSockets,
+		"tcp_listen_ports":    formatPorts(tcpPorts),
+		"udp_listen_ports":    formatPorts(udpPorts),
+	}
+}
+
+// formatPorts returns the sorted list of distinct ports, separated by commas.
+func formatPorts(ports []uint32) string {
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	strs := make([]string, 0, len(ports))
+	for i, port := range ports {
+		if i > 0 && port == ports[i-1] {
+			continue
+		}
+		strs = append(strs, strconv.FormatUint(uint64(port), 10))
+	}
+	return strings.Join(strs, ",")
+}
+
+// mergePorts merges two lists of ports formatted by formatPorts.
+func mergePorts(a, b string) string {
+	var ports []uint32
+	for _, list := range []string{a, b} {
+		for _, s := range strings.Split(list, ",") {
+			port, err := strconv.ParseUint(s, 10, 32)
+			if err == nil {
+				ports = append(ports, uint32(port))
+			}
+		}
+	}
+	return formatPorts(ports)
+}
+
+// Update monitored Processes, returning the tags of the processes found
+func (p *Procstat) updateProcesses(prevInfo map[PID]Process) (map[PID]Process, map[string]string, error) {
 	pids, tags, err := p.findPids()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	procs := make(map[PID]Process, len(prevInfo))
@@ -264,7 +478,7 @@ func (p *Procstat) updateProcesses(prevInfo map[PID]Process) (map[PID]Process, e
 			}
 		}
 	}
-	return procs, nil
+	return procs, tags, nil
 }
 
 // Create and return PIDGatherer lazily
@@ -8,11 +8,13 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/influxdata/telegraf/testutil"
 	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/net"
 	"github.com/shirou/gopsutil/process"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -144,6 +146,34 @@ func (p *testProc) RlimitUsage(gatherUsage bool) ([]process.RlimitStat, error) {
 	return []process.RlimitStat{}, nil
 }
 
+func (p *testProc) Connections() ([]net.ConnectionStat, error) {
+	return []net.ConnectionStat{
+		{Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "LISTEN",
+			Laddr: net.Addr{IP: "0.0.0.0", Port: 8080}},
+		{Family: syscall.AF_INET6, Type: syscall.SOCK_STREAM, Status: "LISTEN",
+			Laddr: net.Addr{IP: "::", Port: 8080}},
+		{Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "LISTEN",
+			Laddr: net.Addr{IP: "127.0.0.1", Port: 443}},
+		{Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "ESTABLISHED",
+			Laddr: net.Addr{IP: "10.0.0.1", Port: 8080}, Raddr: net.Addr{IP: "10.0.0.2", Port: 51234}},
+		{Family: syscall.AF_INET, Type: syscall.SOCK_DGRAM, Status: "NONE",
+			Laddr: net.Addr{IP: "0.0.0.0", Port: 53}},
+		{Family: syscall.AF_INET, Type: syscall.SOCK_DGRAM, Status: "NONE",
+			Laddr: net.Addr{IP: "10.0.0.1", Port: 40000}, Raddr: net.Addr{IP: "10.0.0.3", Port: 53}},
+		// unix sockets are not counted
+		{Family: syscall.AF_UNIX, Type: syscall.SOCK_STREAM, Status: "LISTEN",
+			Laddr: net.Addr{IP: "/run/foo.sock"}},
+		{Family: syscall.AF_UNIX, Type: syscall.SOCK_STREAM, Status: "NONE",
+			Raddr: net.Addr{IP: "/run/systemd/journal/stdout"}},
+		{Family: syscall.AF_UNIX, Type: syscall.SOCK_DGRAM, Status: "NONE",
+			Raddr: net.Addr{IP: "/dev/log"}},
+	}, nil
+}
+
+func (p *testProc) Cgroup() (string, error) {
+	return "/system.slice/foo.service", nil
+}
+
 var pid PID = PID(42)
 var exe string = "foo"
 
@@ -369,3 +399,153 @@ func TestGather_cgroupPIDs(t *testing.T) {
 	assert.Equal(t, []PID{1234, 5678}, pids)
 	assert.Equal(t, td, tags["cgroup"])
 }
+
+func TestGather_Sockets(t *testing.T) {
+	var acc testutil.Accumulator
+
+	p := Procstat{
+		Exe:             exe,
+		GatherSockets:   true,
+		createPIDFinder: pidFinder([]PID{pid}, nil),
+		createProcess:   newTestProc,
+	}
+	require.NoError(t, acc.GatherError(p.Gather))
+
+	fields := acc.Metrics[0].Fields
+	assert.Equal(t, int64(3), fields["num_tcp_listen"])
+	assert.Equal(t, int64(1), fields["num_tcp_connections"])
+	assert.Equal(t, int64(2), fields["num_udp_sockets"])
+	assert.Equal(t, "443,8080", fields["tcp_listen_ports"])
+	assert.Equal(t, "53", fields["udp_listen_ports"])
+}
+
+func TestGather_CgroupTag(t *testing.T) {
+	var acc testutil.Accumulator
+
+	p := Procstat{
+		Exe:             exe,
+		CgroupTag:       true,
+		createPIDFinder: pidFinder([]PID{pid}, nil),
+		createProcess:   newTestProc,
+	}
+	require.NoError(t, acc.GatherError(p.Gather))
+
+	assert.Equal(t, "/system.slice/foo.service", acc.TagValue("procstat", "process_cgroup"))
+}
+
+func TestGather_Aggregate(t *testing.T) {
+	var acc testutil.Accumulator
+
+	p := Procstat{
+		Pattern:         "foo",
+		PidTag:          true,
+		GatherSockets:   true,
+		Aggregate:       true,
+		createPIDFinder: pidFinder([]PID{1, 2, 3}, nil),
+		createProcess: func(pid PID) (Process, error) {
+			return &testProc{pid: pid, tags: make(map[string]string)}, nil
+		},
+	}
+	require.NoError(t, acc.GatherError(p.Gather))
+
+	require.Len(t, acc.Metrics, 1)
+	m := acc.Metrics[0]
+	assert.Equal(t, map[string]string{
+		"pattern":      "foo",
+		"process_name": "test_proc",
+	}, m.Tags)
+	assert.Equal(t, 3, m.Fields["pid_count"])
+	assert.Equal(t, int64(9), m.Fields["num_tcp_listen"])
+	assert.Equal(t, int64(6), m.Fields["num_udp_sockets"])
+	assert.Equal(t, "443,8080", m.Fields["tcp_listen_ports"])
+	assert.Contains(t, m.Fields, "num_fds")
+	assert.NotContains(t, m.Fields, "pid")
+}
+
+func TestGather_AggregateNoProcesses(t *testing.T) {
+	var acc testutil.Accumulator
+
+	p := Procstat{
+		Pattern:         "foo",
+		Aggregate:       true,
+		createPIDFinder: pidFinder([]PID{}, nil),
+		createProcess:   newTestProc,
+	}
+	require.NoError(t, acc.GatherError(p.Gather))
+
+	acc.AssertContainsTaggedFields(t, "procstat",
+		map[string]interface{}{"pid_count": 0},
+		map[string]string{"pattern": "foo"})
+}
+
+func TestAddField(t *testing.T) {
+	fields := map[string]interface{}{}
+	addField(fields, "num_fds", int32(3))
+	addField(fields, "num_fds", int32(4))
+	addField(fields, "read_bytes", uint64(10))
+	addField(fields, "read_bytes", uint64(5))
+	addField(fields, "cpu_usage", 1.5)
+	addField(fields, "cpu_usage", 2.0)
+	addField(fields, "tcp_listen_ports", "80,443")
+	addField(fields, "tcp_listen_ports", "")
+	addField(fields, "tcp_listen_ports", "22,80")
+
+	assert.Equal(t, map[string]interface{}{
+		"num_fds":          int64(7),
+		"read_bytes":       uint64(15),
+		"cpu_usage":        3.5,
+		"tcp_listen_ports": "22,80,443",
+	}, fields)
+}
+
+func TestAddFieldMixedTypes(t *testing.T) {
+	fields := map[string]interface{}{}
+	// num_fds is an int32, unless it is read with the limits
+	addField(fields, "num_fds", int32(3))
+	addField(fields, "num_fds", uint64(4))
+	addField(fields, "num_fds", int32(5))
+	addField(fields, "memory_rss", uint64(10))
+	addField(fields, "memory_rss", int32(5))
+	addField(fields, "cpu_usage", 1.5)
+	addField(fields, "cpu_usage", int64(2))
+
+	assert.Equal(t, map[string]interface{}{
+		"num_fds":    int64(12),
+		"memory_rss": int64(15),
+		"cpu_usage":  1.5,
+	}, fields)
+}
+
+func TestParseCgroup(t *testing.T) {
+	v1 := `12:pids:/system.slice/foo.service
+11:cpu,cpuacct:/system.slice/foo.service
+1:name=systemd:/system.slice/foo.service
+0::/system.slice/foo.service
+`
+	cgroup, err := parseCgroup([]byte(v1))
+	require.NoError(t, err)
+	assert.Equal(t, "/system.slice/foo.service", cgroup)
+
+	cgroup, err = parseCgroup([]byte("12:pids:/docker/abc\n1:name=systemd:/system.slice/docker.service\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "/system.slice/docker.service", cgroup)
+
+	cgroup, err = parseCgroup([]byte("0::/user.slice/user-1000.slice/session-2.scope\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "/user.slice/user-1000.slice/session-2.scope", cgroup)
+
+	_, err = parseCgroup([]byte(""))
+	assert.Error(t, err)
+}
+
+func TestProcCgroup(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("cgroups are only available on linux")
+	}
+	proc, err := NewProc(PID(os.Getpid()))
+	require.NoError(t, err)
+
+	cgroup, err := proc.Cgroup()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(cgroup, "/"))
+}
@@ -0,0 +1,314 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf/internal"
+)
+
+const (
+	// files of the service account mounted in the pods of the cluster
+	serviceAccountToken = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCA    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+	// the annotations of the pods configuring their scraping
+	scrapeAnnotation = "prometheus.io/scrape"
+	portAnnotation   = "prometheus.io/port"
+	pathAnnotation   = "prometheus.io/path"
+	schemeAnnotation = "prometheus.io/scheme"
+
+	defaultScrapePort = "9102"
+	defaultScrapePath = "/metrics"
+
+	// podWatchRetryInterval is how long to wait before listing the pods
+	// again when the watch fails
+	podWatchRetryInterval = 5 * time.Second
+)
+
+type podList struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Items []pod `json:"items"`
+}
+
+type pod struct {
+	Metadata struct {
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace"`
+		Annotations     map[string]string `json:"annotations"`
+		ResourceVersion string            `json:"resourceVersion"`
+	} `json:"metadata"`
+	Status struct {
+		Phase string `json:"phase"`
+		PodIP string `json:"podIP"`
+	} `json:"status"`
+}
+
+// podEvent is an event of the watch of the pods. The object of the ERROR
+// events is a status rather than a pod.
+type podEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// watchPods keeps the pods to scrape up to date until the context is done,
+// listing the pods and then watching their changes.
+func (p *Prometheus) watchPods(ctx context.Context) {
+	for {
+		err := p.listAndWatchPods(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			p.Log.Errorf("Error watching the kubernetes pods: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(podWatchRetryInterval):
+		}
+	}
+}
+
+// listAndWatchPods lists the pods and then watches their changes. The API
+// server ends a watch after a timeout, in which case the pods are watched
+// again from the last resource version seen, without listing them again.
+func (p *Prometheus) listAndWatchPods(ctx context.Context) error {
+	version, err := p.listPods(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		version, err = p.watchPodsFrom(ctx, version)
+		if err != nil || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+// listPods replaces the pods to scrape with the current pods, and returns the
+// resource version of the list.
+func (p *Prometheus) listPods(ctx context.Context) (string, error) {
+	resp, err := p.kubernetesRequest(ctx, url.Values{})
+	if err != nil {
+		return "", err
+	}
+	var pods podList
+	err = json.NewDecoder(resp.Body).Decode(&pods)
+	resp.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("error parsing the pods: %s", err)
+	}
+
+	targets := make(map[string]URLAndAddress)
+	for _, pod := range pods.Items {
+		if u, ok := p.podURL(pod); ok {
+			targets[podKey(pod)] = u
+		}
+	}
+	p.lock.Lock()
+	p.kubernetesPods = targets
+	p.lock.Unlock()
+	return pods.Metadata.ResourceVersion, nil
+}
+
+// watchPodsFrom applies the changes of the pods after the resource version
+// until the watch ends, and returns the resource version of the last change.
+func (p *Prometheus) watchPodsFrom(ctx context.Context, version string) (string, error) {
+	params := url.Values{}
+	params.Set("watch", "true")
+	params.Set("resourceVersion", version)
+	resp, err := p.kubernetesRequest(ctx, params)
+	if err != nil {
+		return version, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event podEvent
+		if err := decoder.Decode(&event); err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				return version, nil
+			}
+			return version, fmt.Errorf("error reading the pod events: %s", err)
+		}
+		if event.Type == "ERROR" {
+			// ie, the resource version is too old, the pods are listed again
+			return version, fmt.Errorf("pod watch error: %s", event.Object)
+		}
+
+		var pod pod
+		if err := json.Unmarshal(event.Object, &pod); err != nil {
+			return version, fmt.Errorf("error parsing the pod event: %s", err)
+		}
+		switch event.Type {
+		case "ADDED", "MODIFIED":
+			p.registerPod(pod)
+		case "DELETED":
+			p.unregisterPod(pod)
+		}
+		if pod.Metadata.ResourceVersion != "" {
+			version = pod.Metadata.ResourceVersion
+		}
+	}
+}
+
+// kubernetesRequest requests the pods to monitor from the API server, with
+// the additional params.
+func (p *Prometheus) kubernetesRequest(ctx context.Context, params url.Values) (*http.Response, error) {
+	if p.KubernetesNodeName != "" {
+		params.Set("fieldSelector", "spec.nodeName="+p.KubernetesNodeName)
+	}
+	u := p.kubernetesAPIURL()
+	if p.MonitorPodsNamespace != "" {
+		u += "/api/v1/namespaces/" + p.MonitorPodsNamespace + "/pods"
+	} else {
+		u += "/api/v1/pods"
+	}
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	tokenFile := p.KubernetesBearerToken
+	if tokenFile == "" && p.KubernetesAPIURL == "" {
+		tokenFile = serviceAccountToken
+	}
+	if tokenFile != "" {
+		token, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := p.kubernetesClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making HTTP request to %s: %s", u, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s returned HTTP status %s", u, resp.Status)
+	}
+	return resp, nil
+}
+
+// kubernetesAPIURL returns the URL of the API server, from the environment
+// of the pod when kubernetes_api_url is not set.
+func (p *Prometheus) kubernetesAPIURL() string {
+	if p.KubernetesAPIURL != "" {
+		return strings.TrimSuffix(p.KubernetesAPIURL, "/")
+	}
+	host := os.Getenv("KUBERNETES_SERVICE_HOST")
+	port := os.Getenv("KUBERNETES_SERVICE_PORT")
+	return "https://" + net.JoinHostPort(host, port)
+}
+
+func (p *Prometheus) createKubernetesClient() (*http.Client, error) {
+	ca := p.KubernetesSSLCA
+	if ca == "" && p.KubernetesAPIURL == "" {
+		ca = serviceAccountCA
+	}
+	tlsCfg, err := internal.GetTLSConfig("", "", ca, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// no timeout, the watch lasts until it is closed by the server
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsCfg,
+		},
+	}, nil
+}
+
+// registerPod adds the pod to the pods scraped, or removes it when it is no
+// longer to be scraped.
+func (p *Prometheus) registerPod(pod pod) {
+	u, ok := p.podURL(pod)
+	if !ok {
+		p.unregisterPod(pod)
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.kubernetesPods[podKey(pod)]; !ok {
+		p.Log.Debugf("Will scrape metrics from %q", u.URL)
+	}
+	p.kubernetesPods[podKey(pod)] = u
+}
+
+func (p *Prometheus) unregisterPod(pod pod) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if u, ok := p.kubernetesPods[podKey(pod)]; ok {
+		p.Log.Debugf("Will stop scraping metrics from %q", u.URL)
+		delete(p.kubernetesPods, podKey(pod))
+	}
+}
+
+// podURL returns the URL to scrape the pod at, from its annotations, and
+// whether the pod is to be scraped.
+func (p *Prometheus) podURL(pod pod) (URLAndAddress, bool) {
+	annotations := pod.Metadata.Annotations
+	if annotations[scrapeAnnotation] != "true" || pod.Status.PodIP == "" {
+		return URLAndAddress{}, false
+	}
+	if pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+		return URLAndAddress{}, false
+	}
+
+	scheme := annotations[schemeAnnotation]
+	if scheme == "" {
+		scheme = "http"
+	}
+	port := annotations[portAnnotation]
+	if port == "" {
+		port = defaultScrapePort
+	}
+	path := annotations[pathAnnotation]
+	if path == "" {
+		path = defaultScrapePath
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	u, err := url.Parse(scheme + "://" + net.JoinHostPort(pod.Status.PodIP, port) + path)
+	if err != nil {
+		p.Log.Errorf("Could not parse the URL of pod %q, skipping it. Error: %s", podKey(pod), err)
+		return URLAndAddress{}, false
+	}
+	return URLAndAddress{
+		URL:         u,
+		OriginalURL: u,
+		Address:     pod.Status.PodIP,
+		Tags: map[string]string{
+			"namespace": pod.Metadata.Namespace,
+			"pod_name":  pod.Metadata.Name,
+		},
+	}, true
+}
+
+func podKey(pod pod) string {
+	return pod.Metadata.Namespace + "/" + pod.Metadata.Name
+}
@@ -0,0 +1,224 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPod(name string, annotations map[string]string) pod {
+	var p pod
+	p.Metadata.Name = name
+	p.Metadata.Namespace = "default"
+	p.Metadata.Annotations = annotations
+	p.Status.Phase = "Running"
+	p.Status.PodIP = "127.0.0.1"
+	return p
+}
+
+func TestPodURL(t *testing.T) {
+	p := &Prometheus{Log: testutil.Logger{}}
+
+	u, ok := p.podURL(testPod("pod", map[string]string{"prometheus.io/scrape": "true"}))
+	require.True(t, ok)
+	assert.Equal(t, "http://127.0.0.1:9102/metrics", u.URL.String())
+	assert.Equal(t, "127.0.0.1", u.Address)
+	assert.Equal(t, map[string]string{"namespace": "default", "pod_name": "pod"}, u.Tags)
+
+	u, ok = p.podURL(testPod("pod", map[string]string{
+		"prometheus.io/scrape": "true",
+		"prometheus.io/scheme": "https",
+		"prometheus.io/port":   "8443",
+		"prometheus.io/path":   "internal/metrics",
+	}))
+	require.True(t, ok)
+	assert.Equal(t, "https://127.0.0.1:8443/internal/metrics", u.URL.String())
+
+	_, ok = p.podURL(testPod("pod", nil))
+	assert.False(t, ok)
+	_, ok = p.podURL(testPod("pod", map[string]string{"prometheus.io/scrape": "false"}))
+	assert.False(t, ok)
+
+	pending := testPod("pod", map[string]string{"prometheus.io/scrape": "true"})
+	pending.Status.Phase = "Pending"
+	pending.Status.PodIP = ""
+	_, ok = p.podURL(pending)
+	assert.False(t, ok)
+
+	completed := testPod("pod", map[string]string{"prometheus.io/scrape": "true"})
+	completed.Status.Phase = "Succeeded"
+	_, ok = p.podURL(completed)
+	assert.False(t, ok)
+}
+
+func TestRegisterPod(t *testing.T) {
+	p := &Prometheus{
+		Log:            testutil.Logger{},
+		kubernetesPods: make(map[string]URLAndAddress),
+	}
+
+	pod := testPod("pod", map[string]string{"prometheus.io/scrape": "true"})
+	p.registerPod(pod)
+	urls, err := p.GetAllURLs()
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	assert.Equal(t, "http://127.0.0.1:9102/metrics", urls[0].URL.String())
+
+	// the pod is no longer scraped once its annotation is removed
+	p.registerPod(testPod("pod", nil))
+	urls, err = p.GetAllURLs()
+	require.NoError(t, err)
+	assert.Len(t, urls, 0)
+
+	p.registerPod(pod)
+	p.unregisterPod(pod)
+	urls, err = p.GetAllURLs()
+	require.NoError(t, err)
+	assert.Len(t, urls, 0)
+}
+
+const podListFormat = `{
+  "kind": "PodList",
+  "metadata": {"resourceVersion": "100"},
+  "items": [%s, %s]
+}`
+
+const podFormat = `{
+  "metadata": {
+    "name": %q,
+    "namespace": "default",
+    "annotations": {
+      "prometheus.io/scrape": "true",
+      "prometheus.io/port": %q,
+      "prometheus.io/path": %q
+    }
+  },
+  "status": {"phase": "Running", "podIP": "127.0.0.1"}
+}`
+
+func TestMonitorPods(t *testing.T) {
+	metrics := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, sampleTextFormat)
+	}))
+	defer metrics.Close()
+	metricsURL, err := url.Parse(metrics.URL)
+	require.NoError(t, err)
+	port := metricsURL.Port()
+
+	requests := make(chan url.Values, 2)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests <- r.URL.Query()
+		if r.URL.Query().Get("watch") != "true" {
+			fmt.Fprintf(w, podListFormat,
+				fmt.Sprintf(podFormat, "first", port, "/metrics"),
+				fmt.Sprintf(podFormat, "second", port, "/second"))
+			return
+		}
+
+		fmt.Fprintf(w, `{"type": "DELETED", "object": %s}`+"\n",
+			fmt.Sprintf(podFormat, "first", port, "/metrics"))
+		fmt.Fprintf(w, `{"type": "ADDED", "object": %s}`+"\n",
+			fmt.Sprintf(podFormat, "third", port, "/third"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer api.Close()
+
+	p := &Prometheus{
+		Log:                  testutil.Logger{},
+		MonitorPods:          true,
+		MonitorPodsNamespace: "default",
+		KubernetesNodeName:   "node1",
+		KubernetesAPIURL:     api.URL,
+	}
+
+	var acc testutil.Accumulator
+	require.NoError(t, p.Start(&acc))
+	defer p.Stop()
+
+	list := <-requests
+	assert.Equal(t, "spec.nodeName=node1", list.Get("fieldSelector"))
+	watch := <-requests
+	assert.Equal(t, "100", watch.Get("resourceVersion"))
+	assert.Equal(t, "spec.nodeName=node1", watch.Get("fieldSelector"))
+
+	var scraped []string
+	for start := time.Now(); time.Since(start) < 5*time.Second; {
+		urls, err := p.GetAllURLs()
+		require.NoError(t, err)
+		scraped = scraped[:0]
+		for _, u := range urls {
+			scraped = append(scraped, u.URL.Path)
+		}
+		if len(scraped) == 2 && (scraped[0] == "/third" || scraped[1] == "/third") {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	sort.Strings(scraped)
+	require.Equal(t, []string{"/second", "/third"}, scraped)
+
+	require.NoError(t, acc.GatherError(p.Gather))
+	assert.True(t, acc.HasFloatField("go_goroutines", "gauge"))
+	for _, m := range acc.Metrics {
+		assert.Equal(t, "default", m.Tags["namespace"])
+		assert.Equal(t, "127.0.0.1", m.Tags["address"])
+		assert.Contains(t, []string{"second", "third"}, m.Tags["pod_name"])
+	}
+}
+
+func TestMonitorPodsWatchTimeout(t *testing.T) {
+	requests := make(chan url.Values, 3)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.URL.Query()
+		if r.URL.Query().Get("watch") != "true" {
+			fmt.Fprint(w, `{"metadata": {"resourceVersion": "100"}, "items": []}`)
+			return
+		}
+		if r.URL.Query().Get("resourceVersion") != "100" {
+			<-r.Context().Done()
+			return
+		}
+
+		// the first watch times out after an event
+		fmt.Fprint(w, `{"type": "ADDED", "object": {"metadata": {"name": "pod",`+
+			` "namespace": "default", "resourceVersion": "105"}}}`+"\n")
+	}))
+	defer api.Close()
+
+	p := &Prometheus{
+		Log:              testutil.Logger{},
+		MonitorPods:      true,
+		KubernetesAPIURL: api.URL,
+	}
+
+	var acc testutil.Accumulator
+	require.NoError(t, p.Start(&acc))
+	defer p.Stop()
+
+	list := <-requests
+	assert.Equal(t, "", list.Get("watch"))
+	watch := <-requests
+	assert.Equal(t, "100", watch.Get("resourceVersion"))
+
+	// watched again right away from the last event, without listing again
+	select {
+	case watch = <-requests:
+		assert.Equal(t, "true", watch.Get("watch"))
+		assert.Equal(t, "105", watch.Get("resourceVersion"))
+	case <-time.After(podWatchRetryInterval / 2):
+		t.Fatal("pods not watched again")
+	}
+}
@@ -1,10 +1,10 @@
 package prometheus
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net"
 	"net/http"
 	"net/url"
@@ -25,6 +25,20 @@ type Prometheus struct {
 	// An array of Kubernetes services to scrape metrics from.
 	KubernetesServices []string
 
+	// Scrape the pods of the cluster annotated with prometheus.io/scrape
+	MonitorPods bool `toml:"monitor_kubernetes_pods"`
+	// Namespace of the pods to scrape, all if empty
+	MonitorPodsNamespace string `toml:"monitor_kubernetes_pods_namespace"`
+	// Only scrape the pods of this node, all nodes if empty
+	KubernetesNodeName string `toml:"kubernetes_node_name"`
+
+	// URL of the API server, the in cluster service if empty
+	KubernetesAPIURL string `toml:"kubernetes_api_url"`
+	// Bearer Token and CA file paths for the API server, the service
+	// account ones by default in the cluster
+	KubernetesBearerToken string `toml:"kubernetes_bearer_token"`
+	KubernetesSSLCA       string `toml:"kubernetes_ssl_ca"`
+
 	// Bearer Token authorization file path
 	BearerToken string `toml:"bearer_token"`
 
@@ -39,7 +53,16 @@ type Prometheus struct {
 	// Use SSL but skip chain & host verification
 	InsecureSkipVerify bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	client *http.Client
+
+	// the pods to scrape, by namespace and name
+	lock             sync.Mutex
+	kubernetesPods   map[string]URLAndAddress
+	kubernetesClient *http.Client
+	cancel           context.CancelFunc
+	wg               sync.WaitGroup
 }
 
 var sampleConfig = `
@@ -49,6 +72,22 @@ var sampleConfig = `
   ## An array of Kubernetes services to scrape metrics from.
   # kubernetes_services = ["http://my-service-dns.my-namespace:9100/metrics"]
 
+  ## Scrape the pods of the cluster annotated with prometheus.io/scrape, at
+  ## their prometheus.io/scheme, prometheus.io/port and prometheus.io/path
+  ## annotations. The pods are watched from the API server.
+  # monitor_kubernetes_pods = false
+  ## Namespace of the pods to scrape, all namespaces if empty
+  # monitor_kubernetes_pods_namespace = ""
+  ## Only scrape the pods of this node, ie, the node telegraf runs on in a
+  ## daemonset. Use the downward API to set NODE_NAME to spec.nodeName.
+  # kubernetes_node_name = "$NODE_NAME"
+
+  ## API server to watch the pods from, the service account of the pod is
+  ## used when empty
+  # kubernetes_api_url = ""
+  # kubernetes_bearer_token = /path/to/bearer/token
+  # kubernetes_ssl_ca = /path/to/cafile
+
   ## Use bearer token for authorization
   # bearer_token = /path/to/bearer/token
 
@@ -96,6 +135,7 @@ type URLAndAddress struct {
 	OriginalURL *url.URL
 	URL         *url.URL
 	Address     string
+	Tags        map[string]string
 }
 
 func (p *Prometheus) GetAllURLs() ([]URLAndAddress, error) {
@@ -103,7 +143,7 @@ func (p *Prometheus) GetAllURLs() ([]URLAndAddress, error) {
 	for _, u := range p.URLs {
 		URL, err := url.Parse(u)
 		if err != nil {
-			log.Printf("prometheus: Could not parse %s, skipping it. Error: %s", u, err)
+			p.Log.Errorf("Could not parse %q, skipping it. Error: %s", u, err)
 			continue
 		}
 
@@ -116,7 +156,7 @@ func (p *Prometheus) GetAllURLs() ([]URLAndAddress, error) {
 		}
 		resolvedAddresses, err := net.LookupHost(URL.Hostname())
 		if err != nil {
-			log.Printf("prometheus: Could not resolve %s, skipping it. Error: %s", URL.Host, err)
+			p.Log.Errorf("Could not resolve %q, skipping it. Error: %s", URL.Host, err)
 			continue
 		}
 		for _, resolved := range resolvedAddresses {
@@ -124,6 +164,12 @@ func (p *Prometheus) GetAllURLs() ([]URLAndAddress, error) {
 			allURLs = append(allURLs, URLAndAddress{URL: serviceURL, Address: resolved, OriginalURL: URL})
 		}
 	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, u := range p.kubernetesPods {
+		allURLs = append(allURLs, u)
+	}
 	return allURLs, nil
 }
 
@@ -226,6 +272,9 @@ func (p *Prometheus) gatherURL(u URLAndAddress, acc telegraf.Accumulator) error
 		if u.Address != "" {
 			tags["address"] = u.Address
 		}
+		for k, v := range u.Tags {
+			tags[k] = v
+		}
 
 		switch metric.Type() {
 		case telegraf.Counter:
@@ -244,6 +293,37 @@ func (p *Prometheus) gatherURL(u URLAndAddress, acc telegraf.Accumulator) error
 	return nil
 }
 
+// Start watches the pods of the cluster when monitor_kubernetes_pods is set.
+func (p *Prometheus) Start(acc telegraf.Accumulator) error {
+	if !p.MonitorPods {
+		return nil
+	}
+
+	client, err := p.createKubernetesClient()
+	if err != nil {
+		return err
+	}
+	p.kubernetesClient = client
+	p.kubernetesPods = make(map[string]URLAndAddress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.watchPods(ctx)
+	}()
+	return nil
+}
+
+// Stop stops watching the pods.
+func (p *Prometheus) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+		p.wg.Wait()
+	}
+}
+
 func init() {
 	inputs.Add("prometheus", func() telegraf.Input {
 		return &Prometheus{ResponseTimeout: internal.Duration{Duration: time.Second * 3}}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
 	"net/url"
 	"strconv"
 	"strings"
@@ -19,6 +18,8 @@ import (
 type Redis struct {
 	Servers []string
 
+	Log telegraf.Logger `toml:"-"`
+
 	clients     []Client
 	initialized bool
 }
@@ -85,7 +86,7 @@ func (r *Redis) init(acc telegraf.Accumulator) error {
 
 	for i, serv := range r.Servers {
 		if !strings.HasPrefix(serv, "tcp://") && !strings.HasPrefix(serv, "unix://") {
-			log.Printf("W! [inputs.redis]: server URL found without scheme; please update your configuration file")
+			r.Log.Warnf("server URL found without scheme; please update your configuration file")
 			serv = "tcp://" + serv
 		}
 
@@ -3,6 +3,7 @@ package snmp
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"math"
 	"net"
@@ -341,6 +342,12 @@ func (s *Snmp) Description() string {
 // Any error encountered does not halt the process. The errors are accumulated
 // and returned at the end.
 func (s *Snmp) Gather(acc telegraf.Accumulator) error {
+	return s.GatherContext(context.Background(), acc)
+}
+
+// GatherContext is like Gather, but stops querying an agent once ctx is done.
+// Requests already sent are not interrupted and end after the SNMP timeout.
+func (s *Snmp) GatherContext(ctx context.Context, acc telegraf.Accumulator) error {
 	if err := s.init(); err != nil {
 		return err
 	}
@@ -368,6 +375,10 @@ func (s *Snmp) Gather(acc telegraf.Accumulator) error {
 
 			// Now is the real tables.
 			for _, t := range s.Tables {
+				if ctx.Err() != nil {
+					acc.AddError(Errorf(ctx.Err(), "agent %s", agent))
+					return
+				}
 				if err := s.gatherTable(acc, gs, t, topTags, true); err != nil {
 					acc.AddError(Errorf(err, "agent %s: gathering table %s", agent, t.Name))
 				}
@@ -3,7 +3,6 @@ package snmp_legacy
 import (
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net"
 	"strconv"
 	"strings"
@@ -24,6 +23,8 @@ type Snmp struct {
 	Subtable          []Subtable
 	SnmptranslateFile string
 
+	Log telegraf.Logger `toml:"-"`
+
 	nameToOid   map[string]string
 	initNode    Node
 	subTableMap map[string]Subtable
@@ -53,6 +54,8 @@ type Host struct {
 	processedOids []string
 
 	OidInstanceMapping map[string]map[string]string
+
+	log telegraf.Logger
 }
 
 type Table struct {
@@ -297,7 +300,7 @@ func (s *Snmp) Gather(acc telegraf.Accumulator) error {
 
 		data, err := ioutil.ReadFile(s.SnmptranslateFile)
 		if err != nil {
-			log.Printf("E! Reading SNMPtranslate file error: %s", err)
+			s.Log.Errorf("Reading SNMPtranslate file error: %s", err)
 			return err
 		} else {
 			for _, line := range strings.Split(string(data), "\n") {
@@ -313,6 +316,7 @@ func (s *Snmp) Gather(acc telegraf.Accumulator) error {
 	}
 	// Fetching data
 	for _, host := range s.Host {
+		host.log = s.Log
 		// Set default args
 		if len(host.Address) == 0 {
 			host.Address = "127.0.0.1:161"
@@ -801,7 +805,7 @@ func (h *Host) HandleResponse(
 					acc.AddFields(field_name, fields, tags)
 				case gosnmp.NoSuchObject, gosnmp.NoSuchInstance:
 					// Oid not found
-					log.Printf("E! [snmp input] Oid not found: %s", oid_key)
+					h.log.Errorf("Oid not found: %s", oid_key)
 				default:
 					// delete other data
 				}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"os"
 	"strings"
@@ -113,7 +112,7 @@ func (ssl *streamSocketListener) read(c net.Conn) {
 
 	if err := scnr.Err(); err != nil {
 		if err, ok := err.(net.Error); ok && err.Timeout() {
-			log.Printf("D! Timeout in plugin [input.socket_listener]: %s", err)
+			ssl.Log.Debugf("Timeout in plugin: %s", err)
 		} else if !strings.HasSuffix(err.Error(), ": use of closed network connection") {
 			ssl.AddError(err)
 		}
@@ -155,6 +154,8 @@ type SocketListener struct {
 	ReadTimeout     *internal.Duration
 	KeepAlivePeriod *internal.Duration
 
+	Log telegraf.Logger `toml:"-"`
+
 	parsers.Parser
 	telegraf.Accumulator
 	io.Closer
@@ -241,7 +242,7 @@ func (sl *SocketListener) Start(acc telegraf.Accumulator) error {
 			if srb, ok := l.(setReadBufferer); ok {
 				srb.SetReadBuffer(sl.ReadBufferSize)
 			} else {
-				log.Printf("W! Unable to set read buffer on a %s socket", spl[0])
+				sl.Log.Warnf("Unable to set read buffer on a %s socket", spl[0])
 			}
 		}
 
@@ -262,7 +263,7 @@ func (sl *SocketListener) Start(acc telegraf.Accumulator) error {
 			if srb, ok := pc.(setReadBufferer); ok {
 				srb.SetReadBuffer(sl.ReadBufferSize)
 			} else {
-				log.Printf("W! Unable to set read buffer on a %s socket", spl[0])
+				sl.Log.Warnf("Unable to set read buffer on a %s socket", spl[0])
 			}
 		}
 
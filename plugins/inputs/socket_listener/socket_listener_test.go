@@ -13,6 +13,7 @@ import (
 
 func TestSocketListener_tcp(t *testing.T) {
 	sl := newSocketListener()
+	sl.Log = testutil.Logger{}
 	sl.ServiceAddress = "tcp://127.0.0.1:0"
 
 	acc := &testutil.Accumulator{}
@@ -28,6 +29,7 @@ func TestSocketListener_tcp(t *testing.T) {
 
 func TestSocketListener_udp(t *testing.T) {
 	sl := newSocketListener()
+	sl.Log = testutil.Logger{}
 	sl.ServiceAddress = "udp://127.0.0.1:0"
 
 	acc := &testutil.Accumulator{}
@@ -44,6 +46,7 @@ func TestSocketListener_udp(t *testing.T) {
 func TestSocketListener_unix(t *testing.T) {
 	os.Create("/tmp/telegraf_test.sock")
 	sl := newSocketListener()
+	sl.Log = testutil.Logger{}
 	sl.ServiceAddress = "unix:///tmp/telegraf_test.sock"
 
 	acc := &testutil.Accumulator{}
@@ -60,6 +63,7 @@ func TestSocketListener_unix(t *testing.T) {
 func TestSocketListener_unixgram(t *testing.T) {
 	os.Create("/tmp/telegraf_test.sock")
 	sl := newSocketListener()
+	sl.Log = testutil.Logger{}
 	sl.ServiceAddress = "unixgram:///tmp/telegraf_test.sock"
 
 	acc := &testutil.Accumulator{}
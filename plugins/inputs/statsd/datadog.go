@@ -0,0 +1,223 @@
+package statsd
+
+// DogStatsD extensions to the statsd protocol, see
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	eventMeasurement        = "statsd_event"
+	serviceCheckMeasurement = "statsd_service_check"
+
+	priorityNormal = "normal"
+	priorityLow    = "low"
+
+	eventInfo    = "info"
+	eventWarning = "warning"
+	eventError   = "error"
+	eventSuccess = "success"
+)
+
+// service check statuses, indexed by their value
+var serviceCheckStatuses = []string{"ok", "warning", "critical", "unknown"}
+
+// isDataDogMessage returns true if the line is an event or a service check.
+func isDataDogMessage(line string) bool {
+	return strings.HasPrefix(line, eventPrefix) ||
+		strings.HasPrefix(line, serviceCheckPrefix)
+}
+
+// parseDataDogMessage parses an event or a service check.
+func (s *Statsd) parseDataDogMessage(now time.Time, line string) error {
+	if strings.HasPrefix(line, eventPrefix) {
+		return s.parseEventMessage(now, line)
+	}
+	return s.parseServiceCheckMessage(now, line)
+}
+
+// parseEventMessage parses a DogStatsD event, which look like this:
+// _e{<TITLE_LENGTH>,<TEXT_LENGTH>}:<TITLE>|<TEXT>|d:<TIMESTAMP>|h:<HOSTNAME>|p:<PRIORITY>|t:<ALERT_TYPE>|k:<AGGREGATION_KEY>|s:<SOURCE_TYPE_NAME>|#<TAG_KEY_1>:<TAG_VALUE_1>,<TAG_2>
+// and adds it to the accumulator.
+func (s *Statsd) parseEventMessage(now time.Time, message string) error {
+	// the lengths are in bytes, the title and text can contain any character
+	// so they are sliced out by length
+	rest := message[len(eventPrefix):]
+	end := strings.Index(rest, "}:")
+	if end < 0 {
+		return fmt.Errorf("invalid event header: %s", message)
+	}
+	lengths := strings.Split(rest[:end], ",")
+	if len(lengths) != 2 {
+		return fmt.Errorf("invalid event lengths: %s", message)
+	}
+	titleLen, err := strconv.Atoi(lengths[0])
+	if err != nil || titleLen < 1 {
+		return fmt.Errorf("invalid event title length: %s", message)
+	}
+	textLen, err := strconv.Atoi(lengths[1])
+	if err != nil || textLen < 0 {
+		return fmt.Errorf("invalid event text length: %s", message)
+	}
+
+	rest = rest[end+2:]
+	// the lengths are checked one by one so that huge lengths cannot
+	// overflow their sum
+	if titleLen > len(rest) || textLen > len(rest) ||
+		titleLen > len(rest)-1-textLen || rest[titleLen] != '|' {
+		return fmt.Errorf("event title and text do not match their lengths: %s", message)
+	}
+	title := rest[:titleLen]
+	text := strings.Replace(rest[titleLen+1:titleLen+1+textLen], "\\n", "\n", -1)
+	rest = rest[titleLen+1+textLen:]
+
+	fields := map[string]interface{}{
+		"title":      title,
+		"text":       text,
+		"priority":   priorityNormal,
+		"alert_type": eventInfo,
+	}
+	tags := make(map[string]string)
+	ts := now
+
+	if rest != "" {
+		if rest[0] != '|' {
+			return fmt.Errorf("invalid event metadata: %s", message)
+		}
+		for _, segment := range strings.Split(rest[1:], "|") {
+			if len(segment) < 2 {
+				continue
+			}
+			switch {
+			case segment[0] == '#':
+				parseDataDogTags(segment[1:], tags)
+				continue
+			case strings.HasPrefix(segment, "c:"):
+				tags["container_id"] = segment[2:]
+				continue
+			case len(segment) < 3 || segment[1] != ':':
+				continue
+			}
+
+			value := segment[2:]
+			switch segment[0] {
+			case 'd':
+				sec, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					s.Log.Errorf("Parsing event timestamp %q: %s", value, message)
+					continue
+				}
+				ts = time.Unix(sec, 0)
+			case 'h':
+				tags["source"] = value
+			case 'p':
+				switch value {
+				case priorityLow, priorityNormal:
+					fields["priority"] = value
+				default:
+					s.Log.Errorf("Invalid event priority %q: %s", value, message)
+				}
+			case 't':
+				switch value {
+				case eventInfo, eventWarning, eventError, eventSuccess:
+					fields["alert_type"] = value
+				default:
+					s.Log.Errorf("Invalid event alert type %q: %s", value, message)
+				}
+			case 'k':
+				fields["aggregation_key"] = value
+			case 's':
+				fields["source_type_name"] = value
+			}
+		}
+	}
+
+	s.acc.AddFields(eventMeasurement, fields, tags, ts)
+	return nil
+}
+
+// parseServiceCheckMessage parses a DogStatsD service check, which look like
+// this:
+// _sc|<NAME>|<STATUS>|d:<TIMESTAMP>|h:<HOSTNAME>|#<TAG_KEY_1>:<TAG_VALUE_1>,<TAG_2>|m:<SERVICE_CHECK_MESSAGE>
+// and adds it to the accumulator.
+func (s *Statsd) parseServiceCheckMessage(now time.Time, message string) error {
+	rest := message[len(serviceCheckPrefix):]
+
+	// the message is always last and can contain pipes
+	var text string
+	if i := strings.Index(rest, "|m:"); i >= 0 {
+		text = rest[i+3:]
+		rest = rest[:i]
+	}
+
+	segments := strings.Split(rest, "|")
+	if len(segments) < 2 || segments[0] == "" {
+		return fmt.Errorf("service check without a name and status: %s", message)
+	}
+	status, err := strconv.Atoi(segments[1])
+	if err != nil || status < 0 || status >= len(serviceCheckStatuses) {
+		return fmt.Errorf("invalid service check status %q: %s", segments[1], message)
+	}
+
+	fields := map[string]interface{}{
+		"status":      int64(status),
+		"status_text": serviceCheckStatuses[status],
+	}
+	if text != "" {
+		fields["message"] = text
+	}
+	tags := map[string]string{
+		"check": segments[0],
+	}
+	ts := now
+
+	for _, segment := range segments[2:] {
+		switch {
+		case len(segment) < 2:
+		case segment[0] == '#':
+			parseDataDogTags(segment[1:], tags)
+		case strings.HasPrefix(segment, "c:"):
+			tags["container_id"] = segment[2:]
+		case strings.HasPrefix(segment, "d:"):
+			sec, err := strconv.ParseInt(segment[2:], 10, 64)
+			if err != nil {
+				s.Log.Errorf("Parsing service check timestamp %q: %s", segment[2:], message)
+				continue
+			}
+			ts = time.Unix(sec, 0)
+		case strings.HasPrefix(segment, "h:"):
+			tags["source"] = segment[2:]
+		}
+	}
+
+	s.acc.AddFields(serviceCheckMeasurement, fields, tags, ts)
+	return nil
+}
+
+// parseDataDogTags parses comma separated DogStatsD tags, which are key:value
+// pairs or keys alone, into tags.
+func parseDataDogTags(tagstr string, tags map[string]string) {
+	for _, tag := range strings.Split(tagstr, ",") {
+		ts := strings.SplitN(tag, ":", 2)
+		var k, v string
+		switch len(ts) {
+		case 1:
+			// just a tag
+			k = ts[0]
+			v = ""
+		case 2:
+			k = ts[0]
+			v = ts[1]
+		}
+		if k != "" {
+			tags[k] = v
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package statsd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_DataDogEvents(t *testing.T) {
+	s := NewTestStatsd()
+	s.DataDogExtensions = true
+	acc := &testutil.Accumulator{}
+	s.acc = acc
+
+	tests := []struct {
+		name   string
+		line   string
+		fields map[string]interface{}
+		tags   map[string]string
+		time   time.Time
+	}{
+		{
+			name: "defaults",
+			line: "_e{5,4}:title|text",
+			fields: map[string]interface{}{
+				"title":      "title",
+				"text":       "text",
+				"priority":   "normal",
+				"alert_type": "info",
+			},
+			tags: map[string]string{},
+		},
+		{
+			name: "metadata",
+			line: "_e{10,21}:test|title|test\\ntext|with pipes|d:21|h:localhost|p:low|t:warning|k:some aggregation key|s:source test|#tag1,tag2:test|c:83c0a99c0a54",
+			fields: map[string]interface{}{
+				"title":            "test|title",
+				"text":             "test\ntext|with pipes",
+				"priority":         "low",
+				"alert_type":       "warning",
+				"aggregation_key":  "some aggregation key",
+				"source_type_name": "source test",
+			},
+			tags: map[string]string{
+				"source":       "localhost",
+				"tag1":         "",
+				"tag2":         "test",
+				"container_id": "83c0a99c0a54",
+			},
+			time: time.Unix(21, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc.ClearMetrics()
+			require.NoError(t, s.parseStatsdLine(tt.line))
+			require.Len(t, acc.Metrics, 1)
+			m := acc.Metrics[0]
+			assert.Equal(t, "statsd_event", m.Measurement)
+			assert.Equal(t, tt.fields, m.Fields)
+			assert.Equal(t, tt.tags, m.Tags)
+			if !tt.time.IsZero() {
+				assert.Equal(t, tt.time, m.Time)
+			}
+		})
+	}
+}
+
+func TestParse_DataDogEventErrors(t *testing.T) {
+	s := NewTestStatsd()
+	s.DataDogExtensions = true
+	acc := &testutil.Accumulator{}
+	s.acc = acc
+
+	lines := []string{
+		"_e{5,4}title|text",
+		"_e{5}:title|text",
+		"_e{0,4}:|text",
+		"_e{5,10}:title|text",
+		"_e{3,4}:title|text",
+		"_e{9223372036854775807,0}:a|b",
+		"_e{1,9223372036854775807}:a|b",
+	}
+	for _, line := range lines {
+		assert.Error(t, s.parseStatsdLine(line), line)
+	}
+	assert.Len(t, acc.Metrics, 0)
+}
+
+func TestParse_DataDogServiceChecks(t *testing.T) {
+	s := NewTestStatsd()
+	s.DataDogExtensions = true
+	acc := &testutil.Accumulator{}
+	s.acc = acc
+
+	require.NoError(t, s.parseStatsdLine(
+		"_sc|my.check|2|d:21|h:localhost|#env:prod,live|m:disk | full"))
+	require.NoError(t, s.parseStatsdLine("_sc|other.check|0"))
+
+	acc.AssertContainsTaggedFields(t, "statsd_service_check",
+		map[string]interface{}{
+			"status":      int64(2),
+			"status_text": "critical",
+			"message":     "disk | full",
+		},
+		map[string]string{
+			"check":  "my.check",
+			"source": "localhost",
+			"env":    "prod",
+			"live":   "",
+		})
+	acc.AssertContainsTaggedFields(t, "statsd_service_check",
+		map[string]interface{}{
+			"status":      int64(0),
+			"status_text": "ok",
+		},
+		map[string]string{
+			"check": "other.check",
+		})
+
+	for _, m := range acc.Metrics {
+		if m.Tags["check"] == "my.check" {
+			assert.Equal(t, time.Unix(21, 0), m.Time)
+		}
+	}
+
+	assert.Error(t, s.parseStatsdLine("_sc|my.check|5"))
+	assert.Error(t, s.parseStatsdLine("_sc|my.check"))
+}
+
+// Test that events and service checks are still malformed lines without the
+// extensions
+func TestParse_DataDogMessagesWithoutExtensions(t *testing.T) {
+	s := NewTestStatsd()
+	s.ParseDataDogTags = true
+	acc := &testutil.Accumulator{}
+	s.acc = acc
+
+	assert.Error(t, s.parseStatsdLine("_e{5,4}:title|text"))
+	assert.Error(t, s.parseStatsdLine("_sc|my.check|0"))
+	assert.Error(t, s.parseStatsdLine("my.dist:1|d"))
+	assert.Len(t, acc.Metrics, 0)
+}
+
+func TestParse_DataDogDistributions(t *testing.T) {
+	s := NewTestStatsd()
+	s.DataDogExtensions = true
+	s.Percentiles = []int{90}
+	acc := &testutil.Accumulator{}
+
+	lines := []string{
+		"my.dist:1|d|#env:prod",
+		"my.dist:2|d|#env:prod",
+		"my.dist:3|d|@0.5|#env:prod",
+	}
+	for _, line := range lines {
+		require.NoError(t, s.parseStatsdLine(line))
+	}
+	require.NoError(t, s.Gather(acc))
+
+	acc.AssertContainsTaggedFields(t, "my_dist",
+		map[string]interface{}{
+			"count":         int64(4),
+			"lower":         float64(1),
+			"upper":         float64(3),
+			"mean":          float64(2.25),
+			"sum":           float64(9),
+			"stddev":        float64(0.82915619758885),
+			"90_percentile": float64(3),
+		},
+		map[string]string{
+			"metric_type": "distribution",
+			"env":         "prod",
+		})
+}
+
+func TestParse_DataDogContainerIDAndTimestamp(t *testing.T) {
+	s := NewTestStatsd()
+	s.DataDogExtensions = true
+	acc := &testutil.Accumulator{}
+
+	lines := []string{
+		"my.counter:1|c|#env:prod|c:83c0a99c0a54|T1656581400",
+		"my.counter:2|c|#env:prod|c:83c0a99c0a54|T1656581410",
+		"my.gauge:1|g|T1656581400",
+		// a counter followed by another value is not a container ID
+		"my.multi:1|ms:2|c:3|g",
+	}
+	for _, line := range lines {
+		require.NoError(t, s.parseStatsdLine(line))
+	}
+	require.NoError(t, s.Gather(acc))
+
+	acc.AssertContainsTaggedFields(t, "my_counter",
+		map[string]interface{}{"value": int64(3)},
+		map[string]string{
+			"metric_type":  "counter",
+			"env":          "prod",
+			"container_id": "83c0a99c0a54",
+		})
+	assert.True(t, acc.HasPoint("my_multi",
+		map[string]string{"metric_type": "counter"}, "value", int64(2)))
+	assert.True(t, acc.HasPoint("my_multi",
+		map[string]string{"metric_type": "gauge"}, "value", float64(3)))
+
+	for _, m := range acc.Metrics {
+		switch m.Measurement {
+		case "my_counter":
+			// the latest timestamp of the values
+			assert.Equal(t, time.Unix(1656581410, 0), m.Time)
+		case "my_gauge":
+			assert.Equal(t, time.Unix(1656581400, 0), m.Time)
+		}
+	}
+}
+
+func TestParse_DataDogTimestampOnlyAppliesToItsInterval(t *testing.T) {
+	s := NewTestStatsd()
+	s.DataDogExtensions = true
+	old := time.Unix(1656581400, 0)
+
+	require.NoError(t, s.parseStatsdLine("my.gauge:1|g|T1656581400"))
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	require.Len(t, acc.Metrics, 1)
+	assert.Equal(t, old, acc.Metrics[0].Time)
+
+	// the gauge is not deleted, it is reported again at the time of the
+	// gather rather than at the timestamp of its previous value
+	acc = &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	require.Len(t, acc.Metrics, 1)
+	assert.True(t, acc.Metrics[0].Time.After(old))
+
+	require.NoError(t, s.parseStatsdLine("my.gauge:2|g"))
+	acc = &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	require.Len(t, acc.Metrics, 1)
+	assert.True(t, acc.Metrics[0].Time.After(old))
+	assert.Equal(t, float64(2), acc.Metrics[0].Fields["value"])
+}
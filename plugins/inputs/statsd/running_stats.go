@@ -24,6 +24,10 @@ type RunningStats struct {
 	perc      []float64
 	PercLimit int
 
+	// Sketch, when set, estimates the percentiles instead of the array, in a
+	// fixed amount of memory.
+	Sketch *Sketch
+
 	sum float64
 
 	lower float64
@@ -45,7 +49,9 @@ func (rs *RunningStats) AddValue(v float64) {
 		if rs.PercLimit == 0 {
 			rs.PercLimit = defaultPercentileLimit
 		}
-		rs.perc = make([]float64, 0, rs.PercLimit)
+		if rs.Sketch == nil {
+			rs.perc = make([]float64, 0, rs.PercLimit)
+		}
 	}
 
 	// These are used for the running mean and variance
@@ -63,7 +69,9 @@ func (rs *RunningStats) AddValue(v float64) {
 		rs.lower = v
 	}
 
-	if len(rs.perc) < rs.PercLimit {
+	if rs.Sketch != nil {
+		rs.Sketch.Add(v)
+	} else if len(rs.perc) < rs.PercLimit {
 		rs.perc = append(rs.perc, v)
 	} else {
 		// Reached limit, choose random index to overwrite in the percentile array
@@ -104,6 +112,10 @@ func (rs *RunningStats) Percentile(n int) float64 {
 		n = 100
 	}
 
+	if rs.Sketch != nil {
+		return rs.Sketch.Quantile(float64(n) / 100)
+	}
+
 	if !rs.sorted {
 		sort.Float64s(rs.perc)
 		rs.sorted = true
@@ -0,0 +1,187 @@
+package statsd
+
+import (
+	"math"
+)
+
+const (
+	defaultSketchRelativeAccuracy = 0.01
+	defaultSketchMaxBins          = 2048
+
+	// values closer to zero than this are counted as zeros
+	sketchMinValue = 1e-9
+)
+
+// Sketch estimates percentiles with a bounded relative error, using a fixed
+// amount of memory whatever the number of values. It is a DDSketch, see
+// https://arxiv.org/abs/1908.10693.
+// Values are counted in buckets whose bounds grow exponentially, so the value
+// of a bucket is within the relative accuracy of all the values counted in
+// it. Once there are more than MaxBins buckets the lowest ones are collapsed,
+// which only loses accuracy on the lowest percentiles. Sketches with the same
+// accuracy can be merged.
+type Sketch struct {
+	gamma   float64
+	lnGamma float64
+	maxBins int
+
+	positive sketchStore
+	negative sketchStore
+	zeros    int64
+}
+
+// NewSketch returns a Sketch with the given relative accuracy, ie, 0.01 for
+// 1%, keeping at most maxBins buckets for positive and for negative values.
+func NewSketch(relativeAccuracy float64, maxBins int) *Sketch {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		relativeAccuracy = defaultSketchRelativeAccuracy
+	}
+	if maxBins <= 0 {
+		maxBins = defaultSketchMaxBins
+	}
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &Sketch{
+		gamma:   gamma,
+		lnGamma: math.Log(gamma),
+		maxBins: maxBins,
+	}
+}
+
+// Add counts a value. Infinite and NaN values have no bucket, they are
+// ignored.
+func (s *Sketch) Add(v float64) {
+	switch {
+	case math.IsInf(v, 0) || math.IsNaN(v):
+		return
+	case v > sketchMinValue:
+		s.positive.add(s.index(v), 1, s.maxBins)
+	case v < -sketchMinValue:
+		s.negative.add(s.index(-v), 1, s.maxBins)
+	default:
+		s.zeros++
+	}
+}
+
+// Merge adds the values counted by other, which must have the same relative
+// accuracy.
+func (s *Sketch) Merge(other *Sketch) {
+	s.positive.merge(&other.positive, s.maxBins)
+	s.negative.merge(&other.negative, s.maxBins)
+	s.zeros += other.zeros
+}
+
+// Count returns the number of values counted.
+func (s *Sketch) Count() int64 {
+	return s.positive.count + s.negative.count + s.zeros
+}
+
+// Quantile returns the estimated q-quantile of the values, q being between 0
+// and 1.
+func (s *Sketch) Quantile(q float64) float64 {
+	count := s.Count()
+	if count == 0 {
+		return 0
+	}
+	q = math.Max(0, math.Min(q, 1))
+	rank := int64(q * float64(count-1))
+
+	if rank < s.negative.count {
+		// the largest negative indexes are the lowest values
+		return -s.value(s.negative.index(s.negative.count - 1 - rank))
+	}
+	rank -= s.negative.count
+	if rank < s.zeros {
+		return 0
+	}
+	return s.value(s.positive.index(rank - s.zeros))
+}
+
+// index returns the index of the bucket of a positive value.
+func (s *Sketch) index(v float64) int {
+	return int(math.Ceil(math.Log(v) / s.lnGamma))
+}
+
+// value returns the value of a bucket, which is within the relative accuracy
+// of its bounds gamma^(i-1) and gamma^i.
+func (s *Sketch) value(i int) float64 {
+	return 2 * math.Pow(s.gamma, float64(i)) / (s.gamma + 1)
+}
+
+// sketchStore counts the values of the contiguous buckets from offset.
+type sketchStore struct {
+	bins   []int64
+	offset int
+	count  int64
+}
+
+func (st *sketchStore) add(i int, n int64, maxBins int) {
+	st.count += n
+	if len(st.bins) == 0 {
+		st.bins = []int64{n}
+		st.offset = i
+		return
+	}
+
+	top := st.offset + len(st.bins) - 1
+	switch {
+	case i < st.offset:
+		// the lowest buckets are the ones collapsed
+		if low := top - maxBins + 1; i < low {
+			i = low
+		}
+		if i < st.offset {
+			bins := make([]int64, top-i+1)
+			copy(bins[st.offset-i:], st.bins)
+			st.bins = bins
+			st.offset = i
+		}
+	case i > top:
+		if low := i - maxBins + 1; low > st.offset {
+			st.collapse(low)
+		}
+		for len(st.bins) < i-st.offset+1 {
+			st.bins = append(st.bins, 0)
+		}
+	}
+	st.bins[i-st.offset] += n
+}
+
+// collapse adds the counts of the buckets below low to the bucket low.
+func (st *sketchStore) collapse(low int) {
+	var collapsed int64
+	n := low - st.offset
+	if n > len(st.bins) {
+		n = len(st.bins)
+	}
+	for _, c := range st.bins[:n] {
+		collapsed += c
+	}
+	bins := make([]int64, len(st.bins)-n, cap(st.bins))
+	copy(bins, st.bins[n:])
+	if len(bins) == 0 {
+		bins = append(bins, 0)
+	}
+	bins[0] += collapsed
+	st.bins = bins
+	st.offset = low
+}
+
+func (st *sketchStore) merge(other *sketchStore, maxBins int) {
+	for j, c := range other.bins {
+		if c != 0 {
+			st.add(other.offset+j, c, maxBins)
+		}
+	}
+}
+
+// index returns the index of the bucket of the value of the given rank.
+func (st *sketchStore) index(rank int64) int {
+	var seen int64
+	for j, c := range st.bins {
+		seen += c
+		if seen > rank {
+			return st.offset + j
+		}
+	}
+	return st.offset + len(st.bins) - 1
+}
@@ -0,0 +1,107 @@
+package statsd
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exactQuantile returns the quantile of the sorted values with the rank used
+// by the sketch
+func exactQuantile(sorted []float64, q float64) float64 {
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+func assertRelative(t *testing.T, want, got, accuracy float64) {
+	assert.True(t, math.Abs(got-want) <= accuracy*math.Abs(want),
+		"got %v, want %v within %v", got, want, accuracy)
+}
+
+func TestSketch_Quantile(t *testing.T) {
+	s := NewSketch(0.01, 2048)
+	r := rand.New(rand.NewSource(1))
+
+	values := make([]float64, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		// spread over several orders of magnitude, with negatives and zeros
+		v := math.Exp(r.Float64()*10) - 100
+		if i%100 == 0 {
+			v = 0
+		}
+		values = append(values, v)
+		s.Add(v)
+	}
+	sort.Float64s(values)
+
+	require.Equal(t, int64(10000), s.Count())
+	for _, q := range []float64{0, 0.01, 0.25, 0.5, 0.9, 0.99, 0.999, 1} {
+		assertRelative(t, exactQuantile(values, q), s.Quantile(q), 0.01)
+	}
+}
+
+func TestSketch_Empty(t *testing.T) {
+	s := NewSketch(0.01, 2048)
+	assert.Equal(t, float64(0), s.Quantile(0.5))
+}
+
+func TestSketch_NonFinite(t *testing.T) {
+	s := NewSketch(0.01, 2048)
+	s.Add(math.Inf(1))
+	s.Add(math.Inf(-1))
+	s.Add(math.NaN())
+	s.Add(10)
+
+	assert.Equal(t, int64(1), s.Count())
+	assertRelative(t, 10, s.Quantile(0.5), 0.01)
+	assertRelative(t, 10, s.Quantile(0.9), 0.01)
+}
+
+func TestSketch_MaxBins(t *testing.T) {
+	s := NewSketch(0.01, 100)
+	for i := 1; i <= 100000; i++ {
+		s.Add(float64(i))
+	}
+
+	assert.True(t, len(s.positive.bins) <= 100)
+	assert.Equal(t, int64(100000), s.Count())
+	// the highest percentiles keep their accuracy
+	assertRelative(t, 99000, s.Quantile(0.99), 0.01)
+	assertRelative(t, 100000, s.Quantile(1), 0.01)
+}
+
+func TestSketch_Merge(t *testing.T) {
+	all := NewSketch(0.01, 2048)
+	a := NewSketch(0.01, 2048)
+	b := NewSketch(0.01, 2048)
+	for i := 1; i <= 1000; i++ {
+		v := float64(i)
+		all.Add(v)
+		all.Add(-v)
+		a.Add(v)
+		b.Add(-v)
+	}
+
+	a.Merge(b)
+	assert.Equal(t, all.Count(), a.Count())
+	for _, q := range []float64{0, 0.1, 0.5, 0.9, 1} {
+		assert.Equal(t, all.Quantile(q), a.Quantile(q))
+	}
+}
+
+func TestRunningStats_Sketch(t *testing.T) {
+	rs := RunningStats{Sketch: NewSketch(0.01, 2048)}
+	for i := 1; i <= 2000; i++ {
+		rs.AddValue(float64(i))
+	}
+
+	assert.Len(t, rs.perc, 0)
+	assert.Equal(t, int64(2000), rs.Count())
+	assert.Equal(t, float64(2000), rs.Upper())
+	assertRelative(t, 1000, rs.Percentile(50), 0.01)
+	assertRelative(t, 1800, rs.Percentile(90), 0.01)
+	assertRelative(t, 2000, rs.Percentile(100), 0.01)
+}
@@ -30,16 +30,19 @@ const (
 
 	defaultProtocol = "udp"
 
+	percentileSample = "sample"
+	percentileSketch = "sketch"
+
 	defaultSeparator           = "_"
 	defaultAllowPendingMessage = 10000
 	MaxTCPConnections          = 250
 )
 
-var dropwarn = "E! Error: statsd message queue full. " +
+var dropwarn = "Statsd message queue full. " +
 	"We have dropped %d messages so far. " +
-	"You may want to increase allowed_pending_messages in the config\n"
+	"You may want to increase allowed_pending_messages in the config"
 
-var malformedwarn = "E! Statsd over TCP has received %d malformed packets" +
+var malformedwarn = "Statsd over TCP has received %d malformed packets" +
 	" thus far."
 
 type Statsd struct {
@@ -58,6 +61,17 @@ type Statsd struct {
 	Percentiles     []int
 	PercentileLimit int
 
+	// PercentileMethod is how the percentiles are estimated: "sample" keeps
+	// up to PercentileLimit values per timing, "sketch" counts them in a
+	// sketch of at most SketchMaxBins buckets.
+	PercentileMethod       string  `toml:"percentile_method"`
+	SketchRelativeAccuracy float64 `toml:"sketch_relative_accuracy"`
+	SketchMaxBins          int     `toml:"sketch_max_bins"`
+
+	// MaxTTL is the time after which the series not updated are expired,
+	// whatever the Delete* flags.
+	MaxTTL internal.Duration `toml:"max_ttl"`
+
 	DeleteGauges   bool
 	DeleteCounters bool
 	DeleteSets     bool
@@ -69,6 +83,10 @@ type Statsd struct {
 	// This flag enables parsing of tags in the dogstatsd extension to the
 	// statsd protocol (http://docs.datadoghq.com/guides/dogstatsd/)
 	ParseDataDogTags bool
+	// This flag enables the other dogstatsd extensions: events, service
+	// checks, distributions, container IDs and timestamps. It implies
+	// ParseDataDogTags.
+	DataDogExtensions bool `toml:"datadog_extensions"`
 
 	// UDPPacketSize is deprecated, it's only here for legacy support
 	// we now always create 1 max size buffer and then copy only what we need
@@ -113,6 +131,8 @@ type Statsd struct {
 
 	MaxTCPConnections int `toml:"max_tcp_connections"`
 
+	Log telegraf.Logger `toml:"-"`
+
 	graphiteParser *graphite.GraphiteParser
 
 	acc telegraf.Accumulator
@@ -140,30 +160,43 @@ type metric struct {
 	additive   bool
 	samplerate float64
 	tags       map[string]string
+	timestamp  time.Time
 }
 
+// The timestamp of the cached metrics is the latest one of their values in
+// the interval, it is only set by the dogstatsd timestamp extension and is
+// cleared once reported. They expire at expiresAt when max_ttl is set.
+
 type cachedset struct {
-	name   string
-	fields map[string]map[string]bool
-	tags   map[string]string
+	name      string
+	fields    map[string]map[string]bool
+	tags      map[string]string
+	timestamp time.Time
+	expiresAt time.Time
 }
 
 type cachedgauge struct {
-	name   string
-	fields map[string]interface{}
-	tags   map[string]string
+	name      string
+	fields    map[string]interface{}
+	tags      map[string]string
+	timestamp time.Time
+	expiresAt time.Time
 }
 
 type cachedcounter struct {
-	name   string
-	fields map[string]interface{}
-	tags   map[string]string
+	name      string
+	fields    map[string]interface{}
+	tags      map[string]string
+	timestamp time.Time
+	expiresAt time.Time
 }
 
 type cachedtimings struct {
-	name   string
-	fields map[string]RunningStats
-	tags   map[string]string
+	name      string
+	fields    map[string]RunningStats
+	tags      map[string]string
+	timestamp time.Time
+	expiresAt time.Time
 }
 
 func (_ *Statsd) Description() string {
@@ -202,6 +235,11 @@ const sampleConfig = `
   ## http://docs.datadoghq.com/guides/dogstatsd/
   parse_data_dog_tags = false
 
+  ## Parses the other datadog statsd extensions: events, service checks, the
+  ## distribution type, container IDs and timestamps; implies
+  ## parse_data_dog_tags
+  datadog_extensions = false
+
   ## Statsd data translation templates, more info can be read here:
   ## https://github.com/influxdata/telegraf/blob/master/docs/DATA_FORMATS_INPUT.md#graphite
   # templates = [
@@ -216,6 +254,20 @@ const sampleConfig = `
   ## calculation of percentiles. Raising this limit increases the accuracy
   ## of percentiles but also increases the memory usage and cpu time.
   percentile_limit = 1000
+
+  ## How the percentiles are estimated: "sample" keeps up to percentile_limit
+  ## values per series, "sketch" counts them in a sketch of a fixed size, whose
+  ## percentiles are within sketch_relative_accuracy of the actual ones.
+  # percentile_method = "sample"
+  # sketch_relative_accuracy = 0.01
+  ## Maximum number of buckets of the sketch, each bucket uses 8 bytes for the
+  ## positive values and 8 for the negative ones. Past it the lowest
+  ## percentiles lose accuracy.
+  # sketch_max_bins = 2048
+
+  ## Max duration (TTL) for each metric to stay cached/reported without being
+  ## updated.
+  # max_ttl = "1000h"
 `
 
 func (_ *Statsd) SampleConfig() string {
@@ -227,7 +279,11 @@ func (s *Statsd) Gather(acc telegraf.Accumulator) error {
 	defer s.Unlock()
 	now := time.Now()
 
-	for _, metric := range s.timings {
+	if s.MaxTTL.Duration > 0 {
+		s.expireCachedMetrics(now)
+	}
+
+	for key, metric := range s.timings {
 		// Defining a template to parse field names for timers allows us to split
 		// out multiple fields per timer. In this case we prefix each stat with the
 		// field name and store these all in a single measurement.
@@ -249,32 +305,42 @@ func (s *Statsd) Gather(acc telegraf.Accumulator) error {
 			}
 		}
 
-		acc.AddFields(metric.name, fields, metric.tags, now)
+		acc.AddFields(metric.name, fields, metric.tags, timestamp(metric.timestamp, now))
+		// the timestamp of the values only applies to the interval they
+		// arrived in, the series is reported at now in the next ones
+		metric.timestamp = time.Time{}
+		s.timings[key] = metric
 	}
 	if s.DeleteTimings {
 		s.timings = make(map[string]cachedtimings)
 	}
 
-	for _, metric := range s.gauges {
-		acc.AddGauge(metric.name, metric.fields, metric.tags, now)
+	for key, metric := range s.gauges {
+		acc.AddGauge(metric.name, metric.fields, metric.tags, timestamp(metric.timestamp, now))
+		metric.timestamp = time.Time{}
+		s.gauges[key] = metric
 	}
 	if s.DeleteGauges {
 		s.gauges = make(map[string]cachedgauge)
 	}
 
-	for _, metric := range s.counters {
-		acc.AddCounter(metric.name, metric.fields, metric.tags, now)
+	for key, metric := range s.counters {
+		acc.AddCounter(metric.name, metric.fields, metric.tags, timestamp(metric.timestamp, now))
+		metric.timestamp = time.Time{}
+		s.counters[key] = metric
 	}
 	if s.DeleteCounters {
 		s.counters = make(map[string]cachedcounter)
 	}
 
-	for _, metric := range s.sets {
+	for key, metric := range s.sets {
 		fields := make(map[string]interface{})
 		for field, set := range metric.fields {
 			fields[field] = int64(len(set))
 		}
-		acc.AddFields(metric.name, fields, metric.tags, now)
+		acc.AddFields(metric.name, fields, metric.tags, timestamp(metric.timestamp, now))
+		metric.timestamp = time.Time{}
+		s.sets[key] = metric
 	}
 	if s.DeleteSets {
 		s.sets = make(map[string]cachedset)
@@ -283,12 +349,45 @@ func (s *Statsd) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
-func (s *Statsd) Start(_ telegraf.Accumulator) error {
+// expireCachedMetrics removes the series not updated within MaxTTL.
+func (s *Statsd) expireCachedMetrics(now time.Time) {
+	for key, cached := range s.gauges {
+		if now.After(cached.expiresAt) {
+			delete(s.gauges, key)
+		}
+	}
+	for key, cached := range s.counters {
+		if now.After(cached.expiresAt) {
+			delete(s.counters, key)
+		}
+	}
+	for key, cached := range s.sets {
+		if now.After(cached.expiresAt) {
+			delete(s.sets, key)
+		}
+	}
+	for key, cached := range s.timings {
+		if now.After(cached.expiresAt) {
+			delete(s.timings, key)
+		}
+	}
+}
+
+// timestamp returns the timestamp of a cached metric, or now if it has none.
+func timestamp(ts time.Time, now time.Time) time.Time {
+	if ts.IsZero() {
+		return now
+	}
+	return ts
+}
+
+func (s *Statsd) Start(acc telegraf.Accumulator) error {
 	// Make data structures
 	s.gauges = make(map[string]cachedgauge)
 	s.counters = make(map[string]cachedcounter)
 	s.sets = make(map[string]cachedset)
 	s.timings = make(map[string]cachedtimings)
+	s.acc = acc
 
 	s.Lock()
 	defer s.Unlock()
@@ -317,7 +416,7 @@ func (s *Statsd) Start(_ telegraf.Accumulator) error {
 	}
 
 	if s.ConvertNames {
-		log.Printf("I! WARNING statsd: convert_names config option is deprecated," +
+		s.Log.Warn("convert_names config option is deprecated," +
 			" please use metric_separator instead")
 	}
 
@@ -325,6 +424,13 @@ func (s *Statsd) Start(_ telegraf.Accumulator) error {
 		s.MetricSeparator = defaultSeparator
 	}
 
+	switch s.PercentileMethod {
+	case "", percentileSample, percentileSketch:
+	default:
+		return fmt.Errorf("invalid percentile_method %q, must be %q or %q",
+			s.PercentileMethod, percentileSample, percentileSketch)
+	}
+
 	s.wg.Add(2)
 	// Start the UDP listener
 	if s.isUDP() {
@@ -334,7 +440,7 @@ func (s *Statsd) Start(_ telegraf.Accumulator) error {
 	}
 	// Start the line parser
 	go s.parser()
-	log.Printf("I! Started the statsd service on %s\n", s.ServiceAddress)
+	s.Log.Infof("Started the statsd service on %s", s.ServiceAddress)
 	return nil
 }
 
@@ -349,7 +455,7 @@ func (s *Statsd) tcpListen() error {
 		log.Fatalf("ERROR: ListenTCP - %s", err)
 		return err
 	}
-	log.Println("I! TCP Statsd listening on: ", s.TCPlistener.Addr().String())
+	s.Log.Infof("TCP listening on %q", s.TCPlistener.Addr().String())
 	for {
 		select {
 		case <-s.done:
@@ -386,7 +492,7 @@ func (s *Statsd) udpListen() error {
 	if err != nil {
 		log.Fatalf("ERROR: ListenUDP - %s", err)
 	}
-	log.Println("I! Statsd UDP listener listening on: ", s.UDPlistener.LocalAddr().String())
+	s.Log.Infof("UDP listening on %q", s.UDPlistener.LocalAddr().String())
 
 	buf := make([]byte, UDP_MAX_PACKET_SIZE)
 	for {
@@ -396,7 +502,7 @@ func (s *Statsd) udpListen() error {
 		default:
 			n, _, err := s.UDPlistener.ReadFromUDP(buf)
 			if err != nil && !strings.Contains(err.Error(), "closed network") {
-				log.Printf("E! Error READ: %s\n", err.Error())
+				s.Log.Errorf("Error reading: %s", err.Error())
 				continue
 			}
 			b := s.bufPool.Get().(*bytes.Buffer)
@@ -408,7 +514,7 @@ func (s *Statsd) udpListen() error {
 			default:
 				s.drops++
 				if s.drops == 1 || s.AllowedPendingMessages == 0 || s.drops%s.AllowedPendingMessages == 0 {
-					log.Printf(dropwarn, s.drops)
+					s.Log.Errorf(dropwarn, s.drops)
 				}
 			}
 		}
@@ -443,37 +549,46 @@ func (s *Statsd) parseStatsdLine(line string) error {
 	s.Lock()
 	defer s.Unlock()
 
+	if s.DataDogExtensions && isDataDogMessage(line) {
+		if err := s.parseDataDogMessage(time.Now(), line); err != nil {
+			s.Log.Errorf("%s", err)
+			return errors.New("Error Parsing statsd line")
+		}
+		return nil
+	}
+
 	lineTags := make(map[string]string)
-	if s.ParseDataDogTags {
+	var lineTime time.Time
+	if s.ParseDataDogTags || s.DataDogExtensions {
 		recombinedSegments := make([]string, 0)
 		// datadog tags look like this:
 		// users.online:1|c|@0.5|#country:china,environment:production
 		// users.online:1|c|#sometagwithnovalue
+		// and the container ID and timestamp extensions like this:
+		// users.online:1|c|#sometagwithnovalue|c:83c0a99c0a54|T1656581400
 		// we will split on the pipe and remove any elements that are datadog
-		// tags, parse them, and rebuild the line sans the datadog tags
+		// tags or extensions, parse them, and rebuild the line sans them
 		pipesplit := strings.Split(line, "|")
-		for _, segment := range pipesplit {
-			if len(segment) > 0 && segment[0] == '#' {
+		for i, segment := range pipesplit {
+			// the extensions follow the value and type segments; on
+			// multi-value lines like "foo:1|c:2|g" a counter type followed by
+			// the next value is told apart by the type that follows it
+			extension := s.DataDogExtensions && i >= 2
+			switch {
+			case len(segment) > 0 && segment[0] == '#':
 				// we have ourselves a tag; they are comma separated
-				tagstr := segment[1:]
-				tags := strings.Split(tagstr, ",")
-				for _, tag := range tags {
-					ts := strings.SplitN(tag, ":", 2)
-					var k, v string
-					switch len(ts) {
-					case 1:
-						// just a tag
-						k = ts[0]
-						v = ""
-					case 2:
-						k = ts[0]
-						v = ts[1]
-					}
-					if k != "" {
-						lineTags[k] = v
-					}
+				parseDataDogTags(segment[1:], lineTags)
+			case extension && strings.HasPrefix(segment, "c:") &&
+				(i == len(pipesplit)-1 || !isMetricType(pipesplit[i+1])):
+				lineTags["container_id"] = segment[2:]
+			case extension && len(segment) > 1 && segment[0] == 'T':
+				sec, err := strconv.ParseInt(segment[1:], 10, 64)
+				if err != nil {
+					s.Log.Errorf("Parsing timestamp %q, ignoring it for line: %s", segment[1:], line)
+					continue
 				}
-			} else {
+				lineTime = time.Unix(sec, 0)
+			default:
 				recombinedSegments = append(recombinedSegments, segment)
 			}
 		}
@@ -483,7 +598,7 @@ func (s *Statsd) parseStatsdLine(line string) error {
 	// Validate splitting the line on ":"
 	bits := strings.Split(line, ":")
 	if len(bits) < 2 {
-		log.Printf("E! Error: splitting ':', Unable to parse metric: %s\n", line)
+		s.Log.Errorf("Splitting ':', unable to parse metric: %s", line)
 		return errors.New("Error Parsing statsd line")
 	}
 
@@ -495,26 +610,27 @@ func (s *Statsd) parseStatsdLine(line string) error {
 		m := metric{}
 
 		m.bucket = bucketName
+		m.timestamp = lineTime
 
 		// Validate splitting the bit on "|"
 		pipesplit := strings.Split(bit, "|")
 		if len(pipesplit) < 2 {
-			log.Printf("E! Error: splitting '|', Unable to parse metric: %s\n", line)
+			s.Log.Errorf("Splitting '|', unable to parse metric: %s", line)
 			return errors.New("Error Parsing statsd line")
 		} else if len(pipesplit) > 2 {
 			sr := pipesplit[2]
-			errmsg := "E! Error: parsing sample rate, %s, it must be in format like: " +
-				"@0.1, @0.5, etc. Ignoring sample rate for line: %s\n"
+			errmsg := "Parsing sample rate, %s, it must be in format like: " +
+				"@0.1, @0.5, etc. Ignoring sample rate for line: %s"
 			if strings.Contains(sr, "@") && len(sr) > 1 {
 				samplerate, err := strconv.ParseFloat(sr[1:], 64)
 				if err != nil {
-					log.Printf(errmsg, err.Error(), line)
+					s.Log.Errorf(errmsg, err.Error(), line)
 				} else {
 					// sample rate successfully parsed
 					m.samplerate = samplerate
 				}
 			} else {
-				log.Printf(errmsg, "", line)
+				s.Log.Errorf(errmsg, "", line)
 			}
 		}
 
@@ -522,25 +638,31 @@ func (s *Statsd) parseStatsdLine(line string) error {
 		switch pipesplit[1] {
 		case "g", "c", "s", "ms", "h":
 			m.mtype = pipesplit[1]
+		case "d":
+			if !s.DataDogExtensions {
+				s.Log.Errorf("Metric type %q requires datadog_extensions", pipesplit[1])
+				return errors.New("Error Parsing statsd line")
+			}
+			m.mtype = pipesplit[1]
 		default:
-			log.Printf("E! Error: Statsd Metric type %s unsupported", pipesplit[1])
+			s.Log.Errorf("Metric type %q unsupported", pipesplit[1])
 			return errors.New("Error Parsing statsd line")
 		}
 
 		// Parse the value
 		if strings.HasPrefix(pipesplit[0], "-") || strings.HasPrefix(pipesplit[0], "+") {
 			if m.mtype != "g" && m.mtype != "c" {
-				log.Printf("E! Error: +- values are only supported for gauges & counters: %s\n", line)
+				s.Log.Errorf("+- values are only supported for gauges & counters: %s", line)
 				return errors.New("Error Parsing statsd line")
 			}
 			m.additive = true
 		}
 
 		switch m.mtype {
-		case "g", "ms", "h":
+		case "g", "ms", "h", "d":
 			v, err := strconv.ParseFloat(pipesplit[0], 64)
 			if err != nil {
-				log.Printf("E! Error: parsing value to float64: %s\n", line)
+				s.Log.Errorf("Parsing value to float64: %s", line)
 				return errors.New("Error Parsing statsd line")
 			}
 			m.floatvalue = v
@@ -550,7 +672,7 @@ func (s *Statsd) parseStatsdLine(line string) error {
 			if err != nil {
 				v2, err2 := strconv.ParseFloat(pipesplit[0], 64)
 				if err2 != nil {
-					log.Printf("E! Error: parsing value to int64: %s\n", line)
+					s.Log.Errorf("Parsing value to int64: %s", line)
 					return errors.New("Error Parsing statsd line")
 				}
 				v = int64(v2)
@@ -577,6 +699,8 @@ func (s *Statsd) parseStatsdLine(line string) error {
 			m.tags["metric_type"] = "timing"
 		case "h":
 			m.tags["metric_type"] = "histogram"
+		case "d":
+			m.tags["metric_type"] = "distribution"
 		}
 
 		if len(lineTags) > 0 {
@@ -600,6 +724,15 @@ func (s *Statsd) parseStatsdLine(line string) error {
 	return nil
 }
 
+// isMetricType returns true if the segment is a statsd metric type.
+func isMetricType(segment string) bool {
+	switch segment {
+	case "g", "c", "s", "ms", "h", "d":
+		return true
+	}
+	return false
+}
+
 // parseName parses the given bucket name with the list of bucket maps in the
 // config file. If there is a match, it will parse the name of the metric and
 // map of tags.
@@ -665,8 +798,9 @@ func parseKeyValue(keyvalue string) (string, string) {
 // aggregates and caches the current value(s). It does not deal with the
 // Delete* options, because those are dealt with in the Gather function.
 func (s *Statsd) aggregate(m metric) {
+	expiresAt := time.Now().Add(s.MaxTTL.Duration)
 	switch m.mtype {
-	case "ms", "h":
+	case "ms", "h", "d":
 		// Check if the measurement exists
 		cached, ok := s.timings[m.hash]
 		if !ok {
@@ -683,6 +817,9 @@ func (s *Statsd) aggregate(m metric) {
 			field = RunningStats{
 				PercLimit: s.PercentileLimit,
 			}
+			if s.PercentileMethod == percentileSketch {
+				field.Sketch = NewSketch(s.SketchRelativeAccuracy, s.SketchMaxBins)
+			}
 		}
 		if m.samplerate > 0 {
 			for i := 0; i < int(1.0/m.samplerate); i++ {
@@ -692,17 +829,26 @@ func (s *Statsd) aggregate(m metric) {
 			field.AddValue(m.floatvalue)
 		}
 		cached.fields[m.field] = field
+		if m.timestamp.After(cached.timestamp) {
+			cached.timestamp = m.timestamp
+		}
+		cached.expiresAt = expiresAt
 		s.timings[m.hash] = cached
 	case "c":
 		// check if the measurement exists
-		_, ok := s.counters[m.hash]
+		cached, ok := s.counters[m.hash]
 		if !ok {
-			s.counters[m.hash] = cachedcounter{
+			cached = cachedcounter{
 				name:   m.name,
 				fields: make(map[string]interface{}),
 				tags:   m.tags,
 			}
 		}
+		if m.timestamp.After(cached.timestamp) {
+			cached.timestamp = m.timestamp
+		}
+		cached.expiresAt = expiresAt
+		s.counters[m.hash] = cached
 		// check if the field exists
 		_, ok = s.counters[m.hash].fields[m.field]
 		if !ok {
@@ -712,14 +858,19 @@ func (s *Statsd) aggregate(m metric) {
 			s.counters[m.hash].fields[m.field].(int64) + m.intvalue
 	case "g":
 		// check if the measurement exists
-		_, ok := s.gauges[m.hash]
+		cached, ok := s.gauges[m.hash]
 		if !ok {
-			s.gauges[m.hash] = cachedgauge{
+			cached = cachedgauge{
 				name:   m.name,
 				fields: make(map[string]interface{}),
 				tags:   m.tags,
 			}
 		}
+		if m.timestamp.After(cached.timestamp) {
+			cached.timestamp = m.timestamp
+		}
+		cached.expiresAt = expiresAt
+		s.gauges[m.hash] = cached
 		// check if the field exists
 		_, ok = s.gauges[m.hash].fields[m.field]
 		if !ok {
@@ -733,14 +884,19 @@ func (s *Statsd) aggregate(m metric) {
 		}
 	case "s":
 		// check if the measurement exists
-		_, ok := s.sets[m.hash]
+		cached, ok := s.sets[m.hash]
 		if !ok {
-			s.sets[m.hash] = cachedset{
+			cached = cachedset{
 				name:   m.name,
 				fields: make(map[string]map[string]bool),
 				tags:   m.tags,
 			}
 		}
+		if m.timestamp.After(cached.timestamp) {
+			cached.timestamp = m.timestamp
+		}
+		cached.expiresAt = expiresAt
+		s.sets[m.hash] = cached
 		// check if the field exists
 		_, ok = s.sets[m.hash].fields[m.field]
 		if !ok {
@@ -791,7 +947,7 @@ func (s *Statsd) handler(conn *net.TCPConn, id string) {
 			default:
 				s.drops++
 				if s.drops == 1 || s.drops%s.AllowedPendingMessages == 0 {
-					log.Printf(dropwarn, s.drops)
+					s.Log.Errorf(dropwarn, s.drops)
 				}
 			}
 		}
@@ -801,8 +957,8 @@ func (s *Statsd) handler(conn *net.TCPConn, id string) {
 // refuser refuses a TCP connection
 func (s *Statsd) refuser(conn *net.TCPConn) {
 	conn.Close()
-	log.Printf("I! Refused TCP Connection from %s", conn.RemoteAddr())
-	log.Printf("I! WARNING: Maximum TCP Connections reached, you may want to" +
+	s.Log.Infof("Refused TCP Connection from %s", conn.RemoteAddr())
+	s.Log.Warn("Maximum TCP Connections reached, you may want to" +
 		" adjust max_tcp_connections")
 }
 
@@ -822,7 +978,7 @@ func (s *Statsd) remember(id string, conn *net.TCPConn) {
 
 func (s *Statsd) Stop() {
 	s.Lock()
-	log.Println("I! Stopping the statsd service")
+	s.Log.Info("Stopping the statsd service")
 	close(s.done)
 	if s.isUDP() {
 		s.UDPlistener.Close()
@@ -848,7 +1004,7 @@ func (s *Statsd) Stop() {
 
 	s.Lock()
 	close(s.in)
-	log.Println("I! Stopped Statsd listener service on ", s.ServiceAddress)
+	s.Log.Infof("Stopped listener service on %q", s.ServiceAddress)
 	s.Unlock()
 }
 
@@ -869,6 +1025,9 @@ func init() {
 			DeleteGauges:           true,
 			DeleteSets:             true,
 			DeleteTimings:          true,
+			PercentileMethod:       percentileSample,
+			SketchRelativeAccuracy: defaultSketchRelativeAccuracy,
+			SketchMaxBins:          defaultSketchMaxBins,
 		}
 	})
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,7 @@ const (
 func newTestTcpListener() (*Statsd, chan *bytes.Buffer) {
 	in := make(chan *bytes.Buffer, 1500)
 	listener := &Statsd{
+		Log:                    testutil.Logger{},
 		Protocol:               "tcp",
 		ServiceAddress:         "localhost:8125",
 		AllowedPendingMessages: 10000,
@@ -31,7 +33,7 @@ func newTestTcpListener() (*Statsd, chan *bytes.Buffer) {
 }
 
 func NewTestStatsd() *Statsd {
-	s := Statsd{}
+	s := Statsd{Log: testutil.Logger{}}
 
 	// Make data structures
 	s.done = make(chan struct{})
@@ -49,6 +51,7 @@ func NewTestStatsd() *Statsd {
 // Test that MaxTCPConections is respected
 func TestConcurrentConns(t *testing.T) {
 	listener := Statsd{
+		Log:                    testutil.Logger{},
 		Protocol:               "tcp",
 		ServiceAddress:         "localhost:8125",
 		AllowedPendingMessages: 10000,
@@ -79,6 +82,7 @@ func TestConcurrentConns(t *testing.T) {
 // Test that MaxTCPConections is respected when max==1
 func TestConcurrentConns1(t *testing.T) {
 	listener := Statsd{
+		Log:                    testutil.Logger{},
 		Protocol:               "tcp",
 		ServiceAddress:         "localhost:8125",
 		AllowedPendingMessages: 10000,
@@ -107,6 +111,7 @@ func TestConcurrentConns1(t *testing.T) {
 // Test that MaxTCPConections is respected
 func TestCloseConcurrentConns(t *testing.T) {
 	listener := Statsd{
+		Log:                    testutil.Logger{},
 		Protocol:               "tcp",
 		ServiceAddress:         "localhost:8125",
 		AllowedPendingMessages: 10000,
@@ -128,6 +133,7 @@ func TestCloseConcurrentConns(t *testing.T) {
 // benchmark how long it takes to accept & process 100,000 metrics:
 func BenchmarkUDP(b *testing.B) {
 	listener := Statsd{
+		Log:                    testutil.Logger{},
 		Protocol:               "udp",
 		ServiceAddress:         "localhost:8125",
 		AllowedPendingMessages: 250000,
@@ -158,6 +164,7 @@ func BenchmarkUDP(b *testing.B) {
 // benchmark how long it takes to accept & process 100,000 metrics:
 func BenchmarkTCP(b *testing.B) {
 	listener := Statsd{
+		Log:                    testutil.Logger{},
 		Protocol:               "tcp",
 		ServiceAddress:         "localhost:8125",
 		AllowedPendingMessages: 250000,
@@ -1600,3 +1607,55 @@ func test_validate_gauge(
 	}
 	return nil
 }
+
+// Test that the series not updated within max_ttl are expired, whatever the
+// Delete* flags
+func TestParse_MaxTTL(t *testing.T) {
+	s := NewTestStatsd()
+	s.MaxTTL = internal.Duration{Duration: 100 * time.Millisecond}
+
+	lines := []string{
+		"old.gauge:1|g",
+		"old.counter:1|c",
+		"old.set:1|s",
+		"old.timing:1|ms",
+	}
+	for _, line := range lines {
+		require.NoError(t, s.parseStatsdLine(line))
+	}
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.Len(t, acc.Metrics, 4)
+
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, s.parseStatsdLine("new.gauge:1|g"))
+
+	acc.ClearMetrics()
+	require.NoError(t, s.Gather(acc))
+	require.Len(t, acc.Metrics, 1)
+	assert.Equal(t, "new_gauge", acc.Metrics[0].Measurement)
+	assert.Len(t, s.counters, 0)
+	assert.Len(t, s.sets, 0)
+	assert.Len(t, s.timings, 0)
+}
+
+// Test that the sketch estimates the percentiles of timings
+func TestParse_TimingsSketch(t *testing.T) {
+	s := NewTestStatsd()
+	s.Percentiles = []int{50, 90}
+	s.PercentileMethod = "sketch"
+
+	for i := 1; i <= 100; i++ {
+		require.NoError(t, s.parseStatsdLine(fmt.Sprintf("test.timing:%d|ms", i)))
+	}
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+
+	m, ok := acc.Get("test_timing")
+	require.True(t, ok)
+	assert.Equal(t, int64(100), m.Fields["count"])
+	assert.InEpsilon(t, 50, m.Fields["50_percentile"], 0.01)
+	assert.InEpsilon(t, 90, m.Fields["90_percentile"], 0.01)
+}
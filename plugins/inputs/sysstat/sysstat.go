@@ -7,7 +7,6 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"os/exec"
 	"path"
@@ -62,6 +61,9 @@ type Sysstat struct {
 
 	// DeviceTags adds the possibility to add additional tags for devices.
 	DeviceTags map[string][]map[string]string `toml:"device_tags"`
+
+	Log telegraf.Logger `toml:"-"`
+
 	tmpFile    string
 	interval   int
 }
@@ -188,7 +190,7 @@ func (s *Sysstat) collect() error {
 	out, err := internal.CombinedOutputTimeout(cmd, time.Second*time.Duration(collectInterval+parseInterval))
 	if err != nil {
 		if err := os.Remove(s.tmpFile); err != nil {
-			log.Printf("E! failed to remove tmp file after %s command: %s", strings.Join(cmd.Args, " "), err)
+			s.Log.Errorf("failed to remove tmp file after %s command: %s", strings.Join(cmd.Args, " "), err)
 		}
 		return fmt.Errorf("failed to run command %s: %s - %s", strings.Join(cmd.Args, " "), err, string(out))
 	}
@@ -2,7 +2,6 @@ package system
 
 import (
 	"fmt"
-	"log"
 	"regexp"
 	"strings"
 
@@ -23,6 +22,8 @@ type DiskIO struct {
 	NameTemplates    []string
 	SkipSerialNumber bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	infoCache    map[string]diskInfoCache
 	deviceFilter filter.Filter
 	initialized  bool
@@ -141,7 +142,7 @@ func (s *DiskIO) diskName(devName string) string {
 
 	di, err := s.diskInfo(devName)
 	if err != nil {
-		log.Printf("W! Error gathering disk info: %s", err)
+		s.Log.Warnf("Error gathering disk info: %s", err)
 		return devName
 	}
 
@@ -174,7 +175,7 @@ func (s *DiskIO) diskTags(devName string) map[string]string {
 
 	di, err := s.diskInfo(devName)
 	if err != nil {
-		log.Printf("W! Error gathering disk info: %s", err)
+		s.Log.Warnf("Error gathering disk info: %s", err)
 		return nil
 	}
 
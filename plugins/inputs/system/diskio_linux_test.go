@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf/testutil"
 )
 
 var nullDiskInfo = []byte(`
@@ -42,7 +44,7 @@ func TestDiskInfo(t *testing.T) {
 	clean := setupNullDisk(t)
 	defer clean()
 
-	s := &DiskIO{}
+	s := &DiskIO{Log: testutil.Logger{}}
 	di, err := s.diskInfo("null")
 	require.NoError(t, err)
 	assert.Equal(t, "myval1", di["MY_PARAM_1"])
@@ -82,6 +84,7 @@ func TestDiskIOStats_diskName(t *testing.T) {
 
 	for _, tc := range tests {
 		s := DiskIO{
+			Log:           testutil.Logger{},
 			NameTemplates: tc.templates,
 		}
 		assert.Equal(t, tc.expected, s.diskName("null"), "Templates: %#v", tc.templates)
@@ -94,6 +97,7 @@ func TestDiskIOStats_diskTags(t *testing.T) {
 	defer setupNullDisk(t)()
 
 	s := &DiskIO{
+		Log:        testutil.Logger{},
 		DeviceTags: []string{"MY_PARAM_2"},
 	}
 	dt := s.diskTags("null")
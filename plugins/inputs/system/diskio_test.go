@@ -102,6 +102,7 @@ func TestDiskIO(t *testing.T) {
 			var acc testutil.Accumulator
 
 			diskio := &DiskIO{
+				Log:     testutil.Logger{},
 				ps:      &mps,
 				Devices: tt.devices,
 			}
@@ -6,7 +6,6 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,6 +18,8 @@ import (
 )
 
 type Processes struct {
+	Log telegraf.Logger `toml:"-"`
+
 	execPS       func() ([]byte, error)
 	readProcFile func(filename string) ([]byte, error)
 
@@ -123,7 +124,7 @@ func (p *Processes) gatherFromPS(fields map[string]interface{}) error {
 		case '?':
 			fields["unknown"] = fields["unknown"].(int64) + int64(1)
 		default:
-			log.Printf("I! processes: Unknown state [ %s ] from ps",
+			p.Log.Infof("Unknown state [ %s ] from ps",
 				string(status[0]))
 		}
 		fields["total"] = fields["total"].(int64) + int64(1)
@@ -178,14 +179,14 @@ func (p *Processes) gatherFromProc(fields map[string]interface{}) error {
 		case 'I':
 			fields["idle"] = fields["idle"].(int64) + int64(1)
 		default:
-			log.Printf("I! processes: Unknown state [ %s ] in file %s",
+			p.Log.Infof("Unknown state [ %s ] in file %s",
 				string(stats[0][0]), filename)
 		}
 		fields["total"] = fields["total"].(int64) + int64(1)
 
 		threads, err := strconv.Atoi(string(stats[17]))
 		if err != nil {
-			log.Printf("I! processes: Error parsing thread count: %s", err)
+			p.Log.Infof("Error parsing thread count: %s", err)
 			continue
 		}
 		fields["total_threads"] = fields["total_threads"].(int64) + int64(threads)
@@ -12,6 +12,7 @@ import (
 
 func TestProcesses(t *testing.T) {
 	processes := &Processes{
+		Log:          testutil.Logger{},
 		execPS:       execPS,
 		readProcFile: readProcFile,
 	}
@@ -31,6 +32,7 @@ func TestProcesses(t *testing.T) {
 
 func TestFromPS(t *testing.T) {
 	processes := &Processes{
+		Log:     testutil.Logger{},
 		execPS:  testExecPS,
 		forcePS: true,
 	}
@@ -52,6 +54,7 @@ func TestFromPS(t *testing.T) {
 
 func TestFromPSError(t *testing.T) {
 	processes := &Processes{
+		Log:     testutil.Logger{},
 		execPS:  testExecPSError,
 		forcePS: true,
 	}
@@ -67,6 +70,7 @@ func TestFromProcFiles(t *testing.T) {
 	}
 	tester := tester{}
 	processes := &Processes{
+		Log:          testutil.Logger{},
 		readProcFile: tester.testProcFile,
 		forceProc:    true,
 	}
@@ -89,6 +93,7 @@ func TestFromProcFilesWithSpaceInCmd(t *testing.T) {
 	}
 	tester := tester{}
 	processes := &Processes{
+		Log:          testutil.Logger{},
 		readProcFile: tester.testProcFile2,
 		forceProc:    true,
 	}
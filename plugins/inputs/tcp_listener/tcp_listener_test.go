@@ -33,6 +33,7 @@ cpu_load_short,host=server06 value=12.0 1422568543702900257
 func newTestTcpListener() (*TcpListener, chan []byte) {
 	in := make(chan []byte, 1500)
 	listener := &TcpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         "localhost:8194",
 		AllowedPendingMessages: 10000,
 		MaxTCPConnections:      250,
@@ -45,6 +46,7 @@ func newTestTcpListener() (*TcpListener, chan []byte) {
 // benchmark how long it takes to accept & process 100,000 metrics:
 func BenchmarkTCP(b *testing.B) {
 	listener := TcpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         "localhost:8198",
 		AllowedPendingMessages: 100000,
 		MaxTCPConnections:      250,
@@ -76,6 +78,7 @@ func BenchmarkTCP(b *testing.B) {
 
 func TestHighTrafficTCP(t *testing.T) {
 	listener := TcpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         "localhost:8199",
 		AllowedPendingMessages: 100000,
 		MaxTCPConnections:      250,
@@ -103,6 +106,7 @@ func TestHighTrafficTCP(t *testing.T) {
 
 func TestConnectTCP(t *testing.T) {
 	listener := TcpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         "localhost:8194",
 		AllowedPendingMessages: 10000,
 		MaxTCPConnections:      250,
@@ -140,6 +144,7 @@ func TestConnectTCP(t *testing.T) {
 // Test that MaxTCPConections is respected
 func TestConcurrentConns(t *testing.T) {
 	listener := TcpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         "localhost:8195",
 		AllowedPendingMessages: 10000,
 		MaxTCPConnections:      2,
@@ -175,6 +180,7 @@ func TestConcurrentConns(t *testing.T) {
 // Test that MaxTCPConections is respected when max==1
 func TestConcurrentConns1(t *testing.T) {
 	listener := TcpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         "localhost:8196",
 		AllowedPendingMessages: 10000,
 		MaxTCPConnections:      1,
@@ -208,6 +214,7 @@ func TestConcurrentConns1(t *testing.T) {
 // Test that MaxTCPConections is respected
 func TestCloseConcurrentConns(t *testing.T) {
 	listener := TcpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         "localhost:8195",
 		AllowedPendingMessages: 10000,
 		MaxTCPConnections:      2,
@@ -2,7 +2,6 @@ package udp_listener
 
 import (
 	"fmt"
-	"log"
 	"net"
 	"sync"
 	"time"
@@ -53,17 +52,19 @@ type UdpListener struct {
 
 	PacketsRecv selfstat.Stat
 	BytesRecv   selfstat.Stat
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 // UDP_MAX_PACKET_SIZE is packet limit, see
 // https://en.wikipedia.org/wiki/User_Datagram_Protocol#Packet_structure
 const UDP_MAX_PACKET_SIZE int = 64 * 1024
 
-var dropwarn = "E! Error: udp_listener message queue full. " +
+var dropwarn = "Error: udp_listener message queue full. " +
 	"We have dropped %d messages so far. " +
-	"You may want to increase allowed_pending_messages in the config\n"
+	"You may want to increase allowed_pending_messages in the config"
 
-var malformedwarn = "E! udp_listener has received %d malformed packets" +
+var malformedwarn = "udp_listener has received %d malformed packets" +
 	" thus far."
 
 const sampleConfig = `
@@ -94,7 +95,7 @@ func (u *UdpListener) Start(acc telegraf.Accumulator) error {
 	u.Lock()
 	defer u.Unlock()
 
-	log.Println("W! DEPRECATED: the UDP listener plugin has been deprecated " +
+	u.Log.Warn("DEPRECATED: the UDP listener plugin has been deprecated " +
 		"in favor of the socket_listener plugin " +
 		"(https://github.com/influxdata/telegraf/tree/master/plugins/inputs/socket_listener)")
 
@@ -113,7 +114,7 @@ func (u *UdpListener) Start(acc telegraf.Accumulator) error {
 	u.wg.Add(1)
 	go u.udpParser()
 
-	log.Printf("I! Started UDP listener service on %s (ReadBuffer: %d)\n", u.ServiceAddress, u.UDPBufferSize)
+	u.Log.Infof("Started UDP listener service on %s (ReadBuffer: %d)", u.ServiceAddress, u.UDPBufferSize)
 	return nil
 }
 
@@ -124,7 +125,7 @@ func (u *UdpListener) Stop() {
 	u.wg.Wait()
 	u.listener.Close()
 	close(u.in)
-	log.Println("I! Stopped UDP listener service on ", u.ServiceAddress)
+	u.Log.Info("Stopped UDP listener service on ", u.ServiceAddress)
 }
 
 func (u *UdpListener) udpListen() error {
@@ -137,7 +138,7 @@ func (u *UdpListener) udpListen() error {
 		return fmt.Errorf("E! Error: ListenUDP - %s", err)
 	}
 
-	log.Println("I! UDP server listening on: ", u.listener.LocalAddr().String())
+	u.Log.Info("UDP server listening on: ", u.listener.LocalAddr().String())
 
 	if u.UDPBufferSize > 0 {
 		err = u.listener.SetReadBuffer(u.UDPBufferSize) // if we want to move away from OS default
@@ -166,7 +167,7 @@ func (u *UdpListener) udpListenLoop() {
 			if err != nil {
 				if err, ok := err.(net.Error); ok && err.Timeout() {
 				} else {
-					log.Printf("E! Error: %s\n", err.Error())
+					u.Log.Errorf("Error: %s", err.Error())
 				}
 				continue
 			}
@@ -180,7 +181,7 @@ func (u *UdpListener) udpListenLoop() {
 			default:
 				u.drops++
 				if u.drops == 1 || u.drops%u.AllowedPendingMessages == 0 {
-					log.Printf(dropwarn, u.drops)
+					u.Log.Errorf(dropwarn, u.drops)
 				}
 			}
 		}
@@ -208,7 +209,7 @@ func (u *UdpListener) udpParser() error {
 			} else {
 				u.malformed++
 				if u.malformed == 1 || u.malformed%1000 == 0 {
-					log.Printf(malformedwarn, u.malformed)
+					u.Log.Errorf(malformedwarn, u.malformed)
 				}
 			}
 		}
@@ -34,16 +34,18 @@ cpu_load_short,host=server06 value=12.0 1422568543702900257
 func newTestUdpListener() (*UdpListener, chan []byte) {
 	in := make(chan []byte, 1500)
 	listener := &UdpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         ":8125",
 		AllowedPendingMessages: 10000,
-		in:   in,
-		done: make(chan struct{}),
+		in:                     in,
+		done:                   make(chan struct{}),
 	}
 	return listener, in
 }
 
 func TestHighTrafficUDP(t *testing.T) {
 	listener := UdpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         ":8126",
 		AllowedPendingMessages: 100000,
 	}
@@ -79,6 +81,7 @@ func TestHighTrafficUDP(t *testing.T) {
 
 func TestConnectUDP(t *testing.T) {
 	listener := UdpListener{
+		Log:                    testutil.Logger{},
 		ServiceAddress:         ":8127",
 		AllowedPendingMessages: 10000,
 	}
@@ -3,7 +3,6 @@ package filestack
 import (
 	"encoding/json"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"time"
 
@@ -14,13 +13,15 @@ import (
 type FilestackWebhook struct {
 	Path string
 	acc  telegraf.Accumulator
+	log  telegraf.Logger
 }
 
-func (fs *FilestackWebhook) Register(router *mux.Router, acc telegraf.Accumulator) {
+func (fs *FilestackWebhook) Register(router *mux.Router, acc telegraf.Accumulator, log telegraf.Logger) {
 	router.HandleFunc(fs.Path, fs.eventHandler).Methods("POST")
 
-	log.Printf("I! Started the webhooks_filestack on %s\n", fs.Path)
 	fs.acc = acc
+	fs.log = log
+	fs.log.Infof("Started the webhooks_filestack on %s", fs.Path)
 }
 
 func (fs *FilestackWebhook) eventHandler(w http.ResponseWriter, r *http.Request) {
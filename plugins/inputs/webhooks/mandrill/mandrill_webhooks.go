@@ -3,7 +3,6 @@ package mandrill
 import (
 	"encoding/json"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,14 +14,16 @@ import (
 type MandrillWebhook struct {
 	Path string
 	acc  telegraf.Accumulator
+	log  telegraf.Logger
 }
 
-func (md *MandrillWebhook) Register(router *mux.Router, acc telegraf.Accumulator) {
+func (md *MandrillWebhook) Register(router *mux.Router, acc telegraf.Accumulator, log telegraf.Logger) {
 	router.HandleFunc(md.Path, md.returnOK).Methods("HEAD")
 	router.HandleFunc(md.Path, md.eventHandler).Methods("POST")
 
-	log.Printf("I! Started the webhooks_mandrill on %s\n", md.Path)
 	md.acc = acc
+	md.log = log
+	md.log.Infof("Started the webhooks_mandrill on %s", md.Path)
 }
 
 func (md *MandrillWebhook) returnOK(w http.ResponseWriter, r *http.Request) {
@@ -2,7 +2,6 @@ package papertrail
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 	"time"
 
@@ -13,12 +12,14 @@ import (
 type PapertrailWebhook struct {
 	Path string
 	acc  telegraf.Accumulator
+	log  telegraf.Logger
 }
 
-func (pt *PapertrailWebhook) Register(router *mux.Router, acc telegraf.Accumulator) {
+func (pt *PapertrailWebhook) Register(router *mux.Router, acc telegraf.Accumulator, log telegraf.Logger) {
 	router.HandleFunc(pt.Path, pt.eventHandler).Methods("POST")
-	log.Printf("I! Started the papertrail_webhook on %s", pt.Path)
 	pt.acc = acc
+	pt.log = log
+	pt.log.Infof("Started the papertrail_webhook on %s", pt.Path)
 }
 
 func (pt *PapertrailWebhook) eventHandler(w http.ResponseWriter, r *http.Request) {
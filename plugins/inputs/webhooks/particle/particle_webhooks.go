@@ -38,11 +38,13 @@ func (e *event) Time() (time.Time, error) {
 type ParticleWebhook struct {
 	Path string
 	acc  telegraf.Accumulator
+	log  telegraf.Logger
 }
 
-func (rb *ParticleWebhook) Register(router *mux.Router, acc telegraf.Accumulator) {
+func (rb *ParticleWebhook) Register(router *mux.Router, acc telegraf.Accumulator, log telegraf.Logger) {
 	router.HandleFunc(rb.Path, rb.eventHandler).Methods("POST")
 	rb.acc = acc
+	rb.log = log
 }
 
 func (rb *ParticleWebhook) eventHandler(w http.ResponseWriter, r *http.Request) {
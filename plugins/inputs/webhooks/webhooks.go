@@ -2,7 +2,6 @@ package webhooks
 
 import (
 	"fmt"
-	"log"
 	"net"
 	"net/http"
 	"reflect"
@@ -20,7 +19,7 @@ import (
 )
 
 type Webhook interface {
-	Register(router *mux.Router, acc telegraf.Accumulator)
+	Register(router *mux.Router, acc telegraf.Accumulator, log telegraf.Logger)
 }
 
 func init() {
@@ -37,6 +36,8 @@ type Webhooks struct {
 	Papertrail *papertrail.PapertrailWebhook
 	Particle   *particle.ParticleWebhook
 
+	Log telegraf.Logger `toml:"-"`
+
 	srv *http.Server
 }
 
@@ -103,14 +104,14 @@ func (wb *Webhooks) Start(acc telegraf.Accumulator) error {
 	r := mux.NewRouter()
 
 	for _, webhook := range wb.AvailableWebhooks() {
-		webhook.Register(r, acc)
+		webhook.Register(r, acc, wb.Log)
 	}
 
 	wb.srv = &http.Server{Handler: r}
 
 	ln, err := net.Listen("tcp", fmt.Sprintf("%s", wb.ServiceAddress))
 	if err != nil {
-		log.Fatalf("E! Error starting server: %v", err)
+		wb.Log.Errorf("Error starting server: %v", err)
 		return err
 
 	}
@@ -123,12 +124,12 @@ func (wb *Webhooks) Start(acc telegraf.Accumulator) error {
 		}
 	}()
 
-	log.Printf("I! Started the webhooks service on %s\n", wb.ServiceAddress)
+	wb.Log.Infof("Started the webhooks service on %s", wb.ServiceAddress)
 
 	return nil
 }
 
 func (rb *Webhooks) Stop() {
 	rb.srv.Close()
-	log.Println("I! Stopping the Webhooks service")
+	rb.Log.Info("Stopping the Webhooks service")
 }
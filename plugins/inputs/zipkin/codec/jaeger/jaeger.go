@@ -0,0 +1,175 @@
+package jaeger
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jaeger/gen-go/jaeger"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/zipkinV2"
+
+	"github.com/apache/thrift/lib/go/thrift"
+)
+
+// Thrift decodes the Jaeger Batch structs, in the thrift binary protocol,
+// `POST`ed to the collector endpoint.
+type Thrift struct{}
+
+// Decode unmarshals the batch and converts its spans
+func (t *Thrift) Decode(octets []byte) ([]codec.Span, error) {
+	buffer := thrift.NewTMemoryBuffer()
+	if _, err := buffer.Write(octets); err != nil {
+		return nil, err
+	}
+
+	b := jaeger.NewBatch()
+	if err := b.Read(thrift.NewTBinaryProtocolTransport(buffer)); err != nil {
+		return nil, err
+	}
+	return codecSpans(b)
+}
+
+// Agent decodes the emitBatch messages, in the thrift compact protocol, that
+// the Jaeger clients send to the agent over UDP.
+type Agent struct{}
+
+// Decode unmarshals the emitBatch message and converts the spans of its batch
+func (a *Agent) Decode(octets []byte) ([]codec.Span, error) {
+	buffer := thrift.NewTMemoryBuffer()
+	if _, err := buffer.Write(octets); err != nil {
+		return nil, err
+	}
+	p := thrift.NewTCompactProtocol(buffer)
+
+	name, _, _, err := p.ReadMessageBegin()
+	if err != nil {
+		return nil, err
+	}
+	if name != "emitBatch" {
+		return nil, fmt.Errorf("unsupported Jaeger agent message %q", name)
+	}
+
+	args := jaeger.NewAgentEmitBatchArgs()
+	if err := args.Read(p); err != nil {
+		return nil, err
+	}
+	if err := p.ReadMessageEnd(); err != nil {
+		return nil, err
+	}
+	if args.Batch == nil {
+		return nil, fmt.Errorf("emitBatch message without a batch")
+	}
+	return codecSpans(args.Batch)
+}
+
+// value returns the value of the tag as a string.
+func value(t *jaeger.Tag) string {
+	switch t.VType {
+	case jaeger.TagType_DOUBLE:
+		return strconv.FormatFloat(t.GetVDouble(), 'f', -1, 64)
+	case jaeger.TagType_BOOL:
+		return strconv.FormatBool(t.GetVBool())
+	case jaeger.TagType_LONG:
+		return strconv.FormatInt(t.GetVLong(), 10)
+	case jaeger.TagType_BINARY:
+		return string(t.GetVBinary())
+	default:
+		return t.GetVStr()
+	}
+}
+
+// codecSpans converts the spans of the batch to Zipkin v2 spans.
+func codecSpans(b *jaeger.Batch) ([]codec.Span, error) {
+	local := &zipkinV2.Endpoint{ServiceName: b.Process.GetServiceName()}
+	for _, t := range b.Process.GetTags() {
+		if t.Key != "ip" {
+			continue
+		}
+		// clients report the ip as a string or as an integer
+		if t.VType == jaeger.TagType_LONG {
+			ip := uint32(t.GetVLong())
+			local.Ipv4 = net.IPv4(byte(ip>>24), byte(ip>>16), byte(ip>>8),
+				byte(ip)).String()
+		} else {
+			local.Ipv4 = value(t)
+		}
+	}
+
+	res := make([]codec.Span, len(b.Spans))
+	for i, s := range b.Spans {
+		zs := zipkin(s, local)
+		if err := zs.Validate(); err != nil {
+			return nil, err
+		}
+		res[i] = zs
+	}
+	return res, nil
+}
+
+// zipkin converts the span like the Jaeger collector does for the Zipkin
+// model.
+func zipkin(s *jaeger.Span, local *zipkinV2.Endpoint) *zipkinV2.Span {
+	zs := &zipkinV2.Span{
+		ID:            formatID(s.SpanId),
+		SpanName:      s.OperationName,
+		Time:          s.StartTime,
+		Dur:           s.Duration,
+		LocalEndpoint: local,
+	}
+	if s.TraceIdHigh == 0 {
+		zs.TraceID = formatID(s.TraceIdLow)
+	} else {
+		zs.TraceID = fmt.Sprintf("%x%016x", uint64(s.TraceIdHigh),
+			uint64(s.TraceIdLow))
+	}
+
+	parent := s.ParentSpanId
+	for _, ref := range s.References {
+		if parent == 0 && ref.RefType == jaeger.SpanRefType_CHILD_OF {
+			parent = ref.SpanId
+		}
+	}
+	if parent != 0 {
+		zs.ParentID = formatID(parent)
+	}
+
+	for _, t := range s.Tags {
+		if t.Key == "span.kind" {
+			zs.Kind = strings.ToUpper(value(t))
+			continue
+		}
+		if zs.Tags == nil {
+			zs.Tags = make(map[string]string)
+		}
+		zs.Tags[t.Key] = value(t)
+	}
+
+	for _, l := range s.Logs {
+		zs.Anno = append(zs.Anno, zipkinV2.Annotation{
+			Timestamp: l.Timestamp,
+			Value:     logValue(l.Fields),
+		})
+	}
+	return zs
+}
+
+// logValue returns the "event" field of a log when it is the only field,
+// otherwise all fields as space separated key=value pairs.
+func logValue(fields []*jaeger.Tag) string {
+	if len(fields) == 1 && fields[0].Key == "event" {
+		return value(fields[0])
+	}
+	pairs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		pairs = append(pairs, f.Key+"="+value(f))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+func formatID(id int64) string {
+	return strconv.FormatUint(uint64(id), 16)
+}
@@ -0,0 +1,123 @@
+package jaeger
+
+import (
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jaeger/gen-go/jaeger"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/zipkinV2"
+)
+
+func stringTag(key, v string) *jaeger.Tag {
+	return &jaeger.Tag{Key: key, VType: jaeger.TagType_STRING, VStr: &v}
+}
+
+func testBatch() *jaeger.Batch {
+	vBool := true
+	vLong := int64(500)
+	return &jaeger.Batch{
+		Process: &jaeger.Process{
+			ServiceName: "frontend",
+			Tags:        []*jaeger.Tag{stringTag("ip", "192.168.0.8")},
+		},
+		Spans: []*jaeger.Span{{
+			TraceIdLow:    0x6b221d5bc9e6496c,
+			SpanId:        0x46946e9cb5d122b6,
+			ParentSpanId:  0x6b221d5bc9e6496c,
+			OperationName: "get /api",
+			Flags:         1,
+			StartTime:     1503031538791000,
+			Duration:      10000,
+			Tags: []*jaeger.Tag{
+				stringTag("span.kind", "client"),
+				{Key: "error", VType: jaeger.TagType_BOOL, VBool: &vBool},
+				{Key: "http.status_code", VType: jaeger.TagType_LONG, VLong: &vLong},
+			},
+			Logs: []*jaeger.Log{{
+				Timestamp: 1503031538792000,
+				Fields:    []*jaeger.Tag{stringTag("event", "retry")},
+			}},
+		}},
+	}
+}
+
+var wantSpan = &zipkinV2.Span{
+	TraceID:  "6b221d5bc9e6496c",
+	ParentID: "6b221d5bc9e6496c",
+	ID:       "46946e9cb5d122b6",
+	Kind:     zipkinV2.KindClient,
+	SpanName: "get /api",
+	Time:     1503031538791000,
+	Dur:      10000,
+	LocalEndpoint: &zipkinV2.Endpoint{
+		ServiceName: "frontend",
+		Ipv4:        "192.168.0.8",
+	},
+	Anno: []zipkinV2.Annotation{
+		{Timestamp: 1503031538792000, Value: "retry"},
+	},
+	Tags: map[string]string{
+		"error":            "true",
+		"http.status_code": "500",
+	},
+}
+
+func TestThrift_Decode(t *testing.T) {
+	buffer := thrift.NewTMemoryBuffer()
+	p := thrift.NewTBinaryProtocolTransport(buffer)
+	require.NoError(t, testBatch().Write(p))
+	require.NoError(t, p.Flush())
+
+	spans, err := (&Thrift{}).Decode(buffer.Bytes())
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+	assert.Equal(t, wantSpan, spans[0])
+}
+
+func TestThrift_DecodeWithoutProcess(t *testing.T) {
+	buffer := thrift.NewTMemoryBuffer()
+	p := thrift.NewTBinaryProtocolTransport(buffer)
+	require.NoError(t, p.WriteStructBegin("Batch"))
+	require.NoError(t, p.WriteFieldStop())
+	require.NoError(t, p.WriteStructEnd())
+	require.NoError(t, p.Flush())
+
+	_, err := (&Thrift{}).Decode(buffer.Bytes())
+	assert.Error(t, err)
+}
+
+func TestAgent_Decode(t *testing.T) {
+	buffer := thrift.NewTMemoryBuffer()
+	p := thrift.NewTCompactProtocol(buffer)
+	require.NoError(t, p.WriteMessageBegin("emitBatch", thrift.ONEWAY, 1))
+	args := &jaeger.AgentEmitBatchArgs{Batch: testBatch()}
+	require.NoError(t, args.Write(p))
+	require.NoError(t, p.WriteMessageEnd())
+	require.NoError(t, p.Flush())
+
+	spans, err := (&Agent{}).Decode(buffer.Bytes())
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+	assert.Equal(t, wantSpan, spans[0])
+}
+
+func TestAgent_DecodeUnknownMessage(t *testing.T) {
+	buffer := thrift.NewTMemoryBuffer()
+	p := thrift.NewTCompactProtocol(buffer)
+	require.NoError(t, p.WriteMessageBegin("emitZipkinBatch", thrift.ONEWAY, 1))
+	require.NoError(t, p.Flush())
+
+	_, err := (&Agent{}).Decode(buffer.Bytes())
+	assert.Error(t, err)
+}
+
+func Test_logValue(t *testing.T) {
+	assert.Equal(t, "retry", logValue([]*jaeger.Tag{stringTag("event", "retry")}))
+	assert.Equal(t, "event=error message=timeout", logValue([]*jaeger.Tag{
+		stringTag("message", "timeout"),
+		stringTag("event", "error"),
+	}))
+}
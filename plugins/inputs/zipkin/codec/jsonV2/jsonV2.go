@@ -0,0 +1,30 @@
+package jsonV2
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/zipkinV2"
+)
+
+// JSON decodes Zipkin v2 spans from JSON bodies `POST`ed to the v2 spans
+// endpoint
+type JSON struct{}
+
+// Decode unmarshals and validates the JSON body
+func (j *JSON) Decode(octets []byte) ([]codec.Span, error) {
+	var spans []zipkinV2.Span
+	err := json.Unmarshal(octets, &spans)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]codec.Span, len(spans))
+	for i := range spans {
+		if err := spans[i].Validate(); err != nil {
+			return nil, err
+		}
+		res[i] = &spans[i]
+	}
+	return res, nil
+}
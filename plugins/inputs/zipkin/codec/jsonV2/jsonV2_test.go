@@ -0,0 +1,130 @@
+package jsonV2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/trace"
+)
+
+func TestJSON_Decode(t *testing.T) {
+	octets := []byte(`
+	[
+		{
+			"traceId": "6b221d5bc9e6496c",
+			"parentId": "6b221d5bc9e6496c",
+			"id": "c6946e9cb5d122b6",
+			"kind": "SERVER",
+			"name": "get /api",
+			"timestamp": 1503031538791000,
+			"duration": 10000,
+			"localEndpoint": {
+				"serviceName": "backend",
+				"ipv4": "192.168.0.8",
+				"port": 8010
+			},
+			"remoteEndpoint": {
+				"serviceName": "frontend",
+				"ipv4": "192.168.0.9"
+			},
+			"annotations": [
+				{"timestamp": 1503031538792000, "value": "cache miss"}
+			],
+			"tags": {
+				"http.path": "/api",
+				"error": "timeout"
+			}
+		}
+	]`)
+
+	spans, err := (&JSON{}).Decode(octets)
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+
+	tr, err := codec.NewTrace(spans)
+	require.NoError(t, err)
+
+	host := "192.168.0.8:8010"
+	want := trace.Trace{
+		{
+			ID:          "c6946e9cb5d122b6",
+			TraceID:     "6b221d5bc9e6496c",
+			Name:        "get /api",
+			ParentID:    "6b221d5bc9e6496c",
+			Timestamp:   time.Unix(0, 1503031538791000*int64(time.Microsecond)).UTC(),
+			Duration:    10 * time.Millisecond,
+			ServiceName: "backend",
+			Annotations: []trace.Annotation{
+				{
+					Timestamp:   time.Unix(0, 1503031538791000*int64(time.Microsecond)).UTC(),
+					Value:       "sr",
+					Host:        host,
+					ServiceName: "backend",
+				},
+				{
+					Timestamp:   time.Unix(0, 1503031538801000*int64(time.Microsecond)).UTC(),
+					Value:       "ss",
+					Host:        host,
+					ServiceName: "backend",
+				},
+				{
+					Timestamp:   time.Unix(0, 1503031538792000*int64(time.Microsecond)).UTC(),
+					Value:       "cache miss",
+					Host:        host,
+					ServiceName: "backend",
+				},
+			},
+			BinaryAnnotations: []trace.BinaryAnnotation{
+				{
+					Key:         "ca",
+					Value:       "true",
+					Host:        host,
+					ServiceName: "backend",
+				},
+				{
+					Key:         "error",
+					Value:       "timeout",
+					Host:        host,
+					ServiceName: "backend",
+				},
+				{
+					Key:         "http.path",
+					Value:       "/api",
+					Host:        host,
+					ServiceName: "backend",
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, tr)
+}
+
+func TestJSON_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		octets []byte
+	}{
+		{
+			name:   "bad json is error",
+			octets: []byte(`[{`),
+		},
+		{
+			name:   "missing span id is error",
+			octets: []byte(`[{"traceId": "6b221d5bc9e6496c", "name": "get"}]`),
+		},
+		{
+			name:   "bad trace id is error",
+			octets: []byte(`[{"traceId": "zzz", "id": "6b221d5bc9e6496c"}]`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&JSON{}).Decode(tt.octets)
+			assert.Error(t, err)
+		})
+	}
+}
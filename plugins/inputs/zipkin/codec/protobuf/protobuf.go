@@ -0,0 +1,84 @@
+package protobuf
+
+import (
+	"encoding/hex"
+	"net"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/protobuf/zipkin_proto3"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/zipkinV2"
+)
+
+// Protobuf decodes Zipkin v2 spans from the proto3 ListOfSpans message of
+// zipkin.proto.
+type Protobuf struct{}
+
+// Decode unmarshals and validates the protobuf body
+func (p *Protobuf) Decode(octets []byte) ([]codec.Span, error) {
+	var list zipkin_proto3.ListOfSpans
+	if err := proto.Unmarshal(octets, &list); err != nil {
+		return nil, err
+	}
+
+	res := make([]codec.Span, len(list.Spans))
+	for i, s := range list.Spans {
+		span := convertSpan(s)
+		if err := span.Validate(); err != nil {
+			return nil, err
+		}
+		res[i] = span
+	}
+	return res, nil
+}
+
+var kinds = map[zipkin_proto3.Span_Kind]string{
+	zipkin_proto3.Span_CLIENT:   zipkinV2.KindClient,
+	zipkin_proto3.Span_SERVER:   zipkinV2.KindServer,
+	zipkin_proto3.Span_PRODUCER: zipkinV2.KindProducer,
+	zipkin_proto3.Span_CONSUMER: zipkinV2.KindConsumer,
+}
+
+// convertSpan converts a protobuf span to the Zipkin v2 model, where the IDs
+// are hex encoded.
+func convertSpan(s *zipkin_proto3.Span) *zipkinV2.Span {
+	span := &zipkinV2.Span{
+		TraceID:        hex.EncodeToString(s.TraceId),
+		ParentID:       hex.EncodeToString(s.ParentId),
+		ID:             hex.EncodeToString(s.Id),
+		Kind:           kinds[s.Kind],
+		SpanName:       s.Name,
+		Time:           int64(s.Timestamp),
+		Dur:            int64(s.Duration),
+		LocalEndpoint:  convertEndpoint(s.LocalEndpoint),
+		RemoteEndpoint: convertEndpoint(s.RemoteEndpoint),
+	}
+	for _, a := range s.Annotations {
+		span.Anno = append(span.Anno, zipkinV2.Annotation{
+			Timestamp: int64(a.Timestamp),
+			Value:     a.Value,
+		})
+	}
+	if len(s.Tags) > 0 {
+		span.Tags = s.Tags
+	}
+	return span
+}
+
+func convertEndpoint(e *zipkin_proto3.Endpoint) *zipkinV2.Endpoint {
+	if e == nil {
+		return nil
+	}
+	endpoint := &zipkinV2.Endpoint{
+		ServiceName: e.ServiceName,
+		Port:        int(e.Port),
+	}
+	if len(e.Ipv4) > 0 {
+		endpoint.Ipv4 = net.IP(e.Ipv4).String()
+	}
+	if len(e.Ipv6) > 0 {
+		endpoint.Ipv6 = net.IP(e.Ipv6).String()
+	}
+	return endpoint
+}
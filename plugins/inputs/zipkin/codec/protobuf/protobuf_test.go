@@ -0,0 +1,103 @@
+package protobuf
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/protobuf/zipkin_proto3"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/zipkinV2"
+)
+
+func TestProtobuf_Decode(t *testing.T) {
+	span := &zipkin_proto3.Span{
+		TraceId:   []byte{0x6b, 0x22, 0x1d, 0x5b, 0xc9, 0xe6, 0x49, 0x6c},
+		Id:        []byte{0xc6, 0x94, 0x6e, 0x9c, 0xb5, 0xd1, 0x22, 0xb6},
+		Kind:      zipkin_proto3.Span_CLIENT,
+		Name:      "get /api",
+		Timestamp: 1503031538791000,
+		Duration:  10000,
+		LocalEndpoint: &zipkin_proto3.Endpoint{
+			ServiceName: "frontend",
+			Ipv4:        []byte{192, 168, 0, 8},
+			Port:        8010,
+		},
+		RemoteEndpoint: &zipkin_proto3.Endpoint{
+			ServiceName: "backend",
+			Ipv6:        []byte{0xfe, 0x80, 15: 1},
+		},
+		Annotations: []*zipkin_proto3.Annotation{
+			{Timestamp: 1503031538792000, Value: "retry"},
+		},
+		Tags:  map[string]string{"http.path": "/api"},
+		Debug: true, // not decoded
+	}
+	octets, err := proto.Marshal(&zipkin_proto3.ListOfSpans{
+		Spans: []*zipkin_proto3.Span{span, span},
+	})
+	require.NoError(t, err)
+
+	spans, err := (&Protobuf{}).Decode(octets)
+	require.NoError(t, err)
+	require.Len(t, spans, 2)
+
+	want := &zipkinV2.Span{
+		TraceID:  "6b221d5bc9e6496c",
+		ID:       "c6946e9cb5d122b6",
+		Kind:     zipkinV2.KindClient,
+		SpanName: "get /api",
+		Time:     1503031538791000,
+		Dur:      10000,
+		LocalEndpoint: &zipkinV2.Endpoint{
+			ServiceName: "frontend",
+			Ipv4:        "192.168.0.8",
+			Port:        8010,
+		},
+		RemoteEndpoint: &zipkinV2.Endpoint{
+			ServiceName: "backend",
+			Ipv6:        "fe80::1",
+		},
+		Anno: []zipkinV2.Annotation{
+			{Timestamp: 1503031538792000, Value: "retry"},
+		},
+		Tags: map[string]string{"http.path": "/api"},
+	}
+	assert.Equal(t, want, spans[0])
+	assert.Equal(t, want, spans[1])
+}
+
+func TestProtobuf_DecodeErrors(t *testing.T) {
+	full, err := proto.Marshal(&zipkin_proto3.ListOfSpans{
+		Spans: []*zipkin_proto3.Span{{
+			TraceId: []byte{0x6b, 0x22, 0x1d, 0x5b, 0xc9, 0xe6, 0x49, 0x6c},
+			Name:    "get /api",
+		}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		octets []byte
+	}{
+		{
+			name:   "truncated message is error",
+			octets: full[:len(full)-2],
+		},
+		{
+			name:   "missing span id is error",
+			octets: full,
+		},
+		{
+			name:   "unknown wire type is error",
+			octets: []byte{1<<3 | 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&Protobuf{}).Decode(tt.octets)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package zipkinV2
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jsonV1"
+	"github.com/openzipkin/zipkin-go-opentracing/_thrift/gen-go/zipkincore"
+)
+
+// Span kinds, the kind of a span replaces the core annotations of Zipkin v1.
+const (
+	KindClient   = "CLIENT"
+	KindServer   = "SERVER"
+	KindProducer = "PRODUCER"
+	KindConsumer = "CONSUMER"
+)
+
+// Core annotations of messaging spans, missing from zipkincore.
+const (
+	messageSend = "ms"
+	messageRecv = "mr"
+)
+
+// Span is a Zipkin v2 span. It is the model the Zipkin v2 JSON and protobuf
+// codecs and the Jaeger codec decode into, and is converted to the v1 model
+// the same way Zipkin does: the kind becomes core annotations, and the tags
+// binary annotations.
+type Span struct {
+	TraceID        string            `json:"traceId"`
+	ParentID       string            `json:"parentId,omitempty"`
+	ID             string            `json:"id"`
+	Kind           string            `json:"kind,omitempty"`
+	SpanName       string            `json:"name"`
+	Time           int64             `json:"timestamp,omitempty"`
+	Dur            int64             `json:"duration,omitempty"`
+	LocalEndpoint  *Endpoint         `json:"localEndpoint,omitempty"`
+	RemoteEndpoint *Endpoint         `json:"remoteEndpoint,omitempty"`
+	Anno           []Annotation      `json:"annotations,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+var _ codec.Span = &Span{}
+
+// Validate checks the IDs of the span.
+func (s *Span) Validate() error {
+	if _, err := s.Trace(); err != nil {
+		return err
+	}
+	if _, err := s.SpanID(); err != nil {
+		return err
+	}
+	_, err := s.Parent()
+	return err
+}
+
+func (s *Span) Trace() (string, error) {
+	if s.TraceID == "" {
+		return "", fmt.Errorf("Trace ID cannot be null")
+	}
+	return jsonV1.TraceIDFromString(s.TraceID)
+}
+
+func (s *Span) SpanID() (string, error) {
+	if s.ID == "" {
+		return "", fmt.Errorf("Span ID cannot be null")
+	}
+	return jsonV1.IDFromString(s.ID)
+}
+
+func (s *Span) Parent() (string, error) {
+	if s.ParentID == "" {
+		return "", nil
+	}
+	return jsonV1.IDFromString(s.ParentID)
+}
+
+func (s *Span) Name() string {
+	return s.SpanName
+}
+
+// local returns the local endpoint, as a nil interface when there is none.
+func (s *Span) local() codec.Endpoint {
+	if s.LocalEndpoint == nil {
+		return nil
+	}
+	return s.LocalEndpoint
+}
+
+func (s *Span) remote() codec.Endpoint {
+	if s.RemoteEndpoint == nil {
+		return nil
+	}
+	return s.RemoteEndpoint
+}
+
+func (s *Span) Annotations() []codec.Annotation {
+	var res []codec.Annotation
+	core := func(value string, ts int64) {
+		res = append(res, &annotation{time: ts, value: value, host: s.local()})
+	}
+	switch s.Kind {
+	case KindClient:
+		core(zipkincore.CLIENT_SEND, s.Time)
+		if s.Dur != 0 {
+			core(zipkincore.CLIENT_RECV, s.Time+s.Dur)
+		}
+	case KindServer:
+		core(zipkincore.SERVER_RECV, s.Time)
+		if s.Dur != 0 {
+			core(zipkincore.SERVER_SEND, s.Time+s.Dur)
+		}
+	case KindProducer:
+		core(messageSend, s.Time)
+	case KindConsumer:
+		core(messageRecv, s.Time)
+	}
+
+	for _, a := range s.Anno {
+		res = append(res, &annotation{
+			time:  a.Timestamp,
+			value: a.Value,
+			host:  s.local(),
+		})
+	}
+	return res
+}
+
+func (s *Span) BinaryAnnotations() ([]codec.BinaryAnnotation, error) {
+	var res []codec.BinaryAnnotation
+	switch s.Kind {
+	case "":
+		// spans without a kind are local spans, their component is named
+		// after the service as v1 instrumentations do
+		if s.LocalEndpoint != nil {
+			res = append(res, &binaryAnnotation{
+				key:   zipkincore.LOCAL_COMPONENT,
+				value: s.LocalEndpoint.ServiceName,
+				host:  s.local(),
+			})
+		}
+	case KindClient:
+		if s.RemoteEndpoint != nil {
+			res = append(res, &binaryAnnotation{
+				key:   zipkincore.SERVER_ADDR,
+				value: "true",
+				host:  s.remote(),
+			})
+		}
+	case KindServer:
+		if s.RemoteEndpoint != nil {
+			res = append(res, &binaryAnnotation{
+				key:   zipkincore.CLIENT_ADDR,
+				value: "true",
+				host:  s.remote(),
+			})
+		}
+	}
+
+	keys := make([]string, 0, len(s.Tags))
+	for k := range s.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		res = append(res, &binaryAnnotation{
+			key:   k,
+			value: s.Tags[k],
+			host:  s.local(),
+		})
+	}
+	return res, nil
+}
+
+func (s *Span) Timestamp() time.Time {
+	if s.Time == 0 {
+		return time.Time{}
+	}
+	return codec.MicroToTime(s.Time)
+}
+
+func (s *Span) Duration() time.Duration {
+	return time.Duration(s.Dur) * time.Microsecond
+}
+
+// Annotation is an event of a span.
+type Annotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+type annotation struct {
+	time  int64
+	value string
+	host  codec.Endpoint
+}
+
+func (a *annotation) Timestamp() time.Time {
+	if a.time == 0 {
+		return time.Time{}
+	}
+	return codec.MicroToTime(a.time)
+}
+
+func (a *annotation) Value() string {
+	return a.value
+}
+
+func (a *annotation) Host() codec.Endpoint {
+	return a.host
+}
+
+type binaryAnnotation struct {
+	key   string
+	value string
+	host  codec.Endpoint
+}
+
+func (b *binaryAnnotation) Key() string {
+	return b.key
+}
+
+func (b *binaryAnnotation) Value() string {
+	return b.value
+}
+
+func (b *binaryAnnotation) Host() codec.Endpoint {
+	return b.host
+}
+
+// Endpoint is the network context of a span.
+type Endpoint struct {
+	ServiceName string `json:"serviceName"`
+	Ipv4        string `json:"ipv4,omitempty"`
+	Ipv6        string `json:"ipv6,omitempty"`
+	Port        int    `json:"port,omitempty"`
+}
+
+var _ codec.Endpoint = &Endpoint{}
+
+func (e *Endpoint) Host() string {
+	ip := e.Ipv4
+	if ip == "" {
+		ip = e.Ipv6
+	}
+	if e.Port != 0 {
+		return ip + ":" + strconv.Itoa(e.Port)
+	}
+	return ip
+}
+
+func (e *Endpoint) Name() string {
+	return e.ServiceName
+}
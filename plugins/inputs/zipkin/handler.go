@@ -10,7 +10,10 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jaeger"
 	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jsonV1"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jsonV2"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/protobuf"
 	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/thrift"
 )
 
@@ -18,13 +21,34 @@ import (
 // span data and sends it to the recorder
 type SpanHandler struct {
 	Path     string
+	decoder  func(r *http.Request) (codec.Decoder, error)
 	recorder Recorder
 }
 
-// NewSpanHandler returns a new server instance given path to handle
+// NewSpanHandler returns a new server instance given path to handle, which
+// accepts Zipkin v1 spans
 func NewSpanHandler(path string) *SpanHandler {
 	return &SpanHandler{
-		Path: path,
+		Path:    path,
+		decoder: ContentDecoder,
+	}
+}
+
+// NewV2SpanHandler returns a new server instance given path to handle, which
+// accepts Zipkin v2 spans
+func NewV2SpanHandler(path string) *SpanHandler {
+	return &SpanHandler{
+		Path:    path,
+		decoder: V2ContentDecoder,
+	}
+}
+
+// NewJaegerHandler returns a new server instance given path to handle, which
+// accepts the Jaeger thrift batches sent to the Jaeger collector
+func NewJaegerHandler(path string) *SpanHandler {
+	return &SpanHandler{
+		Path:    path,
+		decoder: JaegerContentDecoder,
 	}
 }
 
@@ -82,10 +106,11 @@ func (s *SpanHandler) Spans(w http.ResponseWriter, r *http.Request) {
 		defer body.Close()
 	}
 
-	decoder, err := ContentDecoder(r)
+	decoder, err := s.decoder(r)
 	if err != nil {
 		s.recorder.Error(err)
 		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
 	}
 
 	octets, err := ioutil.ReadAll(body)
@@ -140,3 +165,42 @@ func ContentDecoder(r *http.Request) (codec.Decoder, error) {
 	}
 	return nil, fmt.Errorf("Unknown Content-Type: %s", contentType)
 }
+
+// V2ContentDecoder returns a Decoder of Zipkin v2 spans, which are JSON
+// unless the Content-Type is application/x-protobuf.
+func V2ContentDecoder(r *http.Request) (codec.Decoder, error) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return &jsonV2.JSON{}, nil
+	}
+
+	for _, v := range strings.Split(contentType, ",") {
+		t, _, err := mime.ParseMediaType(v)
+		if err != nil {
+			break
+		}
+		if t == "application/json" {
+			return &jsonV2.JSON{}, nil
+		} else if t == "application/x-protobuf" {
+			return &protobuf.Protobuf{}, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown Content-Type: %s", contentType)
+}
+
+// JaegerContentDecoder returns a Decoder of the Jaeger thrift batches, which
+// Jaeger clients send as application/x-thrift or
+// application/vnd.apache.thrift.binary.
+func JaegerContentDecoder(r *http.Request) (codec.Decoder, error) {
+	contentType := r.Header.Get("Content-Type")
+	for _, v := range strings.Split(contentType, ",") {
+		t, _, err := mime.ParseMediaType(v)
+		if err != nil {
+			break
+		}
+		if t == "application/x-thrift" || t == "application/vnd.apache.thrift.binary" {
+			return &jaeger.Thrift{}, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown Content-Type: %s", contentType)
+}
@@ -10,6 +10,10 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jaeger"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jsonV2"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/protobuf"
 	"github.com/influxdata/telegraf/plugins/inputs/zipkin/trace"
 )
 
@@ -133,3 +137,98 @@ func TestSpanHandler(t *testing.T) {
 		t.Fatalf("Got != Want\n %s", cmp.Diff(got, want))
 	}
 }
+
+func TestContentDecoders(t *testing.T) {
+	tests := []struct {
+		name        string
+		decoder     func(r *http.Request) (codec.Decoder, error)
+		contentType string
+		want        codec.Decoder
+		wantErr     bool
+	}{
+		{
+			name:    "v2 defaults to JSON",
+			decoder: V2ContentDecoder,
+			want:    &jsonV2.JSON{},
+		},
+		{
+			name:        "v2 protobuf",
+			decoder:     V2ContentDecoder,
+			contentType: "application/x-protobuf",
+			want:        &protobuf.Protobuf{},
+		},
+		{
+			name:        "v2 thrift is unsupported",
+			decoder:     V2ContentDecoder,
+			contentType: "application/x-thrift",
+			wantErr:     true,
+		},
+		{
+			name:        "jaeger thrift",
+			decoder:     JaegerContentDecoder,
+			contentType: "application/vnd.apache.thrift.binary",
+			want:        &jaeger.Thrift{},
+		},
+		{
+			name:    "jaeger requires a content type",
+			decoder: JaegerContentDecoder,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "http://server.local/", nil)
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			got, err := tt.decoder(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decoder error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Fatalf("Got != Want\n %s", cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestV2SpanHandler(t *testing.T) {
+	dat := []byte(`[{
+		"traceId": "6b221d5bc9e6496c",
+		"id": "6b221d5bc9e6496c",
+		"kind": "CLIENT",
+		"name": "get",
+		"timestamp": 1503031538791000,
+		"duration": 10000,
+		"localEndpoint": {"serviceName": "frontend"}
+	}]`)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(
+		"POST",
+		"http://server.local/api/v2/spans",
+		ioutil.NopCloser(
+			bytes.NewReader(dat)))
+
+	handler := NewV2SpanHandler("/api/v2/spans")
+	mockRecorder := &MockRecorder{}
+	handler.recorder = mockRecorder
+
+	handler.Spans(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("MainHandler did not return StatusNoContent %d", w.Code)
+	}
+	if len(mockRecorder.Data) != 1 || mockRecorder.Data[0].ServiceName != "frontend" {
+		t.Fatalf("unexpected trace %v", mockRecorder.Data)
+	}
+
+	// unsupported content types are rejected
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("POST", "http://server.local/api/v2/spans",
+		bytes.NewReader(dat))
+	r.Header.Set("Content-Type", "text/plain")
+	handler.Spans(w, r)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("MainHandler did not return StatusUnsupportedMediaType %d", w.Code)
+	}
+}
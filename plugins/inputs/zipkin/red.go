@@ -0,0 +1,229 @@
+package zipkin
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/trace"
+)
+
+// Defaults of the RED mode.
+var (
+	// DefaultPercentiles are the percentiles of the span durations.
+	DefaultPercentiles = []float64{50, 90, 99}
+
+	// DefaultBucketsMs are the upper bounds of the duration histogram buckets,
+	// in milliseconds.
+	DefaultBucketsMs = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500,
+		5000, 10000}
+)
+
+// DefaultPercentileLimit is the number of durations kept per series to
+// compute the percentiles, durations are sampled past it.
+const DefaultPercentileLimit = 1000
+
+// redKey identifies a series of the RED metrics.
+type redKey struct {
+	serviceName string
+	name        string
+	err         bool
+}
+
+// redStats are the stats of the spans of a series within an interval.
+type redStats struct {
+	count     int64
+	sum       time.Duration
+	min       time.Duration
+	max       time.Duration
+	buckets   []int64
+	durations []time.Duration
+}
+
+// REDConverter implements the Recorder interface; instead of storing every
+// span it derives request rate, error and duration (RED) metrics per service,
+// span name and error status, which are added to the accumulator by Flush.
+type REDConverter struct {
+	acc telegraf.Accumulator
+
+	// Percentiles of the durations to compute, ie, 99.9.
+	Percentiles []float64
+	// BucketsMs are the upper bounds of the duration histogram buckets, in
+	// milliseconds.
+	BucketsMs []float64
+	// PercentileLimit is the number of durations kept per series.
+	PercentileLimit int
+	// SpanSamples is the number of raw spans recorded each interval, as
+	// LineProtocolConverter does.
+	SpanSamples int
+
+	mu      sync.Mutex
+	stats   map[redKey]*redStats
+	sampled int
+	since   time.Time
+	spans   *LineProtocolConverter
+}
+
+// NewREDConverter returns a REDConverter adding to the given
+// telegraf.Accumulator, with the default percentiles and buckets.
+func NewREDConverter(acc telegraf.Accumulator) *REDConverter {
+	return &REDConverter{
+		acc:             acc,
+		Percentiles:     DefaultPercentiles,
+		BucketsMs:       DefaultBucketsMs,
+		PercentileLimit: DefaultPercentileLimit,
+		stats:           make(map[redKey]*redStats),
+		since:           time.Now(),
+		spans:           NewLineProtocolConverter(acc),
+	}
+}
+
+// Record adds the spans of the trace to the stats of the current interval.
+func (r *REDConverter) Record(t trace.Trace) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range t {
+		key := redKey{
+			serviceName: formatName(s.ServiceName),
+			name:        formatName(s.Name),
+			err:         isError(s),
+		}
+		stats, ok := r.stats[key]
+		if !ok {
+			stats = &redStats{
+				min:     s.Duration,
+				max:     s.Duration,
+				buckets: make([]int64, len(r.BucketsMs)),
+			}
+			r.stats[key] = stats
+		}
+		r.add(stats, s.Duration)
+	}
+
+	if r.sampled < r.SpanSamples {
+		n := r.SpanSamples - r.sampled
+		if n > len(t) {
+			n = len(t)
+		}
+		r.sampled += n
+		return r.spans.Record(t[:n])
+	}
+	return nil
+}
+
+func (r *REDConverter) add(stats *redStats, d time.Duration) {
+	stats.count++
+	stats.sum += d
+	if d < stats.min {
+		stats.min = d
+	}
+	if d > stats.max {
+		stats.max = d
+	}
+
+	ms := float64(d) / float64(time.Millisecond)
+	for i, bound := range r.BucketsMs {
+		if ms <= bound {
+			stats.buckets[i]++
+		}
+	}
+
+	// reservoir sampling keeps an even sample of the durations once there
+	// are more than the limit
+	if len(stats.durations) < r.PercentileLimit {
+		stats.durations = append(stats.durations, d)
+	} else if i := rand.Int63n(stats.count); i < int64(r.PercentileLimit) {
+		stats.durations[i] = d
+	}
+}
+
+// isError returns true if the span is tagged as failed, with an "error"
+// binary annotation or annotation.
+func isError(s trace.Span) bool {
+	for _, b := range s.BinaryAnnotations {
+		if b.Key == "error" {
+			return true
+		}
+	}
+	for _, a := range s.Annotations {
+		if a.Value == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// Flush adds the RED metrics of the interval to the accumulator, and starts a
+// new interval.
+func (r *REDConverter) Flush() {
+	r.mu.Lock()
+	stats := r.stats
+	since := r.since
+	r.stats = make(map[redKey]*redStats)
+	r.sampled = 0
+	r.since = time.Now()
+	r.mu.Unlock()
+
+	now := time.Now()
+	interval := now.Sub(since).Seconds()
+	for key, s := range stats {
+		fields := map[string]interface{}{
+			"count":            s.count,
+			"duration_mean_ns": int64(s.sum) / s.count,
+			"duration_min_ns":  s.min.Nanoseconds(),
+			"duration_max_ns":  s.max.Nanoseconds(),
+		}
+		if interval > 0 {
+			fields["rate"] = float64(s.count) / interval
+		}
+
+		sort.Slice(s.durations, func(i, j int) bool {
+			return s.durations[i] < s.durations[j]
+		})
+		for _, p := range r.Percentiles {
+			fields["duration_p"+formatFloat(p)+"_ns"] =
+				percentile(s.durations, p).Nanoseconds()
+		}
+
+		for i, bound := range r.BucketsMs {
+			fields["duration_le_"+formatFloat(bound)+"ms"] = s.buckets[i]
+		}
+		fields["duration_le_inf"] = s.count
+
+		tags := map[string]string{
+			"service_name": key.serviceName,
+			"name":         key.name,
+			"error":        strconv.FormatBool(key.err),
+		}
+		r.acc.AddFields("zipkin_red", fields, tags, now)
+	}
+}
+
+// percentile returns the p-th percentile of the sorted durations, using the
+// nearest rank.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func (r *REDConverter) Error(err error) {
+	r.acc.AddError(err)
+}
@@ -0,0 +1,120 @@
+package zipkin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/trace"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func redSpan(name string, d time.Duration, err bool) trace.Span {
+	s := trace.Span{
+		ID:          "1",
+		TraceID:     "1",
+		Name:        name,
+		ServiceName: "Frontend",
+		Timestamp:   time.Unix(0, 0),
+		Duration:    d,
+	}
+	if err {
+		s.BinaryAnnotations = []trace.BinaryAnnotation{{
+			Key:         "error",
+			Value:       "timeout",
+			ServiceName: "frontend",
+		}}
+	}
+	return s
+}
+
+func TestREDConverter(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	red := NewREDConverter(acc)
+	red.Percentiles = []float64{50, 100}
+	red.BucketsMs = []float64{10, 100}
+
+	err := red.Record(trace.Trace{
+		redSpan("get", 5*time.Millisecond, false),
+		redSpan("get", 20*time.Millisecond, false),
+		redSpan("get", 200*time.Millisecond, false),
+		redSpan("get", time.Second, true),
+	})
+	require.NoError(t, err)
+	red.Flush()
+
+	require.Len(t, acc.Metrics, 2)
+	ok := map[string]string{
+		"service_name": "frontend",
+		"name":         "get",
+		"error":        "false",
+	}
+	var rate interface{}
+	for _, m := range acc.Metrics {
+		if m.Tags["error"] == "false" {
+			rate = m.Fields["rate"]
+		}
+	}
+	assert.True(t, rate.(float64) > 0)
+	acc.AssertContainsTaggedFields(t, "zipkin_red", map[string]interface{}{
+		"count":             int64(3),
+		"rate":              rate,
+		"duration_mean_ns":  int64(75 * time.Millisecond),
+		"duration_min_ns":   int64(5 * time.Millisecond),
+		"duration_max_ns":   int64(200 * time.Millisecond),
+		"duration_p50_ns":   int64(20 * time.Millisecond),
+		"duration_p100_ns":  int64(200 * time.Millisecond),
+		"duration_le_10ms":  int64(1),
+		"duration_le_100ms": int64(2),
+		"duration_le_inf":   int64(3),
+	}, ok)
+
+	failed := map[string]string{
+		"service_name": "frontend",
+		"name":         "get",
+		"error":        "true",
+	}
+	assert.True(t, acc.HasPoint("zipkin_red", failed, "count", int64(1)))
+
+	// the stats are reset every interval
+	acc.ClearMetrics()
+	red.Flush()
+	assert.Len(t, acc.Metrics, 0)
+}
+
+func TestREDConverterSpanSamples(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	red := NewREDConverter(acc)
+	red.SpanSamples = 1
+
+	tr := trace.Trace{
+		redSpan("get", time.Millisecond, false),
+		redSpan("put", time.Millisecond, false),
+	}
+	require.NoError(t, red.Record(tr))
+	require.NoError(t, red.Record(tr))
+	assert.Len(t, acc.Metrics, 1)
+	assert.Equal(t, "zipkin", acc.Metrics[0].Measurement)
+
+	red.Flush()
+	acc.ClearMetrics()
+	require.NoError(t, red.Record(tr))
+	assert.Len(t, acc.Metrics, 1)
+}
+
+func TestREDConverterPercentileLimit(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	red := NewREDConverter(acc)
+	red.PercentileLimit = 10
+
+	for i := 0; i < 100; i++ {
+		red.Record(trace.Trace{redSpan("get", time.Millisecond, false)})
+	}
+	red.mu.Lock()
+	for _, s := range red.stats {
+		assert.Len(t, s.durations, 10)
+		assert.Equal(t, int64(100), s.count)
+	}
+	red.mu.Unlock()
+}
@@ -3,15 +3,17 @@ package zipkin
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/mux"
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec"
+	"github.com/influxdata/telegraf/plugins/inputs/zipkin/codec/jaeger"
 	"github.com/influxdata/telegraf/plugins/inputs/zipkin/trace"
 )
 
@@ -24,11 +26,31 @@ const (
 	// expect.
 	DefaultRoute = "/api/v1/spans"
 
+	// DefaultV2Route is the default route of the Zipkin v2 spans.
+	DefaultV2Route = "/api/v2/spans"
+
+	// DefaultJaegerRoute is the default route of the Jaeger thrift batches,
+	// the route of the Jaeger collector.
+	DefaultJaegerRoute = "/api/traces"
+
+	// maxDatagramSize is the largest UDP packet the Jaeger agent accepts.
+	maxDatagramSize = 65000
+
 	// DefaultShutdownTimeout is the max amount of time telegraf will wait
 	// for the plugin to shutdown
 	DefaultShutdownTimeout = 5
 )
 
+// Modes of the plugin.
+const (
+	// ModeSpans writes a point for every span and annotation.
+	ModeSpans = "spans"
+
+	// ModeRED writes request rate, error and duration metrics derived from
+	// the spans every interval.
+	ModeRED = "red"
+)
+
 // Recorder represents a type which can record zipkin trace data as well as
 // any accompanying errors, and process that data.
 type Recorder interface {
@@ -45,20 +67,60 @@ type Handler interface {
 const sampleConfig = `
   # path = "/api/v1/spans" # URL path for span data
   # port = 9411            # Port on which Telegraf listens
+
+  ## URL path for Zipkin v2 span data, in JSON or protobuf; empty to disable.
+  # path_v2 = "/api/v2/spans"
+  ## URL path for the thrift batches of Jaeger clients, as sent to the Jaeger
+  ## collector; empty to disable.
+  # jaeger_path = "/api/traces"
+  ## UDP address for the compact thrift batches of Jaeger clients, as sent to
+  ## the Jaeger agent, ie, ":6831"; disabled by default.
+  # jaeger_udp_address = ""
+
+  ## "spans" writes a point for every span and annotation, "red" aggregates
+  ## the spans into request rate, error and duration metrics per service, span
+  ## name and error status, written every interval.
+  # mode = "spans"
+
+  ## RED mode only: percentiles of the span durations.
+  # percentiles = [50.0, 90.0, 99.0]
+  ## RED mode only: upper bounds of the duration histogram buckets, in
+  ## milliseconds.
+  # duration_buckets_ms = [5.0, 10.0, 25.0, 50.0, 100.0, 250.0, 500.0, 1000.0, 2500.0, 5000.0, 10000.0]
+  ## RED mode only: number of durations kept per series to compute the
+  ## percentiles.
+  # percentile_limit = 1000
+  ## RED mode only: number of raw spans written each interval, as in spans
+  ## mode.
+  # span_samples = 0
 `
 
 // Zipkin is a telegraf configuration structure for the zipkin input plugin,
 // but it also contains fields for the management of a separate, concurrent
 // zipkin http server
 type Zipkin struct {
-	ServiceAddress string
-	Port           int
-	Path           string
-
-	address   string
-	handler   Handler
-	server    *http.Server
-	waitGroup *sync.WaitGroup
+	ServiceAddress   string
+	Port             int
+	Path             string
+	PathV2           string `toml:"path_v2"`
+	JaegerPath       string `toml:"jaeger_path"`
+	JaegerUDPAddress string `toml:"jaeger_udp_address"`
+
+	Mode              string
+	Percentiles       []float64
+	DurationBucketsMs []float64 `toml:"duration_buckets_ms"`
+	PercentileLimit   int
+	SpanSamples       int
+
+	Log telegraf.Logger `toml:"-"`
+
+	address    string
+	udpAddress string
+	red        *REDConverter
+	handlers   []Handler
+	server     *http.Server
+	udpConn    net.PacketConn
+	waitGroup  *sync.WaitGroup
 }
 
 // Description is a necessary method implementation from telegraf.ServiceInput
@@ -71,22 +133,57 @@ func (z Zipkin) SampleConfig() string {
 	return sampleConfig
 }
 
-// Gather is empty for the zipkin plugin; all gathering is done through
-// the separate goroutine launched in (*Zipkin).Start()
-func (z *Zipkin) Gather(acc telegraf.Accumulator) error { return nil }
+// Gather writes the RED metrics of the interval in RED mode, otherwise it is
+// empty; all gathering is done through the separate goroutine launched in
+// (*Zipkin).Start()
+func (z *Zipkin) Gather(acc telegraf.Accumulator) error {
+	if z.red != nil {
+		z.red.Flush()
+	}
+	return nil
+}
 
 // Start launches a separate goroutine for collecting zipkin client http requests,
 // passing in a telegraf.Accumulator such that data can be collected.
 func (z *Zipkin) Start(acc telegraf.Accumulator) error {
-	z.handler = NewSpanHandler(z.Path)
+	z.handlers = []Handler{NewSpanHandler(z.Path)}
+	if z.PathV2 != "" {
+		z.handlers = append(z.handlers, NewV2SpanHandler(z.PathV2))
+	}
+	if z.JaegerPath != "" {
+		z.handlers = append(z.handlers, NewJaegerHandler(z.JaegerPath))
+	}
 
 	var wg sync.WaitGroup
 	z.waitGroup = &wg
 
+	var recorder Recorder
+	switch z.Mode {
+	case "", ModeSpans:
+		recorder = NewLineProtocolConverter(acc)
+	case ModeRED:
+		z.red = NewREDConverter(acc)
+		if z.Percentiles != nil {
+			z.red.Percentiles = z.Percentiles
+		}
+		if z.DurationBucketsMs != nil {
+			z.red.BucketsMs = z.DurationBucketsMs
+		}
+		if z.PercentileLimit > 0 {
+			z.red.PercentileLimit = z.PercentileLimit
+		}
+		z.red.SpanSamples = z.SpanSamples
+		recorder = z.red
+	default:
+		return fmt.Errorf("invalid mode %q, must be %s or %s", z.Mode,
+			ModeSpans, ModeRED)
+	}
+
 	router := mux.NewRouter()
-	converter := NewLineProtocolConverter(acc)
-	if err := z.handler.Register(router, converter); err != nil {
-		return err
+	for _, handler := range z.handlers {
+		if err := handler.Register(router, recorder); err != nil {
+			return err
+		}
 	}
 
 	z.server = &http.Server{
@@ -100,7 +197,7 @@ func (z *Zipkin) Start(acc telegraf.Accumulator) error {
 	}
 
 	z.address = ln.Addr().String()
-	log.Printf("I! Started the zipkin listener on %s", z.address)
+	z.Log.Infof("Started the zipkin listener on %s", z.address)
 
 	go func() {
 		wg.Add(1)
@@ -109,9 +206,56 @@ func (z *Zipkin) Start(acc telegraf.Accumulator) error {
 		z.Listen(ln, acc)
 	}()
 
+	if z.JaegerUDPAddress != "" {
+		conn, err := net.ListenPacket("udp", z.JaegerUDPAddress)
+		if err != nil {
+			z.server.Close()
+			return err
+		}
+		z.udpConn = conn
+		z.udpAddress = conn.LocalAddr().String()
+		z.Log.Infof("Started the Jaeger agent listener on %s", z.udpAddress)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			z.listenUDP(conn, recorder)
+		}()
+	}
+
 	return nil
 }
 
+// listenUDP records the spans of the Jaeger emitBatch messages received on
+// conn until it is closed by (*Zipkin).Stop()
+func (z *Zipkin) listenUDP(conn net.PacketConn, recorder Recorder) {
+	decoder := &jaeger.Agent{}
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if !strings.HasSuffix(err.Error(), ": use of closed network connection") {
+				recorder.Error(fmt.Errorf("error reading Jaeger UDP packet: %v", err))
+			}
+			return
+		}
+
+		spans, err := decoder.Decode(buf[:n])
+		if err != nil {
+			recorder.Error(err)
+			continue
+		}
+		trace, err := codec.NewTrace(spans)
+		if err != nil {
+			recorder.Error(err)
+			continue
+		}
+		if err := recorder.Record(trace); err != nil {
+			recorder.Error(err)
+		}
+	}
+}
+
 // Stop shuts the internal http server down with via context.Context
 func (z *Zipkin) Stop() {
 	ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
@@ -119,6 +263,9 @@ func (z *Zipkin) Stop() {
 	defer z.waitGroup.Wait()
 	defer cancel()
 
+	if z.udpConn != nil {
+		z.udpConn.Close()
+	}
 	z.server.Shutdown(ctx)
 }
 
@@ -140,8 +287,10 @@ func (z *Zipkin) Listen(ln net.Listener, acc telegraf.Accumulator) {
 func init() {
 	inputs.Add("zipkin", func() telegraf.Input {
 		return &Zipkin{
-			Path: DefaultRoute,
-			Port: DefaultPort,
+			Path:       DefaultRoute,
+			PathV2:     DefaultV2Route,
+			JaegerPath: DefaultJaegerRoute,
+			Port:       DefaultPort,
 		}
 	})
 }
@@ -558,6 +558,7 @@ func TestZipkinPlugin(t *testing.T) {
 	}
 
 	z := &Zipkin{
+		Log:  testutil.Logger{},
 		Path: "/api/v1/spans",
 		Port: 0,
 	}
@@ -617,3 +618,54 @@ func postThriftData(datafile, address, contentType string) error {
 
 	return nil
 }
+
+func TestZipkinPluginV2(t *testing.T) {
+	acc := testutil.Accumulator{}
+	z := &Zipkin{
+		Log:    testutil.Logger{},
+		Path:   DefaultRoute,
+		PathV2: DefaultV2Route,
+		Port:   0,
+	}
+	if err := z.Start(&acc); err != nil {
+		t.Fatal("Failed to start zipkin server")
+	}
+	defer z.Stop()
+
+	dat := []byte(`[{
+		"traceId": "6b221d5bc9e6496c",
+		"id": "6b221d5bc9e6496c",
+		"name": "get",
+		"timestamp": 1503031538791000,
+		"duration": 10000,
+		"localEndpoint": {"serviceName": "frontend", "ipv4": "127.0.0.1"}
+	}]`)
+	resp, err := http.Post(fmt.Sprintf("http://%s/api/v2/spans", z.address),
+		"application/json", bytes.NewReader(dat))
+	if err != nil {
+		t.Fatalf("HTTP POST request to zipkin endpoint failed %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("Got status %d", resp.StatusCode)
+	}
+
+	acc.Wait(2)
+	tags := map[string]string{
+		"id":           "6b221d5bc9e6496c",
+		"parent_id":    "6b221d5bc9e6496c",
+		"trace_id":     "6b221d5bc9e6496c",
+		"service_name": "frontend",
+		"name":         "get",
+	}
+	acc.AssertContainsTaggedFields(t, "zipkin", map[string]interface{}{
+		"duration_ns": (10 * time.Millisecond).Nanoseconds(),
+	}, tags)
+
+	tags["annotation"] = "frontend"
+	tags["annotation_key"] = "lc"
+	tags["endpoint_host"] = "127.0.0.1"
+	acc.AssertContainsTaggedFields(t, "zipkin", map[string]interface{}{
+		"duration_ns": (10 * time.Millisecond).Nanoseconds(),
+	}, tags)
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 
@@ -18,6 +17,8 @@ type Amon struct {
 	AmonInstance string
 	Timeout      internal.Duration
 
+	Log telegraf.Logger `toml:"-"`
+
 	client *http.Client
 }
 
@@ -76,7 +77,7 @@ func (a *Amon) Write(metrics []telegraf.Metric) error {
 				metricCounter++
 			}
 		} else {
-			log.Printf("I! unable to build Metric for %s, skipping\n", m.Name())
+			a.Log.Infof("unable to build Metric for %s, skipping", m.Name())
 		}
 	}
 
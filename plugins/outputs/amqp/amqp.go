@@ -2,7 +2,6 @@ package amqp
 
 import (
 	"fmt"
-	"log"
 	"net"
 	"strings"
 	"sync"
@@ -52,6 +51,8 @@ type AMQP struct {
 	// Use SSL but skip chain & host verification
 	InsecureSkipVerify bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	sync.Mutex
 	c *client
 
@@ -195,10 +196,10 @@ func (q *AMQP) Connect() error {
 
 		q.setClient(nil)
 
-		log.Printf("I! Closing: %s", err)
-		log.Printf("I! Trying to reconnect")
+		q.Log.Infof("Closing: %s", err)
+		q.Log.Infof("Trying to reconnect")
 		for err := q.Connect(); err != nil; err = q.Connect() {
-			log.Println("E! ", err.Error())
+			q.Log.Error(err.Error())
 			time.Sleep(10 * time.Second)
 		}
 	}()
@@ -213,7 +214,7 @@ func (q *AMQP) Close() error {
 
 	err := c.conn.Close()
 	if err != nil && err != amqp.ErrClosed {
-		log.Printf("E! Error closing AMQP connection: %s", err)
+		q.Log.Errorf("Error closing AMQP connection: %s", err)
 		return err
 	}
 	return nil
@@ -16,6 +16,7 @@ func TestConnectAndWrite(t *testing.T) {
 	var url = "amqp://" + testutil.GetLocalHost() + ":5672/"
 	s, _ := serializers.NewInfluxSerializer()
 	q := &AMQP{
+		Log:        testutil.Logger{},
 		URL:        url,
 		Exchange:   "telegraf_test",
 		serializer: s,
@@ -1,7 +1,6 @@
 package cloudwatch
 
 import (
-	"log"
 	"math"
 	"sort"
 	"strings"
@@ -26,7 +25,10 @@ type CloudWatch struct {
 	Token     string `toml:"token"`
 
 	Namespace string `toml:"namespace"` // CloudWatch Metrics Namespace
-	svc       *cloudwatch.CloudWatch
+
+	Log telegraf.Logger `toml:"-"`
+
+	svc *cloudwatch.CloudWatch
 }
 
 var sampleConfig = `
@@ -79,7 +81,7 @@ func (c *CloudWatch) Connect() error {
 	_, err := stsService.GetCallerIdentity(params)
 
 	if err != nil {
-		log.Printf("E! cloudwatch: Cannot use credentials to connect to AWS : %+v \n", err.Error())
+		c.Log.Errorf("Cannot use credentials to connect to AWS : %+v", err.Error())
 		return err
 	}
 
@@ -131,7 +133,7 @@ func (c *CloudWatch) WriteToCloudWatch(datums []*cloudwatch.MetricDatum) error {
 	_, err := c.svc.PutMetricData(params)
 
 	if err != nil {
-		log.Printf("E! CloudWatch: Unable to write to CloudWatch : %+v \n", err.Error())
+		c.Log.Errorf("Unable to write to CloudWatch : %+v", err.Error())
 	}
 
 	return err
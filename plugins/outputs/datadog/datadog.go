@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"sort"
@@ -19,6 +18,8 @@ type Datadog struct {
 	Apikey  string
 	Timeout internal.Duration
 
+	Log telegraf.Logger `toml:"-"`
+
 	apiUrl string
 	client *http.Client
 }
@@ -97,7 +98,7 @@ func (d *Datadog) Write(metrics []telegraf.Metric) error {
 				metricCounter++
 			}
 		} else {
-			log.Printf("I! unable to build Metric for %s, skipping\n", m.Name())
+			d.Log.Infof("unable to build Metric for %s, skipping", m.Name())
 		}
 	}
 
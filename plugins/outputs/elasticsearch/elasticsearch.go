@@ -3,7 +3,6 @@ package elasticsearch
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -33,6 +32,8 @@ type Elasticsearch struct {
 	SSLKey              string `toml:"ssl_key"`  // Path to cert key file
 	InsecureSkipVerify  bool   // Use SSL but skip chain & host verification
 	Client              *elastic.Client
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 var sampleConfig = `
@@ -126,7 +127,7 @@ func (a *Elasticsearch) Connect() error {
 		clientOptions = append(clientOptions,
 			elastic.SetHealthcheck(false),
 		)
-		log.Printf("D! Elasticsearch output: disabling health check")
+		a.Log.Debugf("Elasticsearch output: disabling health check")
 	}
 
 	client, err := elastic.NewClient(clientOptions...)
@@ -148,7 +149,7 @@ func (a *Elasticsearch) Connect() error {
 		return fmt.Errorf("Elasticsearch version not supported: %s", esVersion)
 	}
 
-	log.Println("I! Elasticsearch version: " + esVersion)
+	a.Log.Info("Elasticsearch version: " + esVersion)
 
 	a.Client = client
 
@@ -203,7 +204,7 @@ func (a *Elasticsearch) Write(metrics []telegraf.Metric) error {
 
 	if res.Errors {
 		for id, err := range res.Failed() {
-			log.Printf("E! Elasticsearch indexing failure, id: %d, error: %s, caused by: %s, %s", id, err.Error.Reason, err.Error.CausedBy["reason"], err.Error.CausedBy["type"])
+			a.Log.Errorf("Elasticsearch indexing failure, id: %d, error: %s, caused by: %s, %s", id, err.Error.Reason, err.Error.CausedBy["reason"], err.Error.CausedBy["type"])
 		}
 		return fmt.Errorf("W! Elasticsearch failed to index %d metrics", len(res.Failed()))
 	}
@@ -302,11 +303,11 @@ func (a *Elasticsearch) manageTemplate(ctx context.Context) error {
 			return fmt.Errorf("Elasticsearch failed to create index template %s : %s", a.TemplateName, errCreateTemplate)
 		}
 
-		log.Printf("D! Elasticsearch template %s created or updated\n", a.TemplateName)
+		a.Log.Debugf("Elasticsearch template %s created or updated", a.TemplateName)
 
 	} else {
 
-		log.Println("D! Found existing Elasticsearch template. Skipping template management")
+		a.Log.Debug("Found existing Elasticsearch template. Skipping template management")
 
 	}
 	return nil
@@ -360,7 +361,7 @@ func (a *Elasticsearch) GetIndexName(indexName string, eventTime time.Time, tagK
 		if value, ok := metricTags[key]; ok {
 			tagValues = append(tagValues, value)
 		} else {
-			log.Printf("D! Tag '%s' not found, using '%s' on index name instead\n", key, a.DefaultTagValue)
+			a.Log.Debugf("Tag '%s' not found, using '%s' on index name instead", key, a.DefaultTagValue)
 			tagValues = append(tagValues, a.DefaultTagValue)
 		}
 	}
@@ -19,6 +19,7 @@ func TestConnectAndWrite(t *testing.T) {
 	urls := []string{"http://" + testutil.GetLocalHost() + ":9200"}
 
 	e := &Elasticsearch{
+		Log:                 testutil.Logger{},
 		URLs:                urls,
 		IndexName:           "test-%Y.%m.%d",
 		Timeout:             internal.Duration{Duration: time.Second * 5},
@@ -48,6 +49,7 @@ func TestTemplateManagementEmptyTemplate(t *testing.T) {
 	ctx := context.Background()
 
 	e := &Elasticsearch{
+		Log:               testutil.Logger{},
 		URLs:              urls,
 		IndexName:         "test-%Y.%m.%d",
 		Timeout:           internal.Duration{Duration: time.Second * 5},
@@ -69,6 +71,7 @@ func TestTemplateManagement(t *testing.T) {
 	urls := []string{"http://" + testutil.GetLocalHost() + ":9200"}
 
 	e := &Elasticsearch{
+		Log:               testutil.Logger{},
 		URLs:              urls,
 		IndexName:         "test-%Y.%m.%d",
 		Timeout:           internal.Duration{Duration: time.Second * 5},
@@ -95,6 +98,7 @@ func TestTemplateInvalidIndexPattern(t *testing.T) {
 	urls := []string{"http://" + testutil.GetLocalHost() + ":9200"}
 
 	e := &Elasticsearch{
+		Log:               testutil.Logger{},
 		URLs:              urls,
 		IndexName:         "{{host}}-%Y.%m.%d",
 		Timeout:           internal.Duration{Duration: time.Second * 5},
@@ -109,6 +113,7 @@ func TestTemplateInvalidIndexPattern(t *testing.T) {
 
 func TestGetTagKeys(t *testing.T) {
 	e := &Elasticsearch{
+		Log:             testutil.Logger{},
 		DefaultTagValue: "none",
 	}
 
@@ -169,6 +174,7 @@ func TestGetTagKeys(t *testing.T) {
 
 func TestGetIndexName(t *testing.T) {
 	e := &Elasticsearch{
+		Log:             testutil.Logger{},
 		DefaultTagValue: "none",
 	}
 
@@ -0,0 +1,106 @@
+package execd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/internal/process"
+	"github.com/influxdata/telegraf/plugins/outputs"
+	"github.com/influxdata/telegraf/plugins/serializers"
+)
+
+const sampleConfig = `
+  ## Program to run as daemon, followed by its arguments.
+  command = ["my-telegraf-output", "--some-flag", "value"]
+
+  ## Delay before the process is restarted after an unexpected termination.
+  ## The delay doubles on each consecutive failure, up to 5 minutes.
+  restart_delay = "10s"
+
+  ## Data format to export.
+  ## Each data format has its own unique set of configuration options, read
+  ## more about them here:
+  ## https://github.com/influxdata/telegraf/blob/master/docs/DATA_FORMATS_OUTPUT.md
+  data_format = "influx"
+`
+
+type Execd struct {
+	Command      []string
+	RestartDelay internal.Duration
+
+	Log telegraf.Logger `toml:"-"`
+
+	process    *process.Process
+	serializer serializers.Serializer
+}
+
+func (e *Execd) SampleConfig() string {
+	return sampleConfig
+}
+
+func (e *Execd) Description() string {
+	return "Run executable as long-running output plugin"
+}
+
+func (e *Execd) SetSerializer(serializer serializers.Serializer) {
+	e.serializer = serializer
+}
+
+func (e *Execd) Connect() error {
+	var err error
+	e.process, err = process.New(e.Command, e.Log)
+	if err != nil {
+		return fmt.Errorf("error creating process: %s", err)
+	}
+	e.process.RestartDelay = e.RestartDelay.Duration
+	e.process.ReadStdout = e.cmdReadOut
+
+	if err := e.process.Start(); err != nil {
+		return fmt.Errorf("failed to start process %s: %s", e.Command, err)
+	}
+	return nil
+}
+
+func (e *Execd) Close() error {
+	if e.process != nil {
+		e.process.Stop()
+	}
+	return nil
+}
+
+// Write sends the serialized metrics to the stdin of the process. If the
+// process is being restarted the write fails and the metrics are kept in the
+// buffer until the next flush.
+func (e *Execd) Write(metrics []telegraf.Metric) error {
+	for _, m := range metrics {
+		b, err := e.serializer.Serialize(m)
+		if err != nil {
+			return fmt.Errorf("failed to serialize metric: %s", err)
+		}
+		if _, err := e.process.Write(b); err != nil {
+			return fmt.Errorf("error writing to process stdin: %s", err)
+		}
+	}
+	return nil
+}
+
+// cmdReadOut logs anything the process writes to its stdout.
+func (e *Execd) cmdReadOut(out io.Reader) {
+	scanner := process.NewScanner(out)
+	for scanner.Scan() {
+		e.Log.Info(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		e.Log.Errorf("Error reading stdout: %s", err)
+	}
+}
+
+func init() {
+	outputs.Add("execd", func() telegraf.Output {
+		return &Execd{
+			RestartDelay: internal.Duration{Duration: process.DefaultRestartDelay},
+		}
+	})
+}
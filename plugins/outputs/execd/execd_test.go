@@ -0,0 +1,68 @@
+// +build !windows
+
+package execd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/plugins/serializers"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteToProcess(t *testing.T) {
+	dir, err := ioutil.TempDir("", "execd")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "out")
+
+	serializer, err := serializers.NewInfluxSerializer()
+	require.NoError(t, err)
+
+	e := &Execd{
+		Command:      []string{"sh", "-c", "cat > " + out},
+		RestartDelay: internal.Duration{Duration: 10 * time.Millisecond},
+		Log:          testutil.Logger{},
+	}
+	e.SetSerializer(serializer)
+
+	require.NoError(t, e.Connect())
+	metrics := []telegraf.Metric{testutil.TestMetric(1, "first"), testutil.TestMetric(2, "second")}
+	require.NoError(t, e.Write(metrics))
+	require.NoError(t, e.Close())
+
+	b, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, string(metrics[0].Serialize())+string(metrics[1].Serialize()), string(b))
+}
+
+func TestWriteFailsWhileRestarting(t *testing.T) {
+	serializer, err := serializers.NewInfluxSerializer()
+	require.NoError(t, err)
+
+	e := &Execd{
+		Command:      []string{"true"},
+		RestartDelay: internal.Duration{Duration: time.Hour},
+		Log:          testutil.Logger{},
+	}
+	e.SetSerializer(serializer)
+
+	require.NoError(t, e.Connect())
+	defer e.Close()
+
+	// wait for the process to exit
+	for i := 0; i < 100; i++ {
+		if err = e.Write([]telegraf.Metric{testutil.TestMetric(1)}); err != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Error(t, err)
+}
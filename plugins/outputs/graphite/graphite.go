@@ -4,7 +4,6 @@ import (
 	"crypto/tls"
 	"errors"
 	"io"
-	"log"
 	"math/rand"
 	"net"
 	"time"
@@ -32,6 +31,8 @@ type Graphite struct {
 	// Skip SSL verification
 	InsecureSkipVerify bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	// tls config
 	tlsConfig *tls.Config
 }
@@ -117,22 +118,22 @@ func (g *Graphite) Description() string {
 // We can detect that by finding an eof
 // if not for this, we can happily write and flush without getting errors (in Go) but getting RST tcp packets back (!)
 // props to Tv via the authors of carbon-relay-ng` for this trick.
-func checkEOF(conn net.Conn) {
+func (g *Graphite) checkEOF(conn net.Conn) {
 	b := make([]byte, 1024)
 	conn.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
 	num, err := conn.Read(b)
 	if err == io.EOF {
-		log.Printf("E! Conn %s is closed. closing conn explicitly", conn)
+		g.Log.Errorf("Conn %s is closed. closing conn explicitly", conn)
 		conn.Close()
 		return
 	}
 	// just in case i misunderstand something or the remote behaves badly
 	if num != 0 {
-		log.Printf("I! conn %s .conn.Read data? did not expect that.  data: %s\n", conn, b[:num])
+		g.Log.Infof("conn %s .conn.Read data? did not expect that.  data: %s", conn, b[:num])
 	}
 	// Log non-timeout errors or close.
 	if e, ok := err.(net.Error); !(ok && e.Timeout()) {
-		log.Printf("E! conn %s checkEOF .conn.Read returned err != EOF, which is unexpected.  closing conn. error: %s\n", conn, err)
+		g.Log.Errorf("conn %s checkEOF .conn.Read returned err != EOF, which is unexpected.  closing conn. error: %s", conn, err)
 		conn.Close()
 	}
 }
@@ -150,7 +151,7 @@ func (g *Graphite) Write(metrics []telegraf.Metric) error {
 	for _, metric := range metrics {
 		buf, err := s.Serialize(metric)
 		if err != nil {
-			log.Printf("E! Error serializing some metrics to graphite: %s", err.Error())
+			g.Log.Errorf("Error serializing some metrics to graphite: %s", err.Error())
 		}
 		batch = append(batch, buf...)
 	}
@@ -159,7 +160,7 @@ func (g *Graphite) Write(metrics []telegraf.Metric) error {
 
 	// try to reconnect and retry to send
 	if err != nil {
-		log.Println("E! Graphite: Reconnecting and retrying: ")
+		g.Log.Error("Reconnecting and retrying: ")
 		g.Connect()
 		err = g.send(batch)
 	}
@@ -177,10 +178,10 @@ func (g *Graphite) send(batch []byte) error {
 		if g.Timeout > 0 {
 			g.conns[n].SetWriteDeadline(time.Now().Add(time.Duration(g.Timeout) * time.Second))
 		}
-		checkEOF(g.conns[n])
+		g.checkEOF(g.conns[n])
 		if _, e := g.conns[n].Write(batch); e != nil {
 			// Error
-			log.Println("E! Graphite Error: " + e.Error())
+			g.Log.Error("Graphite Error: " + e.Error())
 			// Close explicitely
 			g.conns[n].Close()
 			// Let's try the next one
@@ -10,6 +10,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +19,7 @@ import (
 func TestGraphiteError(t *testing.T) {
 	// Init plugin
 	g := Graphite{
+		Log:     testutil.Logger{},
 		Servers: []string{"127.0.0.1:2003", "127.0.0.1:12003"},
 		Prefix:  "my.prefix",
 	}
@@ -48,6 +50,7 @@ func TestGraphiteOK(t *testing.T) {
 
 	// Init plugin
 	g := Graphite{
+		Log:    testutil.Logger{},
 		Prefix: "my.prefix",
 	}
 
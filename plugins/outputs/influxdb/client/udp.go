@@ -3,9 +3,10 @@ package client
 import (
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/url"
+
+	"github.com/influxdata/telegraf"
 )
 
 const (
@@ -23,6 +24,9 @@ type UDPConfig struct {
 	// PayloadSize is the maximum size of a UDP client message, optional
 	// Tune this based on your network. Defaults to UDPPayloadSize.
 	PayloadSize int
+
+	// Log receives the warnings about dropped points.
+	Log telegraf.Logger
 }
 
 // NewUDP will return an instance of the telegraf UDP output plugin for influxdb
@@ -48,12 +52,13 @@ func NewUDP(config UDPConfig) (Client, error) {
 		size = UDPPayloadSize
 	}
 	buf := make([]byte, size)
-	return &udpClient{conn: conn, buffer: buf}, nil
+	return &udpClient{conn: conn, buffer: buf, log: config.Log}, nil
 }
 
 type udpClient struct {
 	conn   *net.UDPConn
 	buffer []byte
+	log    telegraf.Logger
 }
 
 // Query will send the provided query command to the client, returning an error if any issues arise
@@ -80,7 +85,7 @@ func (c *udpClient) WriteStream(r io.Reader) error {
 				return err
 			}
 		} else {
-			log.Printf("E! Could not fit point into UDP payload; dropping")
+			c.log.Error("Could not fit point into UDP payload; dropping")
 			// Scan forward until next line break to realign.
 			for {
 				nR, err := r.Read(c.buffer)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -69,6 +70,7 @@ func TestUDPClient_Write(t *testing.T) {
 	config = UDPConfig{
 		URL:         "udp://localhost:8199",
 		PayloadSize: 40,
+		Log:         testutil.Logger{},
 	}
 	client4, err := NewUDP(config)
 	assert.NoError(t, err)
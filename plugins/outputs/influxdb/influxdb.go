@@ -2,7 +2,6 @@ package influxdb
 
 import (
 	"fmt"
-	"log"
 	"math/rand"
 	"strings"
 	"time"
@@ -49,6 +48,8 @@ type InfluxDB struct {
 	// Precision is only here for legacy support. It will be ignored.
 	Precision string
 
+	Log telegraf.Logger `toml:"-"`
+
 	clients []client.Client
 }
 
@@ -118,6 +119,7 @@ func (i *InfluxDB) Connect() error {
 			config := client.UDPConfig{
 				URL:         u,
 				PayloadSize: i.UDPPayload,
+				Log:         i.Log,
 			}
 			c, err := client.NewUDP(config)
 			if err != nil {
@@ -154,7 +156,7 @@ func (i *InfluxDB) Connect() error {
 			err = c.Query(fmt.Sprintf(`CREATE DATABASE "%s"`, qiReplacer.Replace(i.Database)))
 			if err != nil {
 				if !strings.Contains(err.Error(), "Status Code [403]") {
-					log.Println("I! Database creation failed: " + err.Error())
+					i.Log.Info("Database creation failed: " + err.Error())
 				}
 				continue
 			}
@@ -195,13 +197,13 @@ func (i *InfluxDB) Write(metrics []telegraf.Metric) error {
 			if strings.Contains(e.Error(), "database not found") {
 				errc := i.clients[n].Query(fmt.Sprintf(`CREATE DATABASE "%s"`, qiReplacer.Replace(i.Database)))
 				if errc != nil {
-					log.Printf("E! Error: Database %s not found and failed to recreate\n",
+					i.Log.Errorf("Error: Database %s not found and failed to recreate",
 						i.Database)
 				}
 			}
 
 			if strings.Contains(e.Error(), "field type conflict") {
-				log.Printf("E! Field type conflict, dropping conflicted points: %s", e)
+				i.Log.Errorf("Field type conflict, dropping conflicted points: %s", e)
 				// setting err to nil, otherwise we will keep retrying and points
 				// w/ conflicting types will get stuck in the buffer forever.
 				err = nil
@@ -209,7 +211,7 @@ func (i *InfluxDB) Write(metrics []telegraf.Metric) error {
 			}
 
 			if strings.Contains(e.Error(), "points beyond retention policy") {
-				log.Printf("W! Points beyond retention policy: %s", e)
+				i.Log.Warnf("Points beyond retention policy: %s", e)
 				// This error is indicates the point is older than the
 				// retention policy permits, and is probably not a cause for
 				// concern.  Retrying will not help unless the retention
@@ -219,7 +221,7 @@ func (i *InfluxDB) Write(metrics []telegraf.Metric) error {
 			}
 
 			if strings.Contains(e.Error(), "unable to parse") {
-				log.Printf("E! Parse error; dropping points: %s", e)
+				i.Log.Errorf("Parse error; dropping points: %s", e)
 				// This error indicates a bug in Telegraf or InfluxDB parsing
 				// of line protocol.  Retries will not be successful.
 				err = nil
@@ -233,7 +235,7 @@ func (i *InfluxDB) Write(metrics []telegraf.Metric) error {
 			}
 
 			// Log write failure
-			log.Printf("E! InfluxDB Output Error: %s", e)
+			i.Log.Errorf("InfluxDB Output Error: %s", e)
 		} else {
 			err = nil
 			break
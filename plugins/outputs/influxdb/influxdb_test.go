@@ -38,6 +38,7 @@ func TestIdentQuoting(t *testing.T) {
 		defer ts.Close()
 
 		i := InfluxDB{
+			Log:      testutil.Logger{},
 			URLs:     []string{ts.URL},
 			Database: tc.database,
 		}
@@ -50,6 +51,7 @@ func TestIdentQuoting(t *testing.T) {
 
 func TestUDPInflux(t *testing.T) {
 	i := InfluxDB{
+		Log:  testutil.Logger{},
 		URLs: []string{"udp://localhost:8089"},
 	}
 
@@ -98,6 +100,7 @@ func TestHTTPInflux(t *testing.T) {
 
 func TestUDPConnectError(t *testing.T) {
 	i := InfluxDB{
+		Log:  testutil.Logger{},
 		URLs: []string{"udp://foobar:8089"},
 	}
 
@@ -105,6 +108,7 @@ func TestUDPConnectError(t *testing.T) {
 	require.Error(t, err)
 
 	i = InfluxDB{
+		Log:  testutil.Logger{},
 		URLs: []string{"udp://localhost:9999999"},
 	}
 
@@ -114,6 +118,7 @@ func TestUDPConnectError(t *testing.T) {
 
 func TestHTTPConnectError_InvalidURL(t *testing.T) {
 	i := InfluxDB{
+		Log:  testutil.Logger{},
 		URLs: []string{"http://foobar:8089"},
 	}
 
@@ -121,6 +126,7 @@ func TestHTTPConnectError_InvalidURL(t *testing.T) {
 	require.Error(t, err)
 
 	i = InfluxDB{
+		Log:  testutil.Logger{},
 		URLs: []string{"http://localhost:9999999"},
 	}
 
@@ -140,6 +146,7 @@ func TestHTTPConnectError_DatabaseCreateFail(t *testing.T) {
 	defer ts.Close()
 
 	i := InfluxDB{
+		Log:      testutil.Logger{},
 		URLs:     []string{ts.URL},
 		Database: "test",
 	}
@@ -167,6 +174,7 @@ func TestHTTPError_DatabaseNotFound(t *testing.T) {
 	defer ts.Close()
 
 	i := InfluxDB{
+		Log:      testutil.Logger{},
 		URLs:     []string{ts.URL},
 		Database: "test",
 	}
@@ -268,6 +276,7 @@ func TestHTTPError_WriteErrors(t *testing.T) {
 			defer ts.Close()
 
 			influx := InfluxDB{
+				Log:      testutil.Logger{},
 				URLs:     []string{ts.URL},
 				Database: "test",
 			}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"regexp"
 	"strings"
@@ -31,6 +30,8 @@ type Instrumental struct {
 	Timeout    internal.Duration
 	Debug      bool
 
+	Log telegraf.Logger `toml:"-"`
+
 	conn net.Conn
 }
 
@@ -121,7 +122,7 @@ func (i *Instrumental) Write(metrics []telegraf.Metric) error {
 
 		buf, err := s.Serialize(toSerialize)
 		if err != nil {
-			log.Printf("E! Error serializing a metric to Instrumental: %s", err)
+			i.Log.Errorf("Error serializing a metric to Instrumental: %s", err)
 		}
 
 		switch metricType {
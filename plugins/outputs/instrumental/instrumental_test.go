@@ -10,6 +10,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,6 +20,7 @@ func TestWrite(t *testing.T) {
 	TCPServer(t, &wg)
 
 	i := Instrumental{
+		Log:      testutil.Logger{},
 		Host:     "127.0.0.1",
 		ApiToken: "abc123token",
 		Prefix:   "my.prefix",
@@ -1,7 +1,6 @@
 package kinesis
 
 import (
-	"log"
 	"os"
 	"time"
 
@@ -30,7 +29,10 @@ type (
 		RandomPartitionKey bool       `toml:"use_random_partitionkey"`
 		Partition          *Partition `toml:"partition"`
 		Debug              bool       `toml:"debug"`
-		svc                *kinesis.Kinesis
+
+		Log telegraf.Logger `toml:"-"`
+
+		svc *kinesis.Kinesis
 
 		serializer serializers.Serializer
 	}
@@ -122,7 +124,7 @@ func (k *KinesisOutput) Connect() error {
 	// We attempt first to create a session to Kinesis using an IAMS role, if that fails it will fall through to using
 	// environment variables, and then Shared Credentials.
 	if k.Debug {
-		log.Printf("E! kinesis: Establishing a connection to Kinesis in %+v", k.Region)
+		k.Log.Errorf("Establishing a connection to Kinesis in %+v", k.Region)
 	}
 
 	credentialConfig := &internalaws.CredentialConfig{
@@ -144,21 +146,21 @@ func (k *KinesisOutput) Connect() error {
 	resp, err := svc.ListStreams(KinesisParams)
 
 	if err != nil {
-		log.Printf("E! kinesis: Error in ListSteams API call : %+v \n", err)
+		k.Log.Errorf("Error in ListSteams API call : %+v", err)
 	}
 
 	if checkstream(resp.StreamNames, k.StreamName) {
 		if k.Debug {
-			log.Printf("E! kinesis: Stream Exists")
+			k.Log.Errorf("Stream Exists")
 		}
 		k.svc = svc
 		return nil
 	} else {
-		log.Printf("E! kinesis : You have configured a StreamName %+v which does not exist. exiting.", k.StreamName)
+		k.Log.Errorf("You have configured a StreamName %+v which does not exist. exiting.", k.StreamName)
 		os.Exit(1)
 	}
 	if k.Partition == nil {
-		log.Print("E! kinesis : Deprecated paritionkey configuration in use, please consider using outputs.kinesis.partition")
+		k.Log.Error("Deprecated paritionkey configuration in use, please consider using outputs.kinesis.partition")
 	}
 	return err
 }
@@ -181,14 +183,14 @@ func writekinesis(k *KinesisOutput, r []*kinesis.PutRecordsRequestEntry) time.Du
 	if k.Debug {
 		resp, err := k.svc.PutRecords(payload)
 		if err != nil {
-			log.Printf("E! kinesis: Unable to write to Kinesis : %+v \n", err.Error())
+			k.Log.Errorf("Unable to write to Kinesis : %+v", err.Error())
 		}
-		log.Printf("E! %+v \n", resp)
+		k.Log.Errorf("%+v", resp)
 
 	} else {
 		_, err := k.svc.PutRecords(payload)
 		if err != nil {
-			log.Printf("E! kinesis: Unable to write to Kinesis : %+v \n", err.Error())
+			k.Log.Errorf("Unable to write to Kinesis : %+v", err.Error())
 		}
 	}
 	return time.Since(start)
@@ -208,9 +210,9 @@ func (k *KinesisOutput) getPartitionKey(metric telegraf.Metric) string {
 			if metric.HasTag(k.Partition.Key) {
 				return metric.Tags()[k.Partition.Key]
 			}
-			log.Printf("E! kinesis : You have configured a Partition using tag %+v which does not exist.", k.Partition.Key)
+			k.Log.Errorf("You have configured a Partition using tag %+v which does not exist.", k.Partition.Key)
 		default:
-			log.Printf("E! kinesis : You have configured a Partition method of %+v which is not supported", k.Partition.Method)
+			k.Log.Errorf("You have configured a Partition method of %+v which is not supported", k.Partition.Method)
 		}
 	}
 	if k.RandomPartitionKey {
@@ -249,7 +251,7 @@ func (k *KinesisOutput) Write(metrics []telegraf.Metric) error {
 		if sz == 500 {
 			// Max Messages Per PutRecordRequest is 500
 			elapsed := writekinesis(k, r)
-			log.Printf("E! Wrote a %+v point batch to Kinesis in %+v.\n", sz, elapsed)
+			k.Log.Errorf("Wrote a %+v point batch to Kinesis in %+v.", sz, elapsed)
 			sz = 0
 			r = nil
 		}
@@ -257,7 +259,7 @@ func (k *KinesisOutput) Write(metrics []telegraf.Metric) error {
 	}
 	if sz > 0 {
 		elapsed := writekinesis(k, r)
-		log.Printf("E! Wrote a %+v point batch to Kinesis in %+v.\n", sz, elapsed)
+		k.Log.Errorf("Wrote a %+v point batch to Kinesis in %+v.", sz, elapsed)
 	}
 
 	return nil
@@ -14,6 +14,7 @@ func TestPartitionKey(t *testing.T) {
 	testPoint := testutil.TestMetric(1)
 
 	k := KinesisOutput{
+		Log: testutil.Logger{},
 		Partition: &Partition{
 			Method: "static",
 			Key:    "-",
@@ -22,6 +23,7 @@ func TestPartitionKey(t *testing.T) {
 	assert.Equal("-", k.getPartitionKey(testPoint), "PartitionKey should be '-'")
 
 	k = KinesisOutput{
+		Log: testutil.Logger{},
 		Partition: &Partition{
 			Method: "tag",
 			Key:    "tag1",
@@ -30,6 +32,7 @@ func TestPartitionKey(t *testing.T) {
 	assert.Equal(testPoint.Tags()["tag1"], k.getPartitionKey(testPoint), "PartitionKey should be value of 'tag1'")
 
 	k = KinesisOutput{
+		Log: testutil.Logger{},
 		Partition: &Partition{
 			Method: "tag",
 			Key:    "doesnotexist",
@@ -38,6 +41,7 @@ func TestPartitionKey(t *testing.T) {
 	assert.Equal("", k.getPartitionKey(testPoint), "PartitionKey should be value of ''")
 
 	k = KinesisOutput{
+		Log: testutil.Logger{},
 		Partition: &Partition{
 			Method: "not supported",
 		},
@@ -45,6 +49,7 @@ func TestPartitionKey(t *testing.T) {
 	assert.Equal("", k.getPartitionKey(testPoint), "PartitionKey should be value of ''")
 
 	k = KinesisOutput{
+		Log: testutil.Logger{},
 		Partition: &Partition{
 			Method: "measurement",
 		},
@@ -52,6 +57,7 @@ func TestPartitionKey(t *testing.T) {
 	assert.Equal(testPoint.Name(), k.getPartitionKey(testPoint), "PartitionKey should be value of measurement name")
 
 	k = KinesisOutput{
+		Log: testutil.Logger{},
 		Partition: &Partition{
 			Method: "random",
 		},
@@ -62,11 +68,13 @@ func TestPartitionKey(t *testing.T) {
 	assert.Equal(uint(4), u.Version(), "PartitionKey should be UUIDv4")
 
 	k = KinesisOutput{
+		Log:          testutil.Logger{},
 		PartitionKey: "-",
 	}
 	assert.Equal("-", k.getPartitionKey(testPoint), "PartitionKey should be '-'")
 
 	k = KinesisOutput{
+		Log:                testutil.Logger{},
 		RandomPartitionKey: true,
 	}
 	partitionKey = k.getPartitionKey(testPoint)
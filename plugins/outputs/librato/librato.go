@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"regexp"
 
@@ -25,6 +24,9 @@ type Librato struct {
 	Template  string
 
 	APIUrl string
+
+	Log telegraf.Logger `toml:"-"`
+
 	client *http.Client
 }
 
@@ -106,12 +108,12 @@ func (l *Librato) Write(metrics []telegraf.Metric) error {
 		if gauges, err := l.buildGauges(m); err == nil {
 			for _, gauge := range gauges {
 				tempGauges = append(tempGauges, gauge)
-				log.Printf("D! Got a gauge: %v\n", gauge)
+				l.Log.Debugf("Got a gauge: %v", gauge)
 
 			}
 		} else {
-			log.Printf("I! unable to build Gauge for %s, skipping\n", m.Name())
-			log.Printf("D! Couldn't build gauge: %v\n", err)
+			l.Log.Infof("unable to build Gauge for %s, skipping", m.Name())
+			l.Log.Debugf("Couldn't build gauge: %v", err)
 
 		}
 	}
@@ -133,7 +135,7 @@ func (l *Librato) Write(metrics []telegraf.Metric) error {
 			return fmt.Errorf("unable to marshal Metrics, %s\n", err.Error())
 		}
 
-		log.Printf("D! Librato request: %v\n", string(metricsBytes))
+		l.Log.Debugf("Librato request: %v", string(metricsBytes))
 
 		req, err := http.NewRequest(
 			"POST",
@@ -149,7 +151,7 @@ func (l *Librato) Write(metrics []telegraf.Metric) error {
 
 		resp, err := l.client.Do(req)
 		if err != nil {
-			log.Printf("D! Error POSTing metrics: %v\n", err.Error())
+			l.Log.Debugf("Error POSTing metrics: %v", err.Error())
 			return fmt.Errorf("error POSTing metrics, %s\n", err.Error())
 		}
 		defer resp.Body.Close()
@@ -157,7 +159,7 @@ func (l *Librato) Write(metrics []telegraf.Metric) error {
 		if resp.StatusCode != 200 || l.Debug {
 			htmlData, err := ioutil.ReadAll(resp.Body)
 			if err != nil {
-				log.Printf("D! Couldn't get response! (%v)\n", err)
+				l.Log.Debugf("Couldn't get response! (%v)", err)
 			}
 			if resp.StatusCode != 200 {
 				return fmt.Errorf(
@@ -165,7 +167,7 @@ func (l *Librato) Write(metrics []telegraf.Metric) error {
 					resp.StatusCode,
 					string(htmlData))
 			}
-			log.Printf("D! Librato response: %v\n", string(htmlData))
+			l.Log.Debugf("Librato response: %v", string(htmlData))
 		}
 	}
 
@@ -222,7 +224,7 @@ func (l *Librato) buildGauges(m telegraf.Metric) ([]*Gauge, error) {
 		gauges = append(gauges, gauge)
 	}
 
-	log.Printf("D! Built gauges: %v\n", gauges)
+	l.Log.Debugf("Built gauges: %v", gauges)
 	return gauges, nil
 }
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,8 +20,14 @@ var (
 	fakeToken = "123456"
 )
 
+func newTestLibrato(apiURL string) *Librato {
+	l := NewLibrato(apiURL)
+	l.Log = testutil.Logger{}
+	return l
+}
+
 func fakeLibrato() *Librato {
-	l := NewLibrato(fakeURL)
+	l := newTestLibrato(fakeURL)
 	l.APIUser = fakeUser
 	l.APIToken = fakeToken
 	return l
@@ -34,7 +41,7 @@ func TestUriOverride(t *testing.T) {
 			}))
 	defer ts.Close()
 
-	l := NewLibrato(ts.URL)
+	l := newTestLibrato(ts.URL)
 	l.APIUser = "telegraf@influxdb.com"
 	l.APIToken = "123456"
 	err := l.Connect()
@@ -50,7 +57,7 @@ func TestBadStatusCode(t *testing.T) {
 		}))
 	defer ts.Close()
 
-	l := NewLibrato(ts.URL)
+	l := newTestLibrato(ts.URL)
 	l.APIUser = "telegraf@influxdb.com"
 	l.APIToken = "123456"
 	err := l.Connect()
@@ -140,7 +147,7 @@ func TestBuildGauge(t *testing.T) {
 		},
 	}
 
-	l := NewLibrato(fakeURL)
+	l := newTestLibrato(fakeURL)
 	for _, gt := range gaugeTests {
 		gauges, err := l.buildGauges(gt.ptIn)
 		if err != nil && gt.err == nil {
@@ -257,7 +264,7 @@ func TestBuildGaugeWithSource(t *testing.T) {
 		},
 	}
 
-	l := NewLibrato(fakeURL)
+	l := newTestLibrato(fakeURL)
 	for _, gt := range gaugeTests {
 		l.Template = gt.template
 		gauges, err := l.buildGauges(gt.ptIn)
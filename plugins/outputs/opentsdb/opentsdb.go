@@ -2,7 +2,6 @@ package opentsdb
 
 import (
 	"fmt"
-	"log"
 	"net"
 	"net/url"
 	"regexp"
@@ -36,6 +35,8 @@ type OpenTSDB struct {
 	Debug bool
 
 	Separator string
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 var sampleConfig = `
@@ -122,6 +123,7 @@ func (o *OpenTSDB) WriteHttp(metrics []telegraf.Metric, u *url.URL) error {
 		User:      u.User,
 		BatchSize: o.HttpBatchSize,
 		Debug:     o.Debug,
+		Log:       o.Log,
 	}
 
 	for _, m := range metrics {
@@ -134,7 +136,7 @@ func (o *OpenTSDB) WriteHttp(metrics []telegraf.Metric, u *url.URL) error {
 			case uint64:
 			case float64:
 			default:
-				log.Printf("D! OpenTSDB does not support metric value: [%s] of type [%T].\n", value, value)
+				o.Log.Debugf("OpenTSDB does not support metric value: [%s] of type [%T].", value, value)
 				continue
 			}
 
@@ -179,13 +181,13 @@ func (o *OpenTSDB) WriteTelnet(metrics []telegraf.Metric, u *url.URL) error {
 			case uint64:
 			case float64:
 			default:
-				log.Printf("D! OpenTSDB does not support metric value: [%s] of type [%T].\n", value, value)
+				o.Log.Debugf("OpenTSDB does not support metric value: [%s] of type [%T].", value, value)
 				continue
 			}
 
 			metricValue, buildError := buildValue(value)
 			if buildError != nil {
-				log.Printf("E! OpenTSDB: %s\n", buildError.Error())
+				o.Log.Errorf("%s", buildError.Error())
 				continue
 			}
 
@@ -7,10 +7,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+
+	"github.com/influxdata/telegraf"
 )
 
 type HttpMetric struct {
@@ -27,6 +28,7 @@ type openTSDBHttp struct {
 	User      *url.Userinfo
 	BatchSize int
 	Debug     bool
+	Log       telegraf.Logger
 
 	metricCounter int
 	body          requestBody
@@ -167,7 +169,7 @@ func (o *openTSDBHttp) flush() error {
 
 	if resp.StatusCode/100 != 2 {
 		if resp.StatusCode/100 == 4 {
-			log.Printf("E! Received %d status code. Dropping metrics to avoid overflowing buffer.",
+			o.Log.Errorf("Received %d status code. Dropping metrics to avoid overflowing buffer.",
 				resp.StatusCode)
 		} else {
 			return fmt.Errorf("Error when sending metrics. Received status %d",
@@ -152,6 +152,7 @@ func BenchmarkHttpSend(b *testing.B) {
 	}
 
 	o := &OpenTSDB{
+		Log:           testutil.Logger{},
 		Host:          ts.URL,
 		Port:          port,
 		Prefix:        "",
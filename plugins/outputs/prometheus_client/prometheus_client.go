@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/subtle"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 	"regexp"
@@ -62,6 +61,8 @@ type PrometheusClient struct {
 	Path               string            `toml:"path"`
 	CollectorsExclude  []string          `toml:"collectors_exclude"`
 
+	Log telegraf.Logger `toml:"-"`
+
 	server *http.Server
 
 	sync.Mutex
@@ -157,7 +158,7 @@ func (p *PrometheusClient) Start() error {
 			err = p.server.ListenAndServe()
 		}
 		if err != nil && err != http.ErrServerClosed {
-			log.Printf("E! Error creating prometheus metric endpoint, err: %s\n",
+			p.Log.Errorf("Error creating prometheus metric endpoint, err: %s",
 				err.Error())
 		}
 	}()
@@ -251,8 +252,8 @@ func (p *PrometheusClient) Collect(ch chan<- prometheus.Metric) {
 				metric, err = prometheus.NewConstMetric(desc, getPromValueType(family.TelegrafValueType), sample.Value, labels...)
 			}
 			if err != nil {
-				log.Printf("E! Error creating prometheus metric, "+
-					"key: %s, labels: %v,\nerr: %s\n",
+				p.Log.Errorf("Error creating prometheus metric, "+
+					"key: %s, labels: %v,\nerr: %s",
 					name, labels, err.Error())
 			}
 
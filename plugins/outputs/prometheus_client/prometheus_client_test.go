@@ -21,6 +21,7 @@ func setUnixTime(client *PrometheusClient, sec int64) {
 // NewClient initializes a PrometheusClient.
 func NewClient() *PrometheusClient {
 	return &PrometheusClient{
+		Log:                testutil.Logger{},
 		ExpirationInterval: internal.Duration{Duration: time.Second * 60},
 		fam:                make(map[string]*MetricFamily),
 		now:                time.Now,
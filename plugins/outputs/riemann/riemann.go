@@ -2,7 +2,6 @@ package riemann
 
 import (
 	"fmt"
-	"log"
 	"net/url"
 	"os"
 	"sort"
@@ -26,6 +25,8 @@ type Riemann struct {
 	DescriptionText        string
 	Timeout                internal.Duration
 
+	Log telegraf.Logger `toml:"-"`
+
 	client *raidman.Client
 }
 
@@ -149,14 +150,14 @@ func (r *Riemann) buildRiemannEvents(m telegraf.Metric) []*raidman.Event {
 		case string:
 			// only send string metrics if explicitly enabled, skip otherwise
 			if !r.StringAsState {
-				log.Printf("D! Riemann event states disabled, skipping metric value [%s]\n", value)
+				r.Log.Debugf("Riemann event states disabled, skipping metric value [%s]", value)
 				continue
 			}
 			event.State = value.(string)
 		case int, int64, uint64, float32, float64:
 			event.Metric = value
 		default:
-			log.Printf("D! Riemann does not support metric value [%s]\n", value)
+			r.Log.Debugf("Riemann does not support metric value [%s]", value)
 			continue
 		}
 
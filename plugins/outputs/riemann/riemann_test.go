@@ -6,13 +6,14 @@ import (
 
 	"github.com/amir/raidman"
 	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/require"
 )
 
 func TestAttributes(t *testing.T) {
 	tags := map[string]string{"tag1": "value1", "tag2": "value2"}
 
-	r := &Riemann{}
+	r := &Riemann{Log: testutil.Logger{}}
 	require.Equal(t,
 		map[string]string{"tag1": "value1", "tag2": "value2"},
 		r.attributes("test", tags))
@@ -26,6 +27,7 @@ func TestAttributes(t *testing.T) {
 
 func TestService(t *testing.T) {
 	r := &Riemann{
+		Log:       testutil.Logger{},
 		Separator: "/",
 	}
 	require.Equal(t, "test/value", r.service("test", "value"))
@@ -40,6 +42,7 @@ func TestTags(t *testing.T) {
 
 	// all tag values plus additional tag should be present
 	r := &Riemann{
+		Log:  testutil.Logger{},
 		Tags: []string{"test"},
 	}
 	require.Equal(t,
@@ -62,6 +65,7 @@ func TestTags(t *testing.T) {
 
 func TestMetricEvents(t *testing.T) {
 	r := &Riemann{
+		Log:                    testutil.Logger{},
 		TTL:                    20.0,
 		Separator:              "/",
 		MeasurementAsAttribute: false,
@@ -125,6 +129,7 @@ func TestMetricEvents(t *testing.T) {
 
 func TestStateEvents(t *testing.T) {
 	r := &Riemann{
+		Log:                    testutil.Logger{},
 		MeasurementAsAttribute: true,
 	}
 
@@ -2,7 +2,6 @@ package riemann_legacy
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"sort"
 	"strings"
@@ -12,13 +11,15 @@ import (
 	"github.com/influxdata/telegraf/plugins/outputs"
 )
 
-const deprecationMsg = "E! Error: this Riemann output plugin will be deprecated in a future release, see https://github.com/influxdata/telegraf/issues/1878 for more details & discussion."
+const deprecationMsg = "Error: this Riemann output plugin will be deprecated in a future release, see https://github.com/influxdata/telegraf/issues/1878 for more details & discussion."
 
 type Riemann struct {
 	URL       string
 	Transport string
 	Separator string
 
+	Log telegraf.Logger `toml:"-"`
+
 	client *raidman.Client
 }
 
@@ -32,7 +33,7 @@ var sampleConfig = `
 `
 
 func (r *Riemann) Connect() error {
-	log.Printf(deprecationMsg)
+	r.Log.Error(deprecationMsg)
 	c, err := raidman.Dial(r.Transport, r.URL)
 
 	if err != nil {
@@ -62,7 +63,7 @@ func (r *Riemann) Description() string {
 }
 
 func (r *Riemann) Write(metrics []telegraf.Metric) error {
-	log.Printf(deprecationMsg)
+	r.Log.Error(deprecationMsg)
 	if len(metrics) == 0 {
 		return nil
 	}
@@ -15,6 +15,7 @@ func TestConnectAndWrite(t *testing.T) {
 	url := testutil.GetLocalHost() + ":5555"
 
 	r := &Riemann{
+		Log:       testutil.Logger{},
 		URL:       url,
 		Transport: "tcp",
 	}
@@ -2,7 +2,6 @@ package socket_writer
 
 import (
 	"fmt"
-	"log"
 	"net"
 	"strings"
 
@@ -16,6 +15,8 @@ type SocketWriter struct {
 	Address         string
 	KeepAlivePeriod *internal.Duration
 
+	Log telegraf.Logger `toml:"-"`
+
 	serializers.Serializer
 
 	net.Conn
@@ -69,7 +70,7 @@ func (sw *SocketWriter) Connect() error {
 	}
 
 	if err := sw.setKeepAlive(c); err != nil {
-		log.Printf("unable to configure keep alive (%s): %s", sw.Address, err)
+		sw.Log.Warnf("Unable to configure keep alive (%s): %s", sw.Address, err)
 	}
 
 	sw.Conn = c
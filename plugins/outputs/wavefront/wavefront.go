@@ -3,7 +3,6 @@ package wavefront
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"net"
 	"regexp"
 	"strconv"
@@ -25,6 +24,8 @@ type Wavefront struct {
 	UseRegex        bool
 	SourceOverride  []string
 	StringToNumber  map[string][]map[string]float64
+
+	Log telegraf.Logger `toml:"-"`
 }
 
 // catch many of the invalid chars that could appear in a metric or tag name
@@ -126,7 +127,7 @@ func (w *Wavefront) Write(metrics []telegraf.Metric) error {
 	for _, m := range metrics {
 		for _, metricPoint := range buildMetrics(m, w) {
 			metricLine := formatMetricPoint(metricPoint, w)
-			log.Printf("D! Output [wavefront] %s", metricLine)
+			w.Log.Debugf("%s", metricLine)
 			_, err := connection.Write([]byte(metricLine))
 			if err != nil {
 				return fmt.Errorf("Wavefront: TCP writing error %s", err.Error())
@@ -165,7 +166,7 @@ func buildMetrics(m telegraf.Metric, w *Wavefront) []*MetricPoint {
 
 		metricValue, buildError := buildValue(value, metric.Metric, w)
 		if buildError != nil {
-			log.Printf("D! Output [wavefront] %s\n", buildError.Error())
+			w.Log.Debug(buildError.Error())
 			continue
 		}
 		metric.Value = metricValue
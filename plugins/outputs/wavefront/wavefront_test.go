@@ -13,6 +13,7 @@ import (
 // default config used by Tests
 func defaultWavefront() *Wavefront {
 	return &Wavefront{
+		Log:             testutil.Logger{},
 		Host:            "localhost",
 		Port:            2878,
 		Prefix:          "testWF.",
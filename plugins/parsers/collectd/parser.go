@@ -3,7 +3,6 @@ package collectd
 import (
 	"errors"
 	"fmt"
-	"log"
 	"os"
 
 	"collectd.org/api"
@@ -21,6 +20,8 @@ type CollectdParser struct {
 	// DefaultTags will be added to every parsed metric
 	DefaultTags map[string]string
 
+	Log telegraf.Logger `toml:"-"`
+
 	popts network.ParseOpts
 }
 
@@ -76,7 +77,7 @@ func (p *CollectdParser) Parse(buf []byte) ([]telegraf.Metric, error) {
 
 	metrics := []telegraf.Metric{}
 	for _, valueList := range valueLists {
-		metrics = append(metrics, UnmarshalValueList(valueList)...)
+		metrics = append(metrics, p.UnmarshalValueList(valueList)...)
 	}
 
 	if len(p.DefaultTags) > 0 {
@@ -111,7 +112,7 @@ func (p *CollectdParser) SetDefaultTags(tags map[string]string) {
 }
 
 // UnmarshalValueList translates a ValueList into a Telegraf metric.
-func UnmarshalValueList(vl *api.ValueList) []telegraf.Metric {
+func (p *CollectdParser) UnmarshalValueList(vl *api.ValueList) []telegraf.Metric {
 	timestamp := vl.Time.UTC()
 
 	var metrics []telegraf.Metric
@@ -147,7 +148,7 @@ func UnmarshalValueList(vl *api.ValueList) []telegraf.Metric {
 		// Drop invalid points
 		m, err := metric.New(name, tags, fields, timestamp)
 		if err != nil {
-			log.Printf("E! Dropping metric %v: %v", name, err)
+			p.Log.Errorf("Dropping metric %v: %v", name, err)
 			continue
 		}
 
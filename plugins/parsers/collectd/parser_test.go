@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
 )
 
 type AuthMap struct {
@@ -124,7 +125,7 @@ func TestParse(t *testing.T) {
 		bytes, err := buf.Bytes()
 		require.Nil(t, err)
 
-		parser := &CollectdParser{}
+		parser := &CollectdParser{Log: testutil.Logger{}}
 		require.Nil(t, err)
 		metrics, err := parser.Parse(bytes)
 		require.Nil(t, err)
@@ -139,7 +140,7 @@ func TestParse_DefaultTags(t *testing.T) {
 	bytes, err := buf.Bytes()
 	require.Nil(t, err)
 
-	parser := &CollectdParser{}
+	parser := &CollectdParser{Log: testutil.Logger{}}
 	parser.SetDefaultTags(map[string]string{
 		"foo": "bar",
 	})
@@ -151,7 +152,7 @@ func TestParse_DefaultTags(t *testing.T) {
 }
 
 func TestParse_SignSecurityLevel(t *testing.T) {
-	parser := &CollectdParser{}
+	parser := &CollectdParser{Log: testutil.Logger{}}
 	popts := &network.ParseOpts{
 		SecurityLevel: network.Sign,
 		PasswordLookup: &AuthMap{
@@ -206,7 +207,7 @@ func TestParse_SignSecurityLevel(t *testing.T) {
 }
 
 func TestParse_EncryptSecurityLevel(t *testing.T) {
-	parser := &CollectdParser{}
+	parser := &CollectdParser{Log: testutil.Logger{}}
 	popts := &network.ParseOpts{
 		SecurityLevel: network.Encrypt,
 		PasswordLookup: &AuthMap{
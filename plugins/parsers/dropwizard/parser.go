@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
@@ -49,6 +48,8 @@ type Parser struct {
 	Separator string
 	Templates []string
 
+	Log telegraf.Logger `toml:"-"`
+
 	templateEngine *templating.Engine
 }
 
@@ -134,7 +135,7 @@ func (p *Parser) readTags(buf []byte) map[string]string {
 		var tags map[string]string
 		err := json.Unmarshal(tagsBytes, &tags)
 		if err != nil {
-			log.Printf("W! failed to parse tags from JSON path '%s': %s\n", p.TagsPath, err)
+			p.Log.Warnf("failed to parse tags from JSON path '%s': %s", p.TagsPath, err)
 		} else if len(tags) > 0 {
 			return tags
 		}
@@ -242,7 +243,7 @@ func (p *Parser) readDWMetrics(metricType string, dwms interface{}, metrics []te
 		}
 		newMetrics, err := metric.ParseWithDefaultTime(metricsBuffer.Bytes(), time)
 		if err != nil {
-			log.Printf("W! failed to create metric of type '%s': %s\n", metricType, err)
+			p.Log.Warnf("failed to create metric of type '%s': %s", metricType, err)
 		}
 		return append(metrics, newMetrics...)
 	default:
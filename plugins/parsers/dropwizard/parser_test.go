@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
 
 	"fmt"
 	"time"
@@ -24,7 +25,7 @@ const validEmptyJSON = `
 `
 
 func TestParseValidEmptyJSON(t *testing.T) {
-	parser := Parser{}
+	parser := Parser{Log: testutil.Logger{}}
 
 	// Most basic vanilla test
 	metrics, err := parser.Parse([]byte(validEmptyJSON))
@@ -49,7 +50,7 @@ const validCounterJSON = `
 `
 
 func TestParseValidCounterJSON(t *testing.T) {
-	parser := Parser{}
+	parser := Parser{Log: testutil.Logger{}}
 
 	metrics, err := parser.Parse([]byte(validCounterJSON))
 	assert.NoError(t, err)
@@ -88,6 +89,7 @@ func TestParseValidEmbeddedCounterJSON(t *testing.T) {
 	timeFormat := "2006-01-02T15:04:05Z07:00"
 	metricTime, _ := time.Parse(timeFormat, "2017-02-22T15:33:03.662+03:00")
 	parser := Parser{
+		Log:                testutil.Logger{},
 		MetricRegistryPath: "metrics",
 		TagsPath:           "tags",
 		TimePath:           "time",
@@ -101,15 +103,16 @@ func TestParseValidEmbeddedCounterJSON(t *testing.T) {
 		"count": float64(1),
 	}, metrics[0].Fields())
 	assert.Equal(t, map[string]string{
-		"metric_type": "counter",
-		"tag1":        "green",
-		"tag2":        "yellow",
+		"metric_type":             "counter",
+		"tag1":                    "green",
+		"tag2":                    "yellow",
 		"tag3 space,comma=equals": "red ,=",
 	}, metrics[0].Tags())
 	assert.True(t, metricTime.Equal(metrics[0].Time()), fmt.Sprintf("%s should be equal to %s", metrics[0].Time(), metricTime))
 
 	// now test json tags through TagPathsMap
 	parser2 := Parser{
+		Log:                testutil.Logger{},
 		MetricRegistryPath: "metrics",
 		TagPathsMap:        map[string]string{"tag1": "tags.tag1"},
 		TimePath:           "time",
@@ -141,7 +144,7 @@ const validMeterJSON1 = `
 `
 
 func TestParseValidMeterJSON1(t *testing.T) {
-	parser := Parser{}
+	parser := Parser{Log: testutil.Logger{}}
 
 	metrics, err := parser.Parse([]byte(validMeterJSON1))
 	assert.NoError(t, err)
@@ -181,7 +184,7 @@ const validMeterJSON2 = `
 `
 
 func TestParseValidMeterJSON2(t *testing.T) {
-	parser := Parser{}
+	parser := Parser{Log: testutil.Logger{}}
 
 	metrics, err := parser.Parse([]byte(validMeterJSON2))
 	assert.NoError(t, err)
@@ -215,7 +218,7 @@ const validGaugeJSON = `
 `
 
 func TestParseValidGaugeJSON(t *testing.T) {
-	parser := Parser{}
+	parser := Parser{Log: testutil.Logger{}}
 
 	metrics, err := parser.Parse([]byte(validGaugeJSON))
 	assert.NoError(t, err)
@@ -254,7 +257,7 @@ const validHistogramJSON = `
 `
 
 func TestParseValidHistogramJSON(t *testing.T) {
-	parser := Parser{}
+	parser := Parser{Log: testutil.Logger{}}
 
 	metrics, err := parser.Parse([]byte(validHistogramJSON))
 	assert.NoError(t, err)
@@ -309,7 +312,7 @@ const validTimerJSON = `
 `
 
 func TestParseValidTimerJSON(t *testing.T) {
-	parser := Parser{}
+	parser := Parser{Log: testutil.Logger{}}
 
 	metrics, err := parser.Parse([]byte(validTimerJSON))
 	assert.NoError(t, err)
@@ -360,7 +363,7 @@ const validAllJSON = `
 `
 
 func TestParseValidAllJSON(t *testing.T) {
-	parser := Parser{}
+	parser := Parser{Log: testutil.Logger{}}
 
 	metrics, err := parser.Parse([]byte(validAllJSON))
 	assert.NoError(t, err)
@@ -369,7 +372,7 @@ func TestParseValidAllJSON(t *testing.T) {
 
 func TestTagParsingProblems(t *testing.T) {
 	// giving a wrong path results in empty tags
-	parser1 := Parser{MetricRegistryPath: "metrics", TagsPath: "tags1"}
+	parser1 := Parser{MetricRegistryPath: "metrics", TagsPath: "tags1", Log: testutil.Logger{}}
 	metrics1, err1 := parser1.Parse([]byte(validEmbeddedCounterJSON))
 	assert.NoError(t, err1)
 	assert.Len(t, metrics1, 1)
@@ -377,6 +380,7 @@ func TestTagParsingProblems(t *testing.T) {
 
 	// giving a wrong TagsPath falls back to TagPathsMap
 	parser2 := Parser{
+		Log:                testutil.Logger{},
 		MetricRegistryPath: "metrics",
 		TagsPath:           "tags1",
 		TagPathsMap:        map[string]string{"tag1": "tags.tag1"},
@@ -426,6 +430,7 @@ const sampleTemplateJSON = `
 
 func TestParseSampleTemplateJSON(t *testing.T) {
 	parser := Parser{
+		Log:       testutil.Logger{},
 		Separator: "_",
 		Templates: []string{
 			"jenkins.* measurement.metric.metric.field",
@@ -0,0 +1,174 @@
+package execd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/internal/process"
+	"github.com/influxdata/telegraf/plugins/parsers/influx"
+	"github.com/influxdata/telegraf/plugins/processors"
+	"github.com/influxdata/telegraf/plugins/serializers"
+	serializer "github.com/influxdata/telegraf/plugins/serializers/influx"
+)
+
+const sampleConfig = `
+  ## Program to run as daemon, followed by its arguments.
+  ## The program receives metrics on stdin and must write the processed
+  ## metrics to stdout, both in influx line protocol.
+  command = ["cat"]
+
+  ## Delay before the process is restarted after an unexpected termination.
+  ## The delay doubles on each consecutive failure, up to 5 minutes.
+  restart_delay = "10s"
+`
+
+const (
+	// maxBufferedMetrics is the number of metrics waiting to be written to
+	// the process above which Apply drops metrics rather than blocking.
+	maxBufferedMetrics = 1000
+	// drainTimeout is how long Stop waits for the process to read the
+	// buffered metrics.
+	drainTimeout = 5 * time.Second
+)
+
+// Execd streams metrics through an external process. Metrics are written to
+// the stdin of the process, and the metrics read from its stdout are passed
+// on to the next processor, so the process can modify, drop or add metrics
+// at any time.
+type Execd struct {
+	Command      []string
+	RestartDelay internal.Duration
+
+	Log telegraf.Logger `toml:"-"`
+
+	acc        telegraf.Accumulator
+	parser     *influx.InfluxParser
+	serializer serializers.Serializer
+	process    *process.Process
+
+	metricC chan telegraf.Metric
+	done    chan struct{}
+}
+
+func New() *Execd {
+	return &Execd{
+		RestartDelay: internal.Duration{Duration: process.DefaultRestartDelay},
+		parser:       &influx.InfluxParser{},
+		serializer:   &serializer.InfluxSerializer{},
+	}
+}
+
+func (e *Execd) SampleConfig() string {
+	return sampleConfig
+}
+
+func (e *Execd) Description() string {
+	return "Run executable as long-running processor plugin"
+}
+
+func (e *Execd) Start(acc telegraf.Accumulator) error {
+	e.acc = acc
+
+	var err error
+	e.process, err = process.New(e.Command, e.Log)
+	if err != nil {
+		return fmt.Errorf("error creating process: %s", err)
+	}
+	e.process.RestartDelay = e.RestartDelay.Duration
+	e.process.ReadStdout = e.cmdReadOut
+
+	if err := e.process.Start(); err != nil {
+		return fmt.Errorf("failed to start process %s: %s", e.Command, err)
+	}
+
+	e.metricC = make(chan telegraf.Metric, maxBufferedMetrics)
+	e.done = make(chan struct{})
+	go e.cmdWriteIn()
+	return nil
+}
+
+// Stop writes the buffered metrics to the process before stopping it, unless
+// the process stops reading them.
+func (e *Execd) Stop() {
+	close(e.metricC)
+	select {
+	case <-e.done:
+		e.process.Stop()
+	case <-time.After(drainTimeout):
+		e.Log.Errorf("Process is not reading, dropped %d metrics",
+			len(e.metricC))
+		for range e.metricC {
+		}
+		// closing the stdin of the process unblocks the pending write
+		e.process.Stop()
+		<-e.done
+	}
+}
+
+// Apply queues the metrics to be written to the process, they are added to
+// the accumulator once the process returns them. Metrics are dropped when the
+// process does not keep up with them.
+func (e *Execd) Apply(in ...telegraf.Metric) []telegraf.Metric {
+	dropped := 0
+	for _, m := range in {
+		select {
+		case e.metricC <- m:
+		default:
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		e.Log.Errorf("Process is not keeping up, dropped %d metrics", dropped)
+	}
+	return nil
+}
+
+func (e *Execd) cmdWriteIn() {
+	defer close(e.done)
+
+	for m := range e.metricC {
+		b, err := e.serializer.Serialize(m)
+		if err != nil {
+			e.Log.Errorf("Error serializing metric %s: %s", m.Name(), err)
+			continue
+		}
+		if _, err := e.process.Write(b); err != nil {
+			e.Log.Errorf("Error writing metric %s to process: %s",
+				m.Name(), err)
+		}
+	}
+}
+
+func (e *Execd) cmdReadOut(out io.Reader) {
+	scanner := process.NewScanner(out)
+	for scanner.Scan() {
+		metrics, err := e.parser.Parse(scanner.Bytes())
+		if err != nil {
+			e.acc.AddError(fmt.Errorf("parse error: %s", err))
+		}
+
+		for _, m := range metrics {
+			switch m.Type() {
+			case telegraf.Counter:
+				e.acc.AddCounter(m.Name(), m.Fields(), m.Tags(), m.Time())
+			case telegraf.Gauge:
+				e.acc.AddGauge(m.Name(), m.Fields(), m.Tags(), m.Time())
+			default:
+				e.acc.AddFields(m.Name(), m.Fields(), m.Tags(), m.Time())
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		e.acc.AddError(fmt.Errorf("error reading stdout: %s", err))
+	}
+}
+
+func init() {
+	processors.Add("execd", func() telegraf.Processor {
+		return New()
+	})
+}
@@ -0,0 +1,91 @@
+// +build !windows
+
+package execd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamThroughProcess(t *testing.T) {
+	e := New()
+	e.Log = testutil.Logger{}
+	// rename every metric, and drop the ones named "drop"
+	e.Command = []string{"sh", "-c", `grep --line-buffered -v '^drop' | sed -u 's/^cpu/renamed/'`}
+	e.RestartDelay = internal.Duration{Duration: 10 * time.Millisecond}
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Start(acc))
+	defer e.Stop()
+
+	now := time.Unix(1500000000, 0)
+	m1, err := metric.New("drop", nil, map[string]interface{}{"value": int64(1)}, now)
+	require.NoError(t, err)
+	m2, err := metric.New("cpu",
+		map[string]string{"cpu": "cpu0"},
+		map[string]interface{}{"usage_idle": 42.0},
+		now)
+	require.NoError(t, err)
+
+	assert.Empty(t, e.Apply(m1, m2))
+
+	acc.Wait(1)
+	acc.AssertContainsTaggedFields(t, "renamed",
+		map[string]interface{}{"usage_idle": 42.0},
+		map[string]string{"cpu": "cpu0"})
+	assert.True(t, acc.HasTimestamp("renamed", now))
+	assert.False(t, acc.HasMeasurement("drop"))
+}
+
+// errorLogger records the errors logged.
+type errorLogger struct {
+	testutil.Logger
+	mu     sync.Mutex
+	errors []string
+}
+
+func (l *errorLogger) Errorf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, fmt.Sprintf(format, args...))
+}
+
+func TestApplyDropsMetricsWhenProcessIsSlow(t *testing.T) {
+	log := &errorLogger{}
+	e := New()
+	e.Log = log
+	// the pipe and the buffer fill up before the process starts reading
+	e.Command = []string{"sh", "-c", "sleep 1; cat >/dev/null"}
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Start(acc))
+
+	now := time.Unix(1500000000, 0)
+	in := make([]telegraf.Metric, 10000)
+	for i := range in {
+		m, err := metric.New("cpu",
+			map[string]string{"cpu": "cpu0"},
+			map[string]interface{}{"usage_idle": 42.0},
+			now)
+		require.NoError(t, err)
+		in[i] = m
+	}
+	assert.Empty(t, e.Apply(in...))
+	e.Stop()
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	require.Len(t, log.errors, 1)
+	assert.True(t, strings.HasPrefix(log.errors[0],
+		"Process is not keeping up, dropped "), log.errors[0])
+}
@@ -0,0 +1,239 @@
+package starlark
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
+	"go.starlark.net/starlark"
+)
+
+// Metric is the Starlark representation of a telegraf.Metric. The tags and
+// fields are exposed as regular Starlark dictionaries, and are converted back
+// to a telegraf.Metric once the script returns.
+type Metric struct {
+	name   string
+	tags   *starlark.Dict
+	fields *starlark.Dict
+	time   int64
+	mType  telegraf.ValueType
+	frozen bool
+}
+
+// wrapMetric converts a telegraf.Metric into a Starlark value.
+func wrapMetric(m telegraf.Metric) (*Metric, error) {
+	sm := &Metric{
+		name:   m.Name(),
+		tags:   starlark.NewDict(len(m.Tags())),
+		fields: starlark.NewDict(len(m.Fields())),
+		time:   m.UnixNano(),
+		mType:  m.Type(),
+	}
+
+	for k, v := range m.Tags() {
+		if err := sm.tags.SetKey(starlark.String(k), starlark.String(v)); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range m.Fields() {
+		sv, err := toStarlarkValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %s", k, err)
+		}
+		if err := sm.fields.SetKey(starlark.String(k), sv); err != nil {
+			return nil, err
+		}
+	}
+
+	return sm, nil
+}
+
+// newMetric creates a Starlark metric with no tags or fields.
+func newMetric(name string) *Metric {
+	return &Metric{
+		name:   name,
+		tags:   starlark.NewDict(0),
+		fields: starlark.NewDict(0),
+		time:   time.Now().UnixNano(),
+		mType:  telegraf.Untyped,
+	}
+}
+
+// Unwrap converts the Starlark metric back into a telegraf.Metric.
+func (m *Metric) Unwrap() (telegraf.Metric, error) {
+	tags := make(map[string]string, m.tags.Len())
+	for _, item := range m.tags.Items() {
+		k, ok := starlark.AsString(item[0])
+		if !ok {
+			return nil, fmt.Errorf("tag key must be a string, not %s", item[0].Type())
+		}
+		v, ok := starlark.AsString(item[1])
+		if !ok {
+			return nil, fmt.Errorf("tag %q must be a string, not %s", k, item[1].Type())
+		}
+		tags[k] = v
+	}
+
+	fields := make(map[string]interface{}, m.fields.Len())
+	for _, item := range m.fields.Items() {
+		k, ok := starlark.AsString(item[0])
+		if !ok {
+			return nil, fmt.Errorf("field key must be a string, not %s", item[0].Type())
+		}
+		v, err := toGoValue(item[1])
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %s", k, err)
+		}
+		fields[k] = v
+	}
+
+	if len(fields) == 0 {
+		return nil, errors.New("metric has no fields")
+	}
+
+	return metric.New(m.name, tags, fields, time.Unix(0, m.time), m.mType)
+}
+
+func (m *Metric) String() string {
+	return fmt.Sprintf("Metric(%q, tags=%s, fields=%s, time=%d)",
+		m.name, m.tags.String(), m.fields.String(), m.time)
+}
+
+func (m *Metric) Type() string {
+	return "Metric"
+}
+
+func (m *Metric) Freeze() {
+	m.frozen = true
+	m.tags.Freeze()
+	m.fields.Freeze()
+}
+
+func (m *Metric) Truth() starlark.Bool {
+	return true
+}
+
+func (m *Metric) Hash() (uint32, error) {
+	return 0, errors.New("unhashable type: Metric")
+}
+
+var metricAttrNames = []string{"fields", "name", "tags", "time"}
+
+// Attr implements the starlark.HasAttrs interface.
+func (m *Metric) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "name":
+		return starlark.String(m.name), nil
+	case "tags":
+		return m.tags, nil
+	case "fields":
+		return m.fields, nil
+	case "time":
+		return starlark.MakeInt64(m.time), nil
+	}
+	return nil, nil
+}
+
+// AttrNames implements the starlark.HasAttrs interface.
+func (m *Metric) AttrNames() []string {
+	return metricAttrNames
+}
+
+// SetField implements the starlark.HasSetField interface.
+func (m *Metric) SetField(name string, value starlark.Value) error {
+	if m.frozen {
+		return errors.New("cannot modify frozen metric")
+	}
+
+	switch name {
+	case "name":
+		v, ok := starlark.AsString(value)
+		if !ok {
+			return fmt.Errorf("name must be a string, not %s", value.Type())
+		}
+		m.name = v
+	case "tags", "fields":
+		d, ok := value.(*starlark.Dict)
+		if !ok {
+			return fmt.Errorf("%s must be a dict, not %s", name, value.Type())
+		}
+		if name == "tags" {
+			m.tags = d
+		} else {
+			m.fields = d
+		}
+	case "time":
+		i, ok := value.(starlark.Int)
+		if !ok {
+			return fmt.Errorf("time must be an int, not %s", value.Type())
+		}
+		ns, ok := i.Int64()
+		if !ok {
+			return errors.New("time is out of range")
+		}
+		m.time = ns
+	default:
+		return starlark.NoSuchAttrError(
+			fmt.Sprintf("Metric has no field %q, valid fields are: %s",
+				name, strings.Join(metricAttrNames, ", ")))
+	}
+	return nil
+}
+
+// deepcopy returns a copy of the metric that shares no state with it.
+func (m *Metric) deepcopy() *Metric {
+	c := &Metric{
+		name:   m.name,
+		tags:   starlark.NewDict(m.tags.Len()),
+		fields: starlark.NewDict(m.fields.Len()),
+		time:   m.time,
+		mType:  m.mType,
+	}
+	for _, item := range m.tags.Items() {
+		c.tags.SetKey(item[0], item[1])
+	}
+	for _, item := range m.fields.Items() {
+		c.fields.SetKey(item[0], item[1])
+	}
+	return c
+}
+
+func toStarlarkValue(v interface{}) (starlark.Value, error) {
+	switch v := v.(type) {
+	case int64:
+		return starlark.MakeInt64(v), nil
+	case uint64:
+		return starlark.MakeUint64(v), nil
+	case float64:
+		return starlark.Float(v), nil
+	case string:
+		return starlark.String(v), nil
+	case bool:
+		return starlark.Bool(v), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+func toGoValue(v starlark.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case starlark.Int:
+		if i, ok := v.Int64(); ok {
+			return i, nil
+		}
+		if u, ok := v.Uint64(); ok {
+			return u, nil
+		}
+		return nil, errors.New("int is out of range")
+	case starlark.Float:
+		return float64(v), nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Bool:
+		return bool(v), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", v.Type())
+}
@@ -0,0 +1,205 @@
+package starlark
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/processors"
+	"go.starlark.net/starlark"
+)
+
+const description = "Process metrics using a Starlark script"
+
+// maxExecutionSteps limits the computation of the script when it is loaded
+// and of each call to apply, so that a script that does not terminate fails
+// rather than blocking the processors.
+const maxExecutionSteps = 10000000
+
+var sampleConfig = `
+  ## The Starlark source can be set as a string in this configuration file, or
+  ## by referencing a file containing the script.  Only one source or script
+  ## should be set at once.
+  ##
+  ## Source of the Starlark script.
+  source = '''
+def apply(metric):
+	return metric
+'''
+
+  ## File containing a Starlark script.
+  # script = "/usr/local/bin/myscript.star"
+`
+
+// Starlark runs a Starlark script against every metric. The script must
+// define an apply function, which receives a metric and returns None to drop
+// it, a metric, or a list of metrics.
+type Starlark struct {
+	Source string
+	Script string
+
+	Log telegraf.Logger `toml:"-"`
+
+	thread  *starlark.Thread
+	applyFn starlark.Value
+}
+
+func (s *Starlark) SampleConfig() string {
+	return sampleConfig
+}
+
+func (s *Starlark) Description() string {
+	return description
+}
+
+// Init compiles the script and looks up the apply function. The state dict
+// is predeclared rather than global so that it is not frozen once the
+// script has been executed, allowing it to be modified by apply.
+func (s *Starlark) Init() error {
+	var src interface{}
+	filename := "processors.starlark"
+	switch {
+	case s.Source != "" && s.Script != "":
+		return errors.New("both source and script are set")
+	case s.Source != "":
+		src = s.Source
+	case s.Script != "":
+		b, err := ioutil.ReadFile(s.Script)
+		if err != nil {
+			return err
+		}
+		src = b
+		filename = s.Script
+	default:
+		return errors.New("one of source or script must be set")
+	}
+
+	s.thread = &starlark.Thread{
+		Name: "processors.starlark",
+		Print: func(_ *starlark.Thread, msg string) {
+			s.Log.Info(msg)
+		},
+	}
+	s.thread.SetMaxExecutionSteps(maxExecutionSteps)
+
+	predeclared := starlark.StringDict{
+		"Metric":   starlark.NewBuiltin("Metric", builtinMetric),
+		"deepcopy": starlark.NewBuiltin("deepcopy", builtinDeepcopy),
+		"state":    starlark.NewDict(0),
+	}
+
+	globals, err := starlark.ExecFile(s.thread, filename, src, predeclared)
+	if err != nil {
+		if evalErr, ok := err.(*starlark.EvalError); ok {
+			return errors.New(evalErr.Backtrace())
+		}
+		return err
+	}
+
+	applyFn, ok := globals["apply"]
+	if !ok {
+		return errors.New("apply function not defined")
+	}
+	if _, ok := applyFn.(*starlark.Function); !ok {
+		return fmt.Errorf("apply is a %s, not a function", applyFn.Type())
+	}
+	s.applyFn = applyFn
+	return nil
+}
+
+func (s *Starlark) Apply(in ...telegraf.Metric) []telegraf.Metric {
+	out := make([]telegraf.Metric, 0, len(in))
+	for _, m := range in {
+		metrics, err := s.call(m)
+		if err != nil {
+			s.Log.Errorf("Error processing metric %s: %s", m.Name(), err)
+			continue
+		}
+		out = append(out, metrics...)
+	}
+	return out
+}
+
+// call runs the apply function on a single metric.
+func (s *Starlark) call(m telegraf.Metric) ([]telegraf.Metric, error) {
+	sm, err := wrapMetric(m)
+	if err != nil {
+		return nil, err
+	}
+
+	// the steps are counted for each call rather than for the lifetime of
+	// the thread, and the thread is cancelled once they are exceeded
+	s.thread.Steps = 0
+	s.thread.Uncancel()
+	rv, err := starlark.Call(s.thread, s.applyFn, starlark.Tuple{sm}, nil)
+	if err != nil {
+		if evalErr, ok := err.(*starlark.EvalError); ok {
+			return nil, errors.New(evalErr.Backtrace())
+		}
+		return nil, err
+	}
+
+	switch rv := rv.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case *Metric:
+		nm, err := rv.Unwrap()
+		if err != nil {
+			return nil, err
+		}
+		return []telegraf.Metric{nm}, nil
+	case *starlark.List:
+		metrics := make([]telegraf.Metric, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			v, ok := rv.Index(i).(*Metric)
+			if !ok {
+				return nil, fmt.Errorf("apply returned a list containing a %s, "+
+					"expected only Metric", rv.Index(i).Type())
+			}
+			nm, err := v.Unwrap()
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, nm)
+		}
+		return metrics, nil
+	}
+	return nil, fmt.Errorf("apply returned a %s, expected None, Metric or "+
+		"a list of Metric", rv.Type())
+}
+
+// builtinMetric implements Metric(name), creating a new metric.
+func builtinMetric(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+	var name starlark.String
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &name); err != nil {
+		return nil, err
+	}
+	return newMetric(string(name)), nil
+}
+
+// builtinDeepcopy implements deepcopy(metric), copying a metric so that it
+// can be modified independently from the original.
+func builtinDeepcopy(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+	var sm *Metric
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &sm); err != nil {
+		return nil, err
+	}
+	return sm.deepcopy(), nil
+}
+
+func init() {
+	processors.Add("starlark", func() telegraf.Processor {
+		return &Starlark{}
+	})
+}
@@ -0,0 +1,219 @@
+package starlark
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMetric(
+	t *testing.T,
+	name string,
+	tags map[string]string,
+	fields map[string]interface{},
+	tm time.Time,
+) telegraf.Metric {
+	m, err := metric.New(name, tags, fields, tm)
+	require.NoError(t, err)
+	return m
+}
+
+var now = time.Unix(1500000000, 0)
+
+func TestPassthrough(t *testing.T) {
+	s := &Starlark{Source: `
+def apply(metric):
+	return metric
+`}
+	s.Log = testutil.Logger{}
+	require.NoError(t, s.Init())
+
+	in := testMetric(t, "cpu",
+		map[string]string{"cpu": "cpu0"},
+		map[string]interface{}{"usage_idle": 42.0, "count": int64(1), "up": true, "state": "ok"},
+		now)
+	out := s.Apply(in)
+	require.Len(t, out, 1)
+	assert.Equal(t, "cpu", out[0].Name())
+	assert.Equal(t, in.Tags(), out[0].Tags())
+	assert.Equal(t, in.Fields(), out[0].Fields())
+	assert.Equal(t, now, out[0].Time())
+}
+
+func TestModify(t *testing.T) {
+	s := &Starlark{Source: `
+def apply(metric):
+	metric.name = "processor_" + metric.name
+	metric.tags["host"] = metric.tags.pop("hostname")
+	metric.fields["usage_busy"] = 100 - metric.fields["usage_idle"]
+	metric.time = metric.time - 1000000000
+	return metric
+`}
+	s.Log = testutil.Logger{}
+	require.NoError(t, s.Init())
+
+	out := s.Apply(testMetric(t, "cpu",
+		map[string]string{"hostname": "example.org"},
+		map[string]interface{}{"usage_idle": 40.0},
+		now))
+	require.Len(t, out, 1)
+	assert.Equal(t, "processor_cpu", out[0].Name())
+	assert.Equal(t, map[string]string{"host": "example.org"}, out[0].Tags())
+	assert.Equal(t, map[string]interface{}{"usage_idle": 40.0, "usage_busy": 60.0},
+		out[0].Fields())
+	assert.Equal(t, now.Add(-time.Second), out[0].Time())
+}
+
+func TestDropAndEmit(t *testing.T) {
+	s := &Starlark{Source: `
+def apply(metric):
+	if metric.name == "drop":
+		return None
+	if metric.name == "split":
+		result = []
+		for k, v in metric.fields.items():
+			m = Metric(metric.name + "_" + k)
+			m.tags = metric.tags
+			m.fields["value"] = v
+			m.time = metric.time
+			result.append(m)
+		return result
+	return [metric, deepcopy(metric)]
+`}
+	s.Log = testutil.Logger{}
+	require.NoError(t, s.Init())
+
+	out := s.Apply(
+		testMetric(t, "drop", nil, map[string]interface{}{"value": int64(1)}, now),
+		testMetric(t, "split", map[string]string{"a": "b"},
+			map[string]interface{}{"x": int64(1), "y": int64(2)}, now),
+		testMetric(t, "double", nil, map[string]interface{}{"value": int64(1)}, now),
+	)
+	require.Len(t, out, 4)
+
+	names := []string{}
+	for _, m := range out {
+		names = append(names, m.Name())
+	}
+	sort.Strings(names[:2])
+	assert.Equal(t, []string{"split_x", "split_y", "double", "double"}, names)
+	for _, m := range out[:2] {
+		assert.Equal(t, map[string]string{"a": "b"}, m.Tags())
+		assert.Equal(t, now, m.Time())
+	}
+}
+
+func TestState(t *testing.T) {
+	s := &Starlark{Source: `
+def apply(metric):
+	last = state.get(metric.name)
+	state[metric.name] = metric.fields["value"]
+	if last != None:
+		metric.fields["delta"] = metric.fields["value"] - last
+	return metric
+`}
+	s.Log = testutil.Logger{}
+	require.NoError(t, s.Init())
+
+	out := s.Apply(testMetric(t, "m", nil, map[string]interface{}{"value": int64(10)}, now))
+	require.Len(t, out, 1)
+	assert.Equal(t, map[string]interface{}{"value": int64(10)}, out[0].Fields())
+
+	out = s.Apply(testMetric(t, "m", nil, map[string]interface{}{"value": int64(15)}, now))
+	require.Len(t, out, 1)
+	assert.Equal(t, map[string]interface{}{"value": int64(15), "delta": int64(5)},
+		out[0].Fields())
+}
+
+func TestErrorsDropMetric(t *testing.T) {
+	s := &Starlark{Source: `
+def apply(metric):
+	if metric.name == "fail":
+		fail("failed")
+	if metric.name == "badtag":
+		metric.tags["tag"] = 1
+	if metric.name == "nofields":
+		metric.fields.clear()
+	if metric.name == "badreturn":
+		return 42
+	return metric
+`}
+	s.Log = testutil.Logger{}
+	require.NoError(t, s.Init())
+
+	out := s.Apply(
+		testMetric(t, "fail", nil, map[string]interface{}{"value": int64(1)}, now),
+		testMetric(t, "badtag", nil, map[string]interface{}{"value": int64(1)}, now),
+		testMetric(t, "nofields", nil, map[string]interface{}{"value": int64(1)}, now),
+		testMetric(t, "badreturn", nil, map[string]interface{}{"value": int64(1)}, now),
+		testMetric(t, "ok", nil, map[string]interface{}{"value": int64(1)}, now),
+	)
+	require.Len(t, out, 1)
+	assert.Equal(t, "ok", out[0].Name())
+}
+
+func TestInitError(t *testing.T) {
+	tests := []*Starlark{
+		&Starlark{},
+		&Starlark{Source: `def apply(metric):`},
+		&Starlark{Source: `x = 1`},
+		&Starlark{Source: `apply = 1`},
+		&Starlark{Source: `load("other.star", "x")`},
+		&Starlark{Source: `def apply(metric): pass`, Script: "script.star"},
+		&Starlark{Script: "/nonexistent/script.star"},
+		&Starlark{Source: `
+x = [i for i in range(1000000000)]
+
+def apply(metric):
+	return metric
+`},
+	}
+
+	for _, s := range tests {
+		s.Log = testutil.Logger{}
+		assert.Error(t, s.Init())
+	}
+}
+
+func TestInfiniteLoop(t *testing.T) {
+	s := &Starlark{Source: `
+def apply(metric):
+	if metric.name == "loop":
+		for i in range(1000000000):
+			pass
+	return metric
+`}
+	s.Log = testutil.Logger{}
+	require.NoError(t, s.Init())
+
+	out := s.Apply(
+		testMetric(t, "loop", nil, map[string]interface{}{"value": int64(1)}, now),
+		testMetric(t, "ok", nil, map[string]interface{}{"value": int64(1)}, now),
+	)
+	require.Len(t, out, 1)
+	assert.Equal(t, "ok", out[0].Name())
+}
+
+func TestScriptFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "starlark")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("def apply(metric):\n\tmetric.name = \"renamed\"\n\treturn metric\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	s := &Starlark{Script: f.Name()}
+	s.Log = testutil.Logger{}
+	require.NoError(t, s.Init())
+	out := s.Apply(testMetric(t, "cpu", nil, map[string]interface{}{"value": int64(1)}, now))
+	require.Len(t, out, 1)
+	assert.Equal(t, "renamed", out[0].Name())
+}
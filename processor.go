@@ -10,3 +10,27 @@ type Processor interface {
 	// Apply the filter to the given metric
 	Apply(in ...Metric) []Metric
 }
+
+// StreamingProcessor is a Processor that emits metrics asynchronously, for
+// example because they are handled by an external process. Metrics passed to
+// Apply are not returned, instead the processor adds them to the accumulator
+// given to Start once they have been processed. The metrics added to the
+// accumulator continue through the processors that follow. The aggregates
+// are not passed to streaming processors that run before the aggregators.
+type StreamingProcessor interface {
+	// SampleConfig returns the default configuration of the Processor
+	SampleConfig() string
+
+	// Description returns a one-sentence description on the Processor
+	Description() string
+
+	// Apply sends the given metrics to be processed
+	Apply(in ...Metric) []Metric
+
+	// Start starts the processor, processed metrics are added to acc
+	Start(acc Accumulator) error
+
+	// Stop stops the processor, no metrics may be added to the accumulator
+	// once Stop returns
+	Stop()
+}
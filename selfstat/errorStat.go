@@ -0,0 +1,43 @@
+package selfstat
+
+import (
+	"sync"
+	"time"
+)
+
+// ErrorStat counts the errors of a plugin and keeps the last one.
+type ErrorStat struct {
+	measurement string
+	tags        map[string]string
+	count       Stat
+
+	mu      sync.Mutex
+	message string
+	time    time.Time
+}
+
+// Set counts err and records it as the last error, nil is ignored.
+func (e *ErrorStat) Set(err error) {
+	if err == nil {
+		return
+	}
+	e.count.Incr(1)
+
+	e.mu.Lock()
+	e.message = err.Error()
+	e.time = time.Now()
+	e.mu.Unlock()
+}
+
+// Count returns the number of errors.
+func (e *ErrorStat) Count() int64 {
+	return e.count.Get()
+}
+
+// Last returns the message of the last error and when it occurred. The
+// message is empty if there has not been any error.
+func (e *ErrorStat) Last() (string, time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.message, e.time
+}
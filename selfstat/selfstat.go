@@ -87,13 +87,26 @@ func RegisterTiming(measurement, field string, tags map[string]string) Stat {
 	})
 }
 
+// RegisterError registers an ErrorStat for the given measurement and tags. It
+// counts the errors in the "errors" field of the measurement, and adds the
+// last error as the "last_error" and "last_error_time" fields. If given an
+// identical measurement, it will return the ErrorStat that's already been
+// registered.
+func RegisterError(measurement string, tags map[string]string) *ErrorStat {
+	return registry.registerError(&ErrorStat{
+		measurement: "internal_" + measurement,
+		tags:        tags,
+		count:       Register(measurement, "errors", tags),
+	})
+}
+
 // Metrics returns all registered stats as telegraf metrics.
 func Metrics() []telegraf.Metric {
 	registry.mu.Lock()
 	now := time.Now()
 	metrics := make([]telegraf.Metric, len(registry.stats))
 	i := 0
-	for _, stats := range registry.stats {
+	for k, stats := range registry.stats {
 		if len(stats) > 0 {
 			var tags map[string]string
 			var name string
@@ -107,6 +120,12 @@ func Metrics() []telegraf.Metric {
 				fields[fieldname] = stat.Get()
 				j++
 			}
+			if e, ok := registry.errors[k]; ok {
+				if msg, t := e.Last(); msg != "" {
+					fields["last_error"] = msg
+					fields["last_error_time"] = t.UnixNano()
+				}
+			}
 			metric, err := metric.New(name, tags, fields, now)
 			if err != nil {
 				log.Printf("E! Error creating selfstat metric: %s", err)
@@ -121,8 +140,9 @@ func Metrics() []telegraf.Metric {
 }
 
 type rgstry struct {
-	stats map[uint64]map[string]Stat
-	mu    sync.Mutex
+	stats  map[uint64]map[string]Stat
+	errors map[uint64]*ErrorStat
+	mu     sync.Mutex
 }
 
 func (r *rgstry) register(s Stat) Stat {
@@ -162,8 +182,20 @@ func key(measurement string, tags map[string]string) uint64 {
 	return h.Sum64()
 }
 
+func (r *rgstry) registerError(e *ErrorStat) *ErrorStat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := key(e.measurement, e.tags)
+	if existing, ok := r.errors[k]; ok {
+		return existing
+	}
+	r.errors[k] = e
+	return e
+}
+
 func init() {
 	registry = &rgstry{
-		stats: make(map[uint64]map[string]Stat),
+		stats:  make(map[uint64]map[string]Stat),
+		errors: make(map[uint64]*ErrorStat),
 	}
 }
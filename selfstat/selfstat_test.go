@@ -1,6 +1,7 @@
 package selfstat
 
 import (
+	"errors"
 	"sync"
 	"testing"
 
@@ -19,7 +20,8 @@ var (
 // testCleanup resets the global registry for test cleanup & unlocks the test lock
 func testCleanup() {
 	registry = &rgstry{
-		stats: make(map[uint64]map[string]Stat),
+		stats:  make(map[uint64]map[string]Stat),
+		errors: make(map[uint64]*ErrorStat),
 	}
 	testLock.Unlock()
 }
@@ -219,3 +221,42 @@ func TestRegisterMetricsAndVerify(t *testing.T) {
 		},
 	)
 }
+
+func TestRegisterError(t *testing.T) {
+	testLock.Lock()
+	defer testCleanup()
+
+	e := RegisterError("test_errors", map[string]string{"test": "foo"})
+	assert.Equal(t, e, RegisterError("test_errors", map[string]string{"test": "foo"}))
+
+	acc := testutil.Accumulator{}
+	acc.AddMetrics(Metrics())
+	acc.AssertContainsTaggedFields(t, "internal_test_errors",
+		map[string]interface{}{
+			"errors": int64(0),
+		},
+		map[string]string{
+			"test": "foo",
+		},
+	)
+
+	e.Set(nil)
+	e.Set(errors.New("first"))
+	e.Set(errors.New("second"))
+	assert.Equal(t, int64(2), e.Count())
+	msg, last := e.Last()
+	assert.Equal(t, "second", msg)
+
+	acc = testutil.Accumulator{}
+	acc.AddMetrics(Metrics())
+	acc.AssertContainsTaggedFields(t, "internal_test_errors",
+		map[string]interface{}{
+			"errors":          int64(2),
+			"last_error":      "second",
+			"last_error_time": last.UnixNano(),
+		},
+		map[string]string{
+			"test": "foo",
+		},
+	)
+}